@@ -1,16 +1,37 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/luis-octavius/cintia/internal/account"
+	"github.com/luis-octavius/cintia/internal/apikey"
 	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/luis-octavius/cintia/internal/attachment"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/calendar"
+	"github.com/luis-octavius/cintia/internal/contact"
 	"github.com/luis-octavius/cintia/internal/database"
+	"github.com/luis-octavius/cintia/internal/interview"
 	"github.com/luis-octavius/cintia/internal/job"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/mfa"
 	"github.com/luis-octavius/cintia/internal/middleware"
+	"github.com/luis-octavius/cintia/internal/note"
+	"github.com/luis-octavius/cintia/internal/notification"
+	"github.com/luis-octavius/cintia/internal/offer"
+	"github.com/luis-octavius/cintia/internal/oidc"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+	"github.com/luis-octavius/cintia/internal/session"
 	"github.com/luis-octavius/cintia/internal/user"
+	"github.com/luis-octavius/cintia/internal/webhook"
 )
 
 func main() {
@@ -18,10 +39,9 @@ func main() {
 		log.Println("no .env file found")
 	}
 
-	secret := os.Getenv("SECRET")
-	if secret == "" {
-		secret = "dev-secret-key"
-		log.Println("secret in .env not set, fallback...")
+	tokenKeys, err := loadTokenKeys()
+	if err != nil {
+		log.Fatal("failed to load token signing keys:", err)
 	}
 
 	port := os.Getenv("PORT")
@@ -52,19 +72,155 @@ func main() {
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 
+	// client addresses drive login throttling, so X-Forwarded-For is only
+	// believed when it comes from a known proxy
+	if err := r.SetTrustedProxies(trustedProxies(getEnv("TRUSTED_PROXIES", ""))); err != nil {
+		log.Fatal("invalid TRUSTED_PROXIES:", err)
+	}
+
 	// Initialize repositories with real database
+	repoSession := session.NewPostgresRepository(db)
+	serviceSession := session.NewService(repoSession)
+
+	renderer, err := notification.NewRenderer()
+	if err != nil {
+		log.Fatal("failed to load email templates:", err)
+	}
+	// links in account emails and the OIDC callback need an absolute address
+	publicBaseURL := getEnv("PUBLIC_BASE_URL", "http://localhost:"+port)
+	accountMailer := notification.NewAccountMailer(newMailer(getEnv("MAIL_DRIVER", "log")), renderer, publicBaseURL)
+
+	repoAPIKey := apikey.NewPostgresRepository(db)
+	serviceAPIKey := apikey.NewService(repoAPIKey)
+	handlerAPIKey := apikey.NewGinHandler(serviceAPIKey)
+
+	repoMFA := mfa.NewPostgresRepository(db)
+	serviceMFA := mfa.NewService(repoMFA)
+	handlerMFA := mfa.NewGinHandler(serviceMFA)
+
+	repoLoginGuard := loginguard.NewPostgresRepository(db)
+	serviceLoginGuard := loginguard.NewService(repoLoginGuard, loginguard.EmailPolicy, loginguard.IPPolicy)
+	handlerLoginGuard := loginguard.NewGinHandler(serviceLoginGuard)
+
+	// signing in during the grace period cancels a scheduled account deletion
+	repoAccount := account.NewPostgresRepository(db)
+
 	repoUser := user.NewPostgresRepository(db)
-	serviceUser := user.NewService(repoUser, secret)
+	serviceUser := user.NewService(repoUser, serviceSession, serviceMFA, serviceLoginGuard, accountMailer, tokenKeys, account.NewLoginRestorer(repoAccount))
 	handlerUser := user.NewGinHandler(serviceUser)
 
+	// sign in with an OpenID Connect provider, when one is configured
+	var handlerOIDC *user.OIDCHandler
+	if issuer := os.Getenv("OIDC_ISSUER"); issuer != "" {
+		provider := oidc.NewProvider(oidc.Config{
+			Issuer:       issuer,
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", publicBaseURL+"/api/users/oidc/callback"),
+		}, nil)
+		repoOIDC := oidc.NewPostgresRepository(db)
+		handlerOIDC = user.NewOIDCHandler(serviceUser, oidc.NewService(repoOIDC, provider))
+	}
+
+	repoWebhook := webhook.NewPostgresRepository(db)
+	serviceWebhook := webhook.NewService(repoWebhook)
+	handlerWebhook := webhook.NewGinHandler(serviceWebhook)
+	webhookPublisher := webhook.NewPublisher(repoWebhook, log.Default())
+
 	repoJob := job.NewPostgresRepository(db)
-	serviceJob := job.NewService(repoJob)
+	serviceJob := job.NewService(repoJob, webhookPublisher)
 	handlerJob := job.NewGinHandler(serviceJob)
 
+	repoPipeline := pipeline.NewPostgresRepository(db)
+	servicePipeline := pipeline.NewService(repoPipeline)
+	handlerPipeline := pipeline.NewGinHandler(servicePipeline)
+
 	repoApp := application.NewPostgresRepository(db)
-	serviceApp := application.NewService(repoApp, serviceJob, serviceUser)
+	serviceApp := application.NewService(repoApp, serviceJob, serviceUser, servicePipeline, webhookPublisher)
 	handlerApp := application.NewGinHandler(serviceApp)
 
+	repoInterview := interview.NewPostgresRepository(db)
+	serviceInterview := interview.NewService(repoInterview, serviceApp)
+	handlerInterview := interview.NewGinHandler(serviceInterview)
+
+	attachmentStore, err := attachment.NewLocalStore(getEnv("ATTACHMENTS_DIR", "./data/attachments"))
+	if err != nil {
+		log.Fatal("failed to open attachment storage:", err)
+	}
+	repoAttachment := attachment.NewPostgresRepository(db)
+	serviceAttachment := attachment.NewService(repoAttachment, attachmentStore, serviceApp)
+	handlerAttachment := attachment.NewGinHandler(serviceAttachment)
+
+	repoContact := contact.NewPostgresRepository(db)
+	serviceContact := contact.NewService(repoContact, serviceApp)
+	handlerContact := contact.NewGinHandler(serviceContact)
+
+	repoNote := note.NewPostgresRepository(db)
+	serviceNote := note.NewService(repoNote, serviceApp)
+	handlerNote := note.NewGinHandler(serviceNote)
+
+	repoOffer := offer.NewPostgresRepository(db)
+	serviceOffer := offer.NewService(repoOffer, serviceApp, servicePipeline)
+	handlerOffer := offer.NewGinHandler(serviceOffer)
+
+	repoCalendar := calendar.NewPostgresRepository(db)
+	serviceCalendar := calendar.NewService(repoCalendar)
+	handlerCalendar := calendar.NewGinHandler(serviceCalendar, publicBaseURL)
+
+	deletionGraceDays, err := strconv.Atoi(getEnv("ACCOUNT_DELETION_GRACE_DAYS", "30"))
+	if err != nil || deletionGraceDays <= 0 {
+		log.Printf("invalid ACCOUNT_DELETION_GRACE_DAYS, fallback to 30")
+		deletionGraceDays = 30
+	}
+	serviceAccount := account.NewService(repoAccount, serviceUser, serviceApp, serviceNote, serviceAttachment, serviceAPIKey, serviceLoginGuard, time.Duration(deletionGraceDays)*24*time.Hour)
+	handlerAccount := account.NewGinHandler(serviceAccount)
+
+	// Background job flagging applications without answer as ghosted
+	ghostAfterDays, err := strconv.Atoi(getEnv("GHOST_AFTER_DAYS", "21"))
+	if err != nil || ghostAfterDays <= 0 {
+		log.Printf("invalid GHOST_AFTER_DAYS, fallback to 21")
+		ghostAfterDays = 21
+	}
+	ghostInterval, err := time.ParseDuration(getEnv("GHOST_CHECK_INTERVAL", "1h"))
+	if err != nil {
+		log.Printf("invalid GHOST_CHECK_INTERVAL, fallback to 1h")
+		ghostInterval = time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ghostDetector := application.NewGhostDetector(serviceApp, time.Duration(ghostAfterDays)*24*time.Hour, ghostInterval, log.Default())
+	go ghostDetector.Run(ctx)
+
+	// Background job sending queued webhook deliveries
+	webhookInterval, err := time.ParseDuration(getEnv("WEBHOOK_DELIVERY_INTERVAL", "10s"))
+	if err != nil {
+		log.Printf("invalid WEBHOOK_DELIVERY_INTERVAL, fallback to 10s")
+		webhookInterval = 10 * time.Second
+	}
+	webhookMaxAttempts, err := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"))
+	if err != nil || webhookMaxAttempts <= 0 {
+		log.Printf("invalid WEBHOOK_MAX_ATTEMPTS, fallback to 8")
+		webhookMaxAttempts = 8
+	}
+
+	webhookDispatcher := webhook.NewDispatcher(repoWebhook, nil, webhook.DeliveryOptions{MaxAttempts: webhookMaxAttempts}, webhookInterval, log.Default())
+	go webhookDispatcher.Run(ctx)
+
+	// Background job deleting accounts once their grace period is over
+	accountPurgeInterval, err := time.ParseDuration(getEnv("ACCOUNT_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		log.Printf("invalid ACCOUNT_PURGE_INTERVAL, fallback to 1h")
+		accountPurgeInterval = time.Hour
+	}
+
+	accountPurger := account.NewPurger(serviceAccount, accountPurgeInterval, log.Default())
+	go accountPurger.Run(ctx)
+
+	// public keys for services verifying access tokens on their own
+	r.GET("/.well-known/jwks.json", gin.WrapH(auth.JWKSHandler(tokenKeys)))
+
 	api := r.Group("/api")
 	{
 		// users routes
@@ -72,10 +228,50 @@ func main() {
 		{
 			users.POST("/register", handlerUser.RegisterHandler)
 			users.POST("/login", handlerUser.LoginHandler)
-			users.Use(middleware.AuthMiddleware(secret))
+			users.POST("/login/2fa", handlerUser.LoginSecondFactorHandler)
+			users.POST("/refresh", handlerUser.RefreshHandler)
+			users.POST("/password/forgot", handlerUser.ForgotPasswordHandler)
+			users.POST("/password/reset", handlerUser.ResetPasswordHandler)
+			users.GET("/email/verify", handlerUser.VerifyEmailHandler)
+			if handlerOIDC != nil {
+				users.GET("/oidc/login", handlerOIDC.StartHandler)
+				users.GET("/oidc/callback", handlerOIDC.CallbackHandler)
+			}
+			users.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
 			{
 				users.GET("/me", handlerUser.GetProfileHandler)
-				users.PUT("/me", handlerUser.UpdateProfileHandler)
+				// account settings cannot be changed with an api key
+				users.PUT("/me", middleware.RequireSession(), handlerUser.UpdateProfileHandler)
+				users.POST("/logout", middleware.RequireSession(), handlerUser.LogoutHandler)
+				users.POST("/email/verify/resend", middleware.RequireSession(), handlerUser.ResendVerificationHandler)
+
+				users.GET("/me/export", middleware.RequireSession(), handlerAccount.ExportHandler)
+				users.DELETE("/me", middleware.RequireSession(), handlerAccount.RequestDeletionHandler)
+				users.GET("/me/deletion", handlerAccount.GetDeletionHandler)
+				users.POST("/me/deletion/confirmation", middleware.RequireSession(), handlerAccount.SendDeletionConfirmationHandler)
+				users.DELETE("/me/deletion", middleware.RequireSession(), handlerAccount.CancelDeletionHandler)
+
+				users.GET("/2fa", handlerMFA.StatusHandler)
+				users.POST("/2fa/enroll", middleware.RequireSession(), handlerMFA.EnrollHandler)
+				users.POST("/2fa/confirm", middleware.RequireSession(), handlerMFA.ConfirmHandler)
+				users.POST("/2fa/disable", middleware.RequireSession(), handlerMFA.DisableHandler)
+				users.POST("/2fa/recovery-codes", middleware.RequireSession(), handlerMFA.RegenerateRecoveryCodesHandler)
+
+				users.POST("/api-keys", middleware.RequireSession(), handlerAPIKey.CreateKeyHandler)
+				users.GET("/api-keys", handlerAPIKey.ListKeysHandler)
+				users.DELETE("/api-keys/:id", middleware.RequireSession(), handlerAPIKey.RevokeKeyHandler)
+			}
+		}
+
+		// admin routes, only for signed in admins: an api key of an admin
+		// must not carry their admin powers
+		admin := api.Group("/admin")
+		{
+			admin.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, nil), middleware.RequireRole(auth.RoleAdmin))
+			{
+				admin.GET("/users", handlerUser.ListUsersHandler)
+				admin.PUT("/users/:id/role", handlerUser.ChangeRoleHandler)
+				admin.GET("/login-events", handlerLoginGuard.ListEventsHandler)
 			}
 		}
 
@@ -84,25 +280,132 @@ func main() {
 		{
 			jobs.GET("/", handlerJob.SearchJobsHandler)
 			jobs.GET("/:jobID", handlerJob.GetJobHandler)
-			jobs.GET("/:jobID/applications", handlerApp.GetJobApplicationsHandler)
-			jobs.Use(middleware.AuthMiddleware(secret))
+			jobs.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
 			{
-				jobs.POST("/", handlerJob.CreateJobHandler)
-				jobs.PATCH("/:jobID", handlerJob.ToggleJobStatusHandler)
+				jobs.GET("/:jobID/applications", middleware.RequirePermission(auth.PermViewApplicants), handlerApp.GetJobApplicationsHandler)
+				jobs.POST("/", middleware.RequirePermission(auth.PermCreateJobs), handlerJob.CreateJobHandler)
+				jobs.PATCH("/:jobID", middleware.RequirePermission(auth.PermManageJobs), handlerJob.ToggleJobStatusHandler)
 			}
 
 		}
 
 		applications := api.Group("/applications")
 		{
-			applications.Use(middleware.AuthMiddleware(secret))
+			applications.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
 			{
 				applications.POST("/", handlerApp.CreateApplicationHandler)
 				applications.GET("/", handlerApp.GetUserApplicationsHandler)
+				applications.GET("/export", handlerApp.ExportApplicationsHandler)
+				applications.POST("/import", handlerApp.ImportApplicationsHandler)
+				applications.GET("/stats", handlerApp.GetStatsHandler)
+				applications.GET("/board", handlerApp.GetBoardHandler)
 				applications.GET("/:id", handlerApp.GetApplicationHandler)
 				applications.PUT("/:id", handlerApp.UpdateApplicationHandler)
 				applications.PATCH("/:id/status", handlerApp.UpdateStatusHandler)
+				applications.PATCH("/:id/move", handlerApp.MoveApplicationHandler)
 				applications.DELETE("/:id", handlerApp.DeleteApplicationHandler)
+
+				applications.POST("/:id/interviews", handlerInterview.ScheduleInterviewHandler)
+				applications.GET("/:id/interviews", handlerInterview.GetApplicationInterviewsHandler)
+				applications.PUT("/:id/interviews/:interviewID", handlerInterview.UpdateInterviewHandler)
+				applications.DELETE("/:id/interviews/:interviewID", handlerInterview.DeleteInterviewHandler)
+
+				applications.POST("/:id/attachments", handlerAttachment.UploadAttachmentHandler)
+				applications.GET("/:id/attachments", handlerAttachment.GetApplicationAttachmentsHandler)
+				applications.GET("/:id/attachments/:attachmentID", handlerAttachment.DownloadAttachmentHandler)
+				applications.DELETE("/:id/attachments/:attachmentID", handlerAttachment.DeleteAttachmentHandler)
+
+				applications.GET("/:id/contacts", handlerContact.GetApplicationContactsHandler)
+				applications.POST("/:id/contacts", handlerContact.LinkApplicationHandler)
+				applications.DELETE("/:id/contacts/:contactID", handlerContact.UnlinkApplicationHandler)
+
+				applications.POST("/:id/notes", handlerNote.CreateNoteHandler)
+				applications.GET("/:id/notes", handlerNote.GetApplicationNotesHandler)
+				applications.PUT("/:id/notes/:noteID", handlerNote.UpdateNoteHandler)
+				applications.DELETE("/:id/notes/:noteID", handlerNote.DeleteNoteHandler)
+
+				applications.POST("/:id/offer", handlerOffer.CreateOfferHandler)
+				applications.GET("/:id/offer", handlerOffer.GetOfferHandler)
+				applications.PUT("/:id/offer", handlerOffer.UpdateOfferHandler)
+				applications.DELETE("/:id/offer", handlerOffer.DeleteOfferHandler)
+			}
+		}
+
+		interviews := api.Group("/interviews")
+		{
+			interviews.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				interviews.GET("/upcoming", handlerInterview.GetUpcomingInterviewsHandler)
+			}
+		}
+
+		calendars := api.Group("/calendar")
+		{
+			// the feed is authenticated by its secret token so calendar
+			// apps can subscribe without a JWT
+			calendars.GET("/:token", handlerCalendar.FeedHandler)
+			calendars.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				calendars.POST("/feed", handlerCalendar.IssueTokenHandler)
+				calendars.GET("/feed", handlerCalendar.GetFeedHandler)
+				calendars.DELETE("/feed", handlerCalendar.RevokeFeedHandler)
+			}
+		}
+
+		pipelines := api.Group("/pipelines")
+		{
+			pipelines.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				pipelines.POST("/", handlerPipeline.CreatePipelineHandler)
+				pipelines.GET("/", handlerPipeline.GetUserPipelinesHandler)
+				pipelines.GET("/:id", handlerPipeline.GetPipelineHandler)
+				pipelines.PUT("/:id/default", handlerPipeline.SetDefaultPipelineHandler)
+				pipelines.DELETE("/:id", handlerPipeline.DeletePipelineHandler)
+			}
+		}
+
+		contacts := api.Group("/contacts")
+		{
+			contacts.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				contacts.POST("/", handlerContact.CreateContactHandler)
+				contacts.GET("/", handlerContact.GetContactsHandler)
+				contacts.GET("/:id", handlerContact.GetContactHandler)
+				contacts.PUT("/:id", handlerContact.UpdateContactHandler)
+				contacts.DELETE("/:id", handlerContact.DeleteContactHandler)
+				contacts.POST("/:id/interactions", handlerContact.LogInteractionHandler)
+				contacts.GET("/:id/interactions", handlerContact.GetInteractionsHandler)
+			}
+		}
+
+		notes := api.Group("/notes")
+		{
+			notes.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				notes.GET("/search", handlerNote.SearchNotesHandler)
+			}
+		}
+
+		offers := api.Group("/offers")
+		{
+			offers.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				offers.GET("/", handlerOffer.GetUserOffersHandler)
+				offers.GET("/compare", handlerOffer.CompareOffersHandler)
+			}
+		}
+
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.Use(middleware.AuthMiddleware(tokenKeys, serviceSession, serviceAPIKey))
+			{
+				webhooks.POST("/", handlerWebhook.CreateEndpointHandler)
+				webhooks.GET("/", handlerWebhook.GetEndpointsHandler)
+				webhooks.GET("/:id", handlerWebhook.GetEndpointHandler)
+				webhooks.PUT("/:id", handlerWebhook.UpdateEndpointHandler)
+				webhooks.DELETE("/:id", handlerWebhook.DeleteEndpointHandler)
+				webhooks.GET("/:id/deliveries", handlerWebhook.GetDeliveriesHandler)
+				webhooks.POST("/:id/deliveries/:deliveryID/replay", handlerWebhook.ReplayDeliveryHandler)
 			}
 		}
 
@@ -121,6 +424,96 @@ func main() {
 	}
 }
 
+// newMailer picks where account emails go: a real SMTP server, .eml files
+// in MAIL_DIR, or the log
+func newMailer(driver string) notification.Mailer {
+	from := getEnv("SMTP_FROM", "Cintia <cintia@localhost>")
+
+	switch strings.ToLower(driver) {
+	case "smtp":
+		return notification.NewSMTPMailer(notification.SMTPConfig{
+			Host:       getEnv("SMTP_HOST", "localhost"),
+			Port:       getEnv("SMTP_PORT", "587"),
+			Username:   getEnv("SMTP_USERNAME", ""),
+			Password:   getEnv("SMTP_PASSWORD", ""),
+			From:       from,
+			RequireTLS: strings.EqualFold(getEnv("SMTP_REQUIRE_TLS", "false"), "true"),
+		})
+	case "file":
+		mailer, err := notification.NewFileMailer(getEnv("MAIL_DIR", "./data/mail"), from)
+		if err != nil {
+			log.Fatal("failed to open mail directory:", err)
+		}
+		return mailer
+	case "log":
+		return notification.NewLogMailer(log.Default())
+	default:
+		log.Printf("unknown MAIL_DRIVER %q, fallback to log", driver)
+		return notification.NewLogMailer(log.Default())
+	}
+}
+
+// loadTokenKeys reads the PEM key access tokens are signed with from
+// JWT_PRIVATE_KEY_FILE, and the keys of JWT_PREVIOUS_KEY_FILES that still
+// verify tokens issued before a rotation. Without a key one is generated,
+// so tokens do not survive a restart
+func loadTokenKeys() (*auth.KeySet, error) {
+	issuer := getEnv("JWT_ISSUER", auth.DefaultIssuer)
+
+	path := os.Getenv("JWT_PRIVATE_KEY_FILE")
+	if path == "" {
+		log.Println("JWT_PRIVATE_KEY_FILE not set, signing tokens with a temporary key")
+		signing, err := auth.GenerateSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewKeySet(issuer, signing)
+	}
+
+	signing, err := readKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous []*auth.SigningKey
+	for _, path := range strings.Split(os.Getenv("JWT_PREVIOUS_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		key, err := readKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		previous = append(previous, key)
+	}
+
+	return auth.NewKeySet(issuer, signing, previous...)
+}
+
+func readKeyFile(path string) (*auth.SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := auth.ParseKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// trustedProxies splits a comma separated list of addresses or CIDRs.
+// An empty list trusts no proxy
+func trustedProxies(value string) []string {
+	proxies := []string{}
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
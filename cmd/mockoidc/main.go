@@ -0,0 +1,47 @@
+// Command mockoidc runs a local OpenID Connect provider to try the OIDC
+// login without a real one. It signs in MOCK_OIDC_EMAIL without asking
+// anything; never expose it
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/luis-octavius/cintia/internal/oidc/oidctest"
+)
+
+func main() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("no .env file found")
+	}
+
+	addr := getEnv("MOCK_OIDC_ADDR", "localhost:9998")
+	issuer := getEnv("MOCK_OIDC_ISSUER", "http://"+addr)
+
+	provider, err := oidctest.NewProvider(issuer, getEnv("OIDC_CLIENT_ID", "cintia"), getEnv("OIDC_CLIENT_SECRET", "cintia-secret"))
+	if err != nil {
+		log.Fatal("failed to create provider: ", err)
+	}
+
+	email := getEnv("MOCK_OIDC_EMAIL", "dev@example.com")
+	provider.SetUser(oidctest.User{
+		Subject:       getEnv("MOCK_OIDC_SUBJECT", email),
+		Email:         email,
+		EmailVerified: !strings.EqualFold(getEnv("MOCK_OIDC_EMAIL_VERIFIED", "true"), "false"),
+		Name:          getEnv("MOCK_OIDC_NAME", "Dev User"),
+	})
+
+	log.Printf("mock OIDC provider for client %s at %s, signing in %s", provider.ClientID, provider.Issuer, email)
+	log.Fatal(http.ListenAndServe(addr, provider))
+}
+
+// getEnv gets an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/luis-octavius/cintia/internal/database"
+	"github.com/luis-octavius/cintia/internal/notification"
+)
+
+func main() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("no .env file found")
+	}
+
+	interval := parseDuration("NOTIFIER_INTERVAL", getEnv("NOTIFIER_INTERVAL", "1m"), time.Minute)
+	runOnce := strings.EqualFold(getEnv("NOTIFIER_ONCE", "false"), "true")
+	opts := notification.ClaimOptions{
+		InterviewLead: parseDuration("REMINDER_INTERVIEW_LEAD", getEnv("REMINDER_INTERVIEW_LEAD", "24h"), 24*time.Hour),
+		Lease:         parseDuration("REMINDER_LEASE", getEnv("REMINDER_LEASE", "5m"), 5*time.Minute),
+		Limit:         parseInt("REMINDER_BATCH_SIZE", getEnv("REMINDER_BATCH_SIZE", "100"), 100),
+		WeeklyDigest:  strings.EqualFold(getEnv("NOTIFIER_WEEKLY_DIGEST", "true"), "true"),
+	}
+
+	dbConfig := database.Config{
+		Host:     getEnv("DB_HOST", "localhost"),
+		Port:     getEnv("DB_PORT", "5432"),
+		User:     getEnv("DB_USER", "postgres"),
+		Password: getEnv("DB_PASSWORD", ""),
+		DBName:   getEnv("DB_NAME", "cintia"),
+		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	}
+
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		log.Fatal("failed to connect to database: ", err)
+	}
+	defer db.Close()
+
+	renderer, err := notification.NewRenderer()
+	if err != nil {
+		log.Fatal("failed to load notification templates: ", err)
+	}
+
+	notifier := newNotifier(getEnv("NOTIFIER_CHANNEL", "log"), renderer)
+
+	repo := notification.NewPostgresRepository(db)
+	dispatcher := notification.NewDispatcher(repo, notifier, opts, interval, log.Default())
+
+	if runOnce {
+		stats, err := dispatcher.RunOnce(context.Background())
+		if err != nil {
+			log.Printf("notifier run failed: %v", err)
+		}
+		log.Printf("notifier run once finished: claimed=%d sent=%d failed=%d", stats.Claimed, stats.Sent, stats.Failed)
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	dispatcher.Run(ctx)
+}
+
+func newNotifier(channel string, renderer *notification.Renderer) notification.Notifier {
+	switch strings.ToLower(channel) {
+	case "smtp":
+		mailer := notification.NewSMTPMailer(notification.SMTPConfig{
+			Host:       getEnv("SMTP_HOST", "localhost"),
+			Port:       getEnv("SMTP_PORT", "587"),
+			Username:   getEnv("SMTP_USERNAME", ""),
+			Password:   getEnv("SMTP_PASSWORD", ""),
+			From:       getEnv("SMTP_FROM", "Cintia <cintia@localhost>"),
+			RequireTLS: strings.EqualFold(getEnv("SMTP_REQUIRE_TLS", "false"), "true"),
+		})
+		return notification.NewEmailNotifier(mailer, renderer)
+	case "webhook":
+		url := getEnv("NOTIFIER_WEBHOOK_URL", "")
+		if url == "" {
+			log.Fatal("NOTIFIER_WEBHOOK_URL is required for the webhook channel")
+		}
+		return notification.NewWebhookNotifier(url, renderer, nil)
+	case "log":
+		return notification.NewLogNotifier(log.Default())
+	default:
+		log.Printf("unknown NOTIFIER_CHANNEL %q, fallback to log", channel)
+		return notification.NewLogNotifier(log.Default())
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func parseDuration(key, raw string, fallback time.Duration) time.Duration {
+	parsed, err := time.ParseDuration(raw)
+	if err != nil || parsed <= 0 {
+		log.Printf("invalid %s %q, fallback to %s", key, raw, fallback)
+		return fallback
+	}
+
+	return parsed
+}
+
+func parseInt(key, raw string, fallback int) int {
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed <= 0 {
+		log.Printf("invalid %s %q, fallback to %d", key, raw, fallback)
+		return fallback
+	}
+
+	return parsed
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/luis-octavius/cintia/internal/job"
 	"github.com/luis-octavius/cintia/internal/scraper"
 	"github.com/luis-octavius/cintia/internal/scraper/sources"
+	"github.com/luis-octavius/cintia/internal/webhook"
 )
 
 func main() {
@@ -42,7 +43,9 @@ func main() {
 	defer db.Close()
 
 	jobRepo := job.NewPostgresRepository(db)
-	jobService := job.NewService(jobRepo)
+	// Scraped jobs are queued for job.created subscribers; the API process
+	// dispatcher sends them
+	jobService := job.NewService(jobRepo, webhook.NewPublisher(webhook.NewPostgresRepository(db), log.Default()))
 
 	jobSources := []scraper.Source{
 		sources.NewLinkedInSource("", keywords, location),
@@ -0,0 +1,28 @@
+package account
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/session"
+)
+
+// DefaultGracePeriod is how long a deleted account can still be restored
+const DefaultGracePeriod = 30 * 24 * time.Hour
+
+// Deletion is a pending request to delete an account. The account and
+// everything it owns are purged once ScheduledFor has passed
+type Deletion struct {
+	UserID       uuid.UUID `json:"user_id"`
+	RequestedAt  time.Time `json:"requested_at"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+// DeletionInput confirms a deletion with the password or, for users who
+// sign in without one, the token of the confirmation email
+type DeletionInput struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+	// Client is filled in from the request, not the body
+	Client session.Client `json:"-"`
+}
@@ -0,0 +1,97 @@
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/luis-octavius/cintia/internal/attachment"
+	"github.com/luis-octavius/cintia/internal/note"
+	"github.com/luis-octavius/cintia/internal/user"
+)
+
+// Export is the personal data of a user. Attachment content is only read
+// while the archive is written
+type Export struct {
+	GeneratedAt  time.Time
+	Profile      *user.User
+	Applications []*application.ExportRow
+	Notes        []*note.Note
+	Attachments  []*attachment.Attachment
+
+	open func(ctx context.Context, a *attachment.Attachment) (io.ReadCloser, error)
+}
+
+// Filename is the name the archive is downloaded as
+func (e *Export) Filename() string {
+	return "cintia-export-" + e.GeneratedAt.Format("20060102") + ".zip"
+}
+
+// WriteZip writes a ZIP archive holding profile.json, applications.json,
+// notes.json and attachments.json, with the documents themselves under
+// attachments/
+func (e *Export) WriteZip(ctx context.Context, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", e.Profile},
+		{"applications.json", e.Applications},
+		{"notes.json", e.Notes},
+		{"attachments.json", e.Attachments},
+	}
+	for _, file := range files {
+		if err := e.writeJSON(archive, file.name, file.data); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range e.Attachments {
+		if err := e.writeAttachment(ctx, archive, a); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func (e *Export) writeJSON(archive *zip.Writer, name string, data any) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: e.GeneratedAt})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeAttachment prefixes the filename with the attachment ID, names are
+// not unique across applications
+func (e *Export) writeAttachment(ctx context.Context, archive *zip.Writer, a *attachment.Attachment) error {
+	content, err := e.open(ctx, a)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment %s: %w", a.ID, err)
+	}
+	defer content.Close()
+
+	name := "attachments/" + a.ID.String() + "-" + a.Filename
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: a.CreatedAt})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, content); err != nil {
+		return fmt.Errorf("failed to write attachment %s: %w", a.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package account
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/session"
+)
+
+type Handler interface {
+	ExportHandler(c *gin.Context)
+	RequestDeletionHandler(c *gin.Context)
+	SendDeletionConfirmationHandler(c *gin.Context)
+	GetDeletionHandler(c *gin.Context)
+	CancelDeletionHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// GET /api/users/me/export - download a ZIP archive of the user's personal data
+func (h *GinHandler) ExportHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.service.Export(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename()+`"`)
+	c.Status(http.StatusOK)
+
+	// The body is streamed, so a failure past this point can only be logged
+	if err := export.WriteZip(c.Request.Context(), c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// DELETE /api/users/me - schedule the deletion of the account, confirmed with the password or an emailed token
+func (h *GinHandler) RequestDeletionHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req DeletionInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	req.Client = session.Client{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+
+	deletion, err := h.service.RequestDeletion(c.Request.Context(), userID, req)
+	if err != nil {
+		var throttledErr *loginguard.ThrottledError
+		if errors.As(err, &throttledErr) {
+			seconds := int(math.Ceil(throttledErr.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       err.Error(),
+				"retry_after": seconds,
+			})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":  "account scheduled for deletion, sign in again before then to keep it",
+		"deletion": deletion,
+	})
+}
+
+// POST /api/users/me/deletion/confirmation - mail a token that confirms the deletion in place of the password
+func (h *GinHandler) SendDeletionConfirmationHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.SendDeletionConfirmation(c.Request.Context(), userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "deletion confirmation email sent",
+	})
+}
+
+// GET /api/users/me/deletion - get the pending deletion of the account
+func (h *GinHandler) GetDeletionHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.service.GetDeletion(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deletion": deletion,
+	})
+}
+
+// DELETE /api/users/me/deletion - cancel the pending deletion of the account
+func (h *GinHandler) CancelDeletionHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.CancelDeletion(c.Request.Context(), userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "account deletion cancelled",
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUserNotFound),
+		errors.Is(err, ErrDeletionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConfirmationRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrInvalidPassword),
+		errors.Is(err, ErrInvalidToken):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
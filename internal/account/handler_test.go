@@ -0,0 +1,120 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHandler(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	handler := NewGinHandler(env.service)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/users/me/export", env.userID, nil)
+
+	// Execute
+	handler.ExportHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `filename="cintia-export-20260302.zip"`)
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	assert.Len(t, archive.File, 4)
+}
+
+func TestRequestDeletionHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   any
+		status int
+	}{
+		{"missing password", gin.H{}, http.StatusBadRequest},
+		{"wrong password", DeletionInput{Password: "wrong"}, http.StatusForbidden},
+		{"wrong token", DeletionInput{Token: "guessed"}, http.StatusForbidden},
+		{"scheduled", DeletionInput{Password: "correct horse"}, http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			env := newTestEnv(t)
+			handler := NewGinHandler(env.service)
+
+			w := httptest.NewRecorder()
+			c := jsonContext(w, "DELETE", "/users/me", env.userID, tt.body)
+
+			// Execute
+			handler.RequestDeletionHandler(c)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusAccepted {
+				var response struct {
+					Deletion Deletion `json:"deletion"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, env.now.Add(DefaultGracePeriod), response.Deletion.ScheduledFor)
+			}
+		})
+	}
+}
+
+func TestRequestDeletionHandler_Throttled(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	handler := NewGinHandler(env.service)
+	for i := 0; i < loginguard.EmailPolicy.FreeAttempts+1; i++ {
+		handler.RequestDeletionHandler(jsonContext(httptest.NewRecorder(), "DELETE", "/users/me", env.userID, DeletionInput{Password: "wrong"}))
+	}
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "DELETE", "/users/me", env.userID, DeletionInput{Password: "correct horse"})
+
+	// Execute
+	handler.RequestDeletionHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
+
+func TestCancelDeletionHandler_NothingScheduled(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	handler := NewGinHandler(env.service)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "DELETE", "/users/me/deletion", env.userID, nil)
+
+	// Execute
+	handler.CancelDeletionHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func jsonContext(w *httptest.ResponseRecorder, method, path string, userID uuid.UUID, body any) *gin.Context {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+	return c
+}
@@ -0,0 +1,59 @@
+package account
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Purger periodically deletes the accounts whose grace period is over
+type Purger struct {
+	service  Service
+	interval time.Duration
+	logger   *log.Logger
+}
+
+func NewPurger(service Service, interval time.Duration, logger *log.Logger) *Purger {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &Purger{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// RunOnce purges due accounts and returns how many were deleted
+func (p *Purger) RunOnce(ctx context.Context) (int, error) {
+	return p.service.PurgeDue(ctx)
+}
+
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.logger.Printf("account purger started: interval=%s", p.interval)
+
+	for {
+		count, err := p.RunOnce(ctx)
+		if err != nil {
+			p.logger.Printf("account purger run failed: %v", err)
+		}
+		if count > 0 {
+			p.logger.Printf("account purger deleted %d accounts", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			p.logger.Println("account purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
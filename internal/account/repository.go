@@ -0,0 +1,23 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("account deletion not found")
+
+type Repository interface {
+	// ScheduleDeletion records a deletion request. Asking again keeps the
+	// date of the first request
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, scheduledFor time.Time) (*Deletion, error)
+	GetDeletion(ctx context.Context, userID uuid.UUID) (*Deletion, error)
+	// CancelDeletion returns ErrNotFound when no deletion is pending
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	// ListDueDeletions returns deletions scheduled up to before, oldest
+	// first
+	ListDueDeletions(ctx context.Context, before time.Time, limit int) ([]*Deletion, error)
+}
@@ -0,0 +1,84 @@
+package account
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu        sync.RWMutex
+	deletions map[uuid.UUID]*Deletion
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		deletions: make(map[uuid.UUID]*Deletion),
+	}
+}
+
+func (m *mockRepository) ScheduleDeletion(ctx context.Context, userID uuid.UUID, scheduledFor time.Time) (*Deletion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deletion, exists := m.deletions[userID]
+	if !exists {
+		deletion = &Deletion{
+			UserID:       userID,
+			RequestedAt:  time.Now(),
+			ScheduledFor: scheduledFor,
+		}
+		m.deletions[userID] = deletion
+	}
+
+	found := *deletion
+	return &found, nil
+}
+
+func (m *mockRepository) GetDeletion(ctx context.Context, userID uuid.UUID) (*Deletion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deletion, exists := m.deletions[userID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	found := *deletion
+	return &found, nil
+}
+
+func (m *mockRepository) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.deletions[userID]; !exists {
+		return ErrNotFound
+	}
+	delete(m.deletions, userID)
+	return nil
+}
+
+func (m *mockRepository) ListDueDeletions(ctx context.Context, before time.Time, limit int) ([]*Deletion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deletions := []*Deletion{}
+	for _, deletion := range m.deletions {
+		if !deletion.ScheduledFor.After(before) {
+			found := *deletion
+			deletions = append(deletions, &found)
+		}
+	}
+
+	sort.Slice(deletions, func(i, j int) bool {
+		return deletions[i].ScheduledFor.Before(deletions[j].ScheduledFor)
+	})
+	if limit < len(deletions) {
+		deletions = deletions[:limit]
+	}
+	return deletions, nil
+}
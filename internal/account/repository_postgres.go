@@ -0,0 +1,80 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) ScheduleDeletion(ctx context.Context, userID uuid.UUID, scheduledFor time.Time) (*Deletion, error) {
+	dbDeletion, err := r.queries.ScheduleAccountDeletion(ctx, database.ScheduleAccountDeletionParams{
+		UserID:       userID,
+		ScheduledFor: scheduledFor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbDeletionToDeletion(&dbDeletion), nil
+}
+
+func (r *PostgresRepository) GetDeletion(ctx context.Context, userID uuid.UUID) (*Deletion, error) {
+	dbDeletion, err := r.queries.GetAccountDeletion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbDeletionToDeletion(&dbDeletion), nil
+}
+
+func (r *PostgresRepository) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	rows, err := r.queries.CancelAccountDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListDueDeletions(ctx context.Context, before time.Time, limit int) ([]*Deletion, error) {
+	dbDeletions, err := r.queries.ListDueAccountDeletions(ctx, database.ListDueAccountDeletionsParams{
+		ScheduledFor: before,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := make([]*Deletion, len(dbDeletions))
+	for i := range dbDeletions {
+		deletions[i] = dbDeletionToDeletion(&dbDeletions[i])
+	}
+	return deletions, nil
+}
+
+func dbDeletionToDeletion(dbDeletion *database.AccountDeletion) *Deletion {
+	return &Deletion{
+		UserID:       dbDeletion.UserID,
+		RequestedAt:  dbDeletion.RequestedAt,
+		ScheduledFor: dbDeletion.ScheduledFor,
+	}
+}
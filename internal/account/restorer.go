@@ -0,0 +1,29 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/user"
+)
+
+// loginRestorer cancels the pending deletion of a user signing in. It
+// works on the repository, the account service needs the user service
+// which needs the restorer
+type loginRestorer struct {
+	repo Repository
+}
+
+// NewLoginRestorer returns the user.PendingDeletions to give
+// user.NewService, signing in during the grace period keeps the account
+func NewLoginRestorer(repo Repository) user.PendingDeletions {
+	return &loginRestorer{repo: repo}
+}
+
+func (r *loginRestorer) CancelPendingDeletion(ctx context.Context, userID uuid.UUID) error {
+	if err := r.repo.CancelDeletion(ctx, userID); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
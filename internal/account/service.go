@@ -0,0 +1,293 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/apikey"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/luis-octavius/cintia/internal/attachment"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/note"
+	"github.com/luis-octavius/cintia/internal/user"
+)
+
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidPassword      = errors.New("password is incorrect")
+	ErrInvalidToken         = errors.New("invalid or expired confirmation token")
+	ErrConfirmationRequired = errors.New("confirm with the password or a token from the confirmation email")
+	ErrDeletionNotFound     = errors.New("no account deletion is scheduled")
+)
+
+// purgeBatch bounds how many accounts one PurgeDue call deletes
+const purgeBatch = 100
+
+type Service interface {
+	// Export gathers the personal data of a user, see Export.WriteZip
+	Export(ctx context.Context, userID uuid.UUID) (*Export, error)
+	// RequestDeletion schedules the account for deletion after the grace
+	// period and signs the user out everywhere. Wrong passwords count as
+	// failed logins, too many return a *loginguard.ThrottledError
+	RequestDeletion(ctx context.Context, userID uuid.UUID, input DeletionInput) (*Deletion, error)
+	// SendDeletionConfirmation mails a token RequestDeletion accepts in
+	// place of the password, for accounts created through an identity
+	// provider
+	SendDeletionConfirmation(ctx context.Context, userID uuid.UUID) error
+	GetDeletion(ctx context.Context, userID uuid.UUID) (*Deletion, error)
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	// PurgeDue deletes the accounts whose grace period is over and returns
+	// how many were deleted
+	PurgeDue(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo         Repository
+	users        user.Service
+	applications application.Service
+	notes        note.Service
+	attachments  attachment.Service
+	apiKeys      apikey.Service
+	guard        loginguard.Service
+	grace        time.Duration
+	now          func() time.Time
+}
+
+func NewService(
+	repo Repository,
+	users user.Service,
+	applications application.Service,
+	notes note.Service,
+	attachments attachment.Service,
+	apiKeys apikey.Service,
+	guard loginguard.Service,
+	grace time.Duration,
+) Service {
+	if grace <= 0 {
+		grace = DefaultGracePeriod
+	}
+
+	return &service{
+		repo:         repo,
+		users:        users,
+		applications: applications,
+		notes:        notes,
+		attachments:  attachments,
+		apiKeys:      apiKeys,
+		guard:        guard,
+		grace:        grace,
+		now:          time.Now,
+	}
+}
+
+func (s *service) Export(ctx context.Context, userID uuid.UUID) (*Export, error) {
+	profile, err := s.profile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := s.applications.ExportApplications(ctx, userID, application.ExportFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export applications: %w", err)
+	}
+
+	userNotes, err := s.notes.GetUserNotes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export notes: %w", err)
+	}
+
+	// group the notes by application, in the order of the applications
+	byApplication := make(map[uuid.UUID][]*note.Note, len(applications))
+	for _, n := range userNotes {
+		byApplication[n.ApplicationID] = append(byApplication[n.ApplicationID], n)
+	}
+	notes := make([]*note.Note, 0, len(userNotes))
+	for _, app := range applications {
+		notes = append(notes, byApplication[app.ID]...)
+	}
+
+	attachments, err := s.attachments.GetUserAttachments(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export attachments: %w", err)
+	}
+
+	return &Export{
+		GeneratedAt:  s.now().UTC(),
+		Profile:      profile,
+		Applications: applications,
+		Notes:        notes,
+		Attachments:  attachments,
+		open: func(ctx context.Context, a *attachment.Attachment) (io.ReadCloser, error) {
+			_, content, err := s.attachments.Open(ctx, userID, a.ApplicationID, a.ID)
+			return content, err
+		},
+	}, nil
+}
+
+// RequestDeletion also revokes the API keys: during the grace period the
+// account is only kept to be restored, not used. Signing in again restores
+// it, see NewLoginRestorer
+func (s *service) RequestDeletion(ctx context.Context, userID uuid.UUID, input DeletionInput) (*Deletion, error) {
+	if err := s.confirmDeletion(ctx, userID, input); err != nil {
+		return nil, err
+	}
+
+	deletion, err := s.repo.ScheduleDeletion(ctx, userID, s.now().Add(s.grace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule deletion: %w", err)
+	}
+
+	keys, err := s.apiKeys.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	for _, key := range keys {
+		if err := s.apiKeys.Revoke(ctx, userID, key.ID); err != nil {
+			return nil, fmt.Errorf("failed to revoke api key: %w", err)
+		}
+	}
+
+	if err := s.users.LogoutAll(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return deletion, nil
+}
+
+// confirmDeletion accepts the password or a token mailed by
+// SendDeletionConfirmation. Only passwords can be guessed, so only they go
+// through the login guard
+func (s *service) confirmDeletion(ctx context.Context, userID uuid.UUID, input DeletionInput) error {
+	if input.Token != "" {
+		err := s.users.ConfirmDeletionToken(ctx, userID, input.Token)
+		if errors.Is(err, user.ErrInvalidToken) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	if input.Password == "" {
+		return ErrConfirmationRequired
+	}
+
+	profile, err := s.profile(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	attempt := loginguard.Attempt{
+		Email:     profile.Email,
+		IPAddress: input.Client.IPAddress,
+		UserAgent: input.Client.UserAgent,
+	}
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return err
+	}
+
+	if err := s.users.ConfirmPassword(ctx, userID, input.Password); err != nil {
+		switch {
+		case errors.Is(err, user.ErrInvalidPassword):
+			if err := s.guard.Failure(ctx, attempt, &userID, loginguard.ReasonWrongPassword); err != nil {
+				log.Printf("failed to record failed deletion confirmation: %v", err)
+			}
+			return ErrInvalidPassword
+		case errors.Is(err, user.ErrUserNotFound):
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *service) SendDeletionConfirmation(ctx context.Context, userID uuid.UUID) error {
+	if err := s.users.SendDeletionConfirmation(ctx, userID); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *service) GetDeletion(ctx context.Context, userID uuid.UUID) (*Deletion, error) {
+	deletion, err := s.repo.GetDeletion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrDeletionNotFound
+		}
+		return nil, fmt.Errorf("failed to get deletion: %w", err)
+	}
+	return deletion, nil
+}
+
+func (s *service) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.CancelDeletion(ctx, userID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrDeletionNotFound
+		}
+		return fmt.Errorf("failed to cancel deletion: %w", err)
+	}
+	return nil
+}
+
+// PurgeDue goes on past an account that fails, it is retried on the next
+// run, and returns the errors together
+func (s *service) PurgeDue(ctx context.Context) (int, error) {
+	deletions, err := s.repo.ListDueDeletions(ctx, s.now(), purgeBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due deletions: %w", err)
+	}
+
+	purged := 0
+	var errs []error
+	for _, deletion := range deletions {
+		if err := s.purge(ctx, deletion.UserID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to purge user %s: %w", deletion.UserID, err))
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+// purge removes what the foreign keys do not: attachment content lives
+// outside the database and login events only lose their user
+func (s *service) purge(ctx context.Context, userID uuid.UUID) error {
+	profile, err := s.profile(ctx, userID)
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return err
+	}
+
+	if profile != nil {
+		if err := s.attachments.DeleteUserAttachments(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.guard.Forget(ctx, userID, profile.Email); err != nil {
+			return err
+		}
+		if err := s.users.Delete(ctx, userID); err != nil {
+			return err
+		}
+		log.Printf("account %s deleted", userID)
+	}
+
+	// the request normally goes with the user
+	if err := s.repo.CancelDeletion(ctx, userID); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to clear deletion: %w", err)
+	}
+	return nil
+}
+
+func (s *service) profile(ctx context.Context, userID uuid.UUID) (*user.User, error) {
+	profile, err := s.users.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	if profile == nil {
+		return nil, ErrUserNotFound
+	}
+	return profile, nil
+}
@@ -0,0 +1,344 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/apikey"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/luis-octavius/cintia/internal/attachment"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/note"
+	"github.com/luis-octavius/cintia/internal/session"
+	"github.com/luis-octavius/cintia/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport_WritesArchive(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.notes.CreateNote(ctx, env.userID, env.appID, note.NoteInput{Body: "called the recruiter"})
+	require.NoError(t, err)
+	uploaded, err := env.attachments.Upload(ctx, env.userID, env.appID, attachment.UploadInput{
+		Kind:     attachment.KindResume,
+		Filename: "resume.txt",
+		Content:  strings.NewReader("Ada Lovelace, analyst"),
+	})
+	require.NoError(t, err)
+
+	// Execute
+	export, err := env.service.Export(ctx, env.userID)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteZip(ctx, &buf))
+
+	// Assert
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(content)
+	}
+
+	var profile user.User
+	require.NoError(t, json.Unmarshal([]byte(files["profile.json"]), &profile))
+	assert.Equal(t, "ada@example.com", profile.Email)
+	assert.NotContains(t, files["profile.json"], "secret-hash")
+
+	assert.Contains(t, files["applications.json"], "Data Analyst")
+	assert.Contains(t, files["notes.json"], "called the recruiter")
+	assert.Contains(t, files["attachments.json"], "resume.txt")
+	assert.Equal(t, "Ada Lovelace, analyst", files["attachments/"+uploaded.ID.String()+"-resume.txt"])
+	assert.True(t, strings.HasPrefix(export.Filename(), "cintia-export-"))
+}
+
+func TestRequestDeletion_WrongPassword(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+
+	// Execute
+	_, err := env.service.RequestDeletion(context.Background(), env.userID, DeletionInput{Password: "wrong"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+	_, err = env.service.GetDeletion(context.Background(), env.userID)
+	assert.ErrorIs(t, err, ErrDeletionNotFound)
+	assert.False(t, env.users.loggedOut)
+}
+
+func TestRequestDeletion_WrongPasswordsAreThrottled(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	ctx := context.Background()
+	input := DeletionInput{Password: "wrong", Client: session.Client{IPAddress: "203.0.113.7"}}
+	for i := 0; i < loginguard.EmailPolicy.FreeAttempts+1; i++ {
+		_, err := env.service.RequestDeletion(ctx, env.userID, input)
+		require.ErrorIs(t, err, ErrInvalidPassword)
+	}
+
+	// Execute
+	input.Password = "correct horse"
+	_, err := env.service.RequestDeletion(ctx, env.userID, input)
+
+	// Assert
+	var throttled *loginguard.ThrottledError
+	assert.ErrorAs(t, err, &throttled)
+	assert.False(t, env.users.loggedOut)
+
+	events, err := env.guard.ListEvents(ctx, loginguard.EventFilters{Email: "ada@example.com", Event: loginguard.EventFailure})
+	require.NoError(t, err)
+	require.Len(t, events, loginguard.EmailPolicy.FreeAttempts+1)
+	assert.Equal(t, loginguard.ReasonWrongPassword, events[0].Reason)
+	assert.Equal(t, env.userID, *events[0].UserID)
+}
+
+func TestRequestDeletion_MailedToken(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	ctx := context.Background()
+	_, missing := env.service.RequestDeletion(ctx, env.userID, DeletionInput{})
+	_, unsent := env.service.RequestDeletion(ctx, env.userID, DeletionInput{Token: "guessed"})
+	require.NoError(t, env.service.SendDeletionConfirmation(ctx, env.userID))
+
+	// Execute
+	deletion, err := env.service.RequestDeletion(ctx, env.userID, DeletionInput{Token: env.users.token})
+
+	// Assert
+	assert.ErrorIs(t, missing, ErrConfirmationRequired)
+	assert.ErrorIs(t, unsent, ErrInvalidToken)
+	require.NoError(t, err)
+	assert.Equal(t, env.now.Add(DefaultGracePeriod), deletion.ScheduledFor)
+	assert.True(t, env.users.loggedOut)
+}
+
+func TestRequestDeletion_SchedulesAndSignsOut(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	ctx := context.Background()
+	_, err := env.apiKeys.Create(ctx, env.userID, apikey.CreateInput{Name: "ci", Scopes: []string{"read"}})
+	require.NoError(t, err)
+
+	// Execute
+	deletion, err := env.service.RequestDeletion(ctx, env.userID, DeletionInput{Password: "correct horse"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, env.now.Add(DefaultGracePeriod), deletion.ScheduledFor)
+	assert.True(t, env.users.loggedOut)
+	keys, err := env.apiKeys.List(ctx, env.userID)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// asking again keeps the first date
+	env.now = env.now.Add(24 * time.Hour)
+	again, err := env.service.RequestDeletion(ctx, env.userID, DeletionInput{Password: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, deletion.ScheduledFor, again.ScheduledFor)
+}
+
+func TestCancelDeletion(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	ctx := context.Background()
+	_, err := env.service.RequestDeletion(ctx, env.userID, DeletionInput{Password: "correct horse"})
+	require.NoError(t, err)
+
+	// Execute
+	err = env.service.CancelDeletion(ctx, env.userID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, env.service.CancelDeletion(ctx, env.userID), ErrDeletionNotFound)
+
+	env.now = env.now.Add(DefaultGracePeriod + time.Hour)
+	purged, err := env.service.PurgeDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	assert.False(t, env.users.deleted)
+}
+
+func TestPurgeDue_DeletesAfterGracePeriod(t *testing.T) {
+	// Setup
+	env := newTestEnv(t)
+	ctx := context.Background()
+	uploaded, err := env.attachments.Upload(ctx, env.userID, env.appID, attachment.UploadInput{
+		Kind:     attachment.KindOther,
+		Filename: "notes.md",
+		Content:  strings.NewReader("# interview prep"),
+	})
+	require.NoError(t, err)
+	attempt := loginguard.Attempt{Email: "ada@example.com", IPAddress: "203.0.113.7"}
+	require.NoError(t, env.guard.Failure(ctx, attempt, &env.userID, loginguard.ReasonWrongPassword))
+	_, err = env.service.RequestDeletion(ctx, env.userID, DeletionInput{Password: "correct horse"})
+	require.NoError(t, err)
+
+	// Execute
+	early, err := env.service.PurgeDue(ctx)
+	require.NoError(t, err)
+	env.now = env.now.Add(DefaultGracePeriod)
+	purged, err := env.service.PurgeDue(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Zero(t, early)
+	assert.Equal(t, 1, purged)
+	assert.True(t, env.users.deleted)
+
+	_, _, err = env.attachments.Open(ctx, env.userID, env.appID, uploaded.ID)
+	assert.ErrorIs(t, err, attachment.ErrAttachmentNotFound)
+	_, err = env.store.Get(ctx, uploaded.StorageKey)
+	assert.ErrorIs(t, err, attachment.ErrBlobNotFound)
+
+	events, err := env.guard.ListEvents(ctx, loginguard.EventFilters{Email: "ada@example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = env.service.GetDeletion(ctx, env.userID)
+	assert.ErrorIs(t, err, ErrDeletionNotFound)
+}
+
+type testEnv struct {
+	service     *service
+	users       *stubUserService
+	notes       note.Service
+	attachments attachment.Service
+	apiKeys     apikey.Service
+	guard       loginguard.Service
+	store       *attachment.LocalStore
+	userID      uuid.UUID
+	appID       uuid.UUID
+	now         time.Time
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		userID: uuid.New(),
+		appID:  uuid.New(),
+		now:    time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+	}
+	env.users = &stubUserService{profile: &user.User{
+		ID:           env.userID,
+		Name:         "Ada",
+		Email:        "ada@example.com",
+		PasswordHash: "secret-hash",
+		Role:         "candidate",
+	}}
+	apps := &stubApplicationService{app: &application.ExportRow{
+		ID:         env.appID,
+		Status:     application.StatusApplied,
+		JobTitle:   "Data Analyst",
+		JobCompany: "Analytical Engines",
+	}, userID: env.userID}
+
+	store, err := attachment.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	env.store = store
+	env.notes = note.NewService(note.NewMockRepository(), apps)
+	env.attachments = attachment.NewService(attachment.NewMockRepository(), store, apps)
+	env.apiKeys = apikey.NewService(apikey.NewMockRepository())
+	env.guard = loginguard.NewService(loginguard.NewMockRepository(), loginguard.EmailPolicy, loginguard.IPPolicy)
+
+	env.service = NewService(NewMockRepository(), env.users, apps, env.notes, env.attachments, env.apiKeys, env.guard, 0).(*service)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+type stubUserService struct {
+	user.Service
+	profile   *user.User
+	loggedOut bool
+	deleted   bool
+	token     string
+}
+
+func (s *stubUserService) GetProfile(ctx context.Context, userID uuid.UUID) (*user.User, error) {
+	if s.deleted || userID != s.profile.ID {
+		return nil, nil
+	}
+	return s.profile, nil
+}
+
+func (s *stubUserService) ConfirmPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	if password != "correct horse" {
+		return user.ErrInvalidPassword
+	}
+	return nil
+}
+
+func (s *stubUserService) SendDeletionConfirmation(ctx context.Context, userID uuid.UUID) error {
+	s.token = "mailed-" + userID.String()
+	return nil
+}
+
+func (s *stubUserService) ConfirmDeletionToken(ctx context.Context, userID uuid.UUID, token string) error {
+	if s.token == "" || token != s.token {
+		return user.ErrInvalidToken
+	}
+	s.token = ""
+	return nil
+}
+
+func (s *stubUserService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	s.loggedOut = true
+	return nil
+}
+
+func (s *stubUserService) Delete(ctx context.Context, userID uuid.UUID) error {
+	s.deleted = true
+	return nil
+}
+
+type stubApplicationService struct {
+	application.Service
+	app    *application.ExportRow
+	userID uuid.UUID
+}
+
+func (s *stubApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*application.Application, error) {
+	if id != s.app.ID {
+		return nil, application.ErrApplicationNotFound
+	}
+	return &application.Application{ID: s.app.ID, UserID: s.userID, Status: s.app.Status}, nil
+}
+
+func (s *stubApplicationService) ExportApplications(ctx context.Context, userID uuid.UUID, filter application.ExportFilter) ([]*application.ExportRow, error) {
+	if userID != s.userID {
+		return []*application.ExportRow{}, nil
+	}
+	return []*application.ExportRow{s.app}, nil
+}
+
+func TestLoginRestorer_CancelsPendingDeletion(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	userID := uuid.New()
+	_, err := repo.ScheduleDeletion(context.Background(), userID, time.Now().Add(DefaultGracePeriod))
+	require.NoError(t, err)
+	restorer := NewLoginRestorer(repo)
+
+	// Execute
+	err = restorer.CancelPendingDeletion(context.Background(), userID)
+
+	// Assert
+	require.NoError(t, err)
+	_, err = repo.GetDeletion(context.Background(), userID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	// nothing pending is not an error, most logins have no deletion
+	assert.NoError(t, restorer.CancelPendingDeletion(context.Background(), userID))
+}
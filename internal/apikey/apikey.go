@@ -0,0 +1,99 @@
+package apikey
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Prefix starts every key, so keys are told apart from JWTs in the
+// Authorization header and found by secret scanners
+const Prefix = "cintia_"
+
+const (
+	MaxKeysPerUser = 20
+	MaxNameLength  = 100
+	// displayLength is how much of a key is kept in clear to recognize it
+	displayLength = len(Prefix) + 6
+)
+
+// Scope limits what a key can do on top of its owner's role
+type Scope string
+
+const (
+	// ScopeRead allows GET, HEAD and OPTIONS requests
+	ScopeRead Scope = "read"
+	// ScopeWrite allows every request, reads included
+	ScopeWrite Scope = "write"
+)
+
+var Scopes = []Scope{ScopeRead, ScopeWrite}
+
+func (s Scope) IsValid() bool {
+	for _, scope := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is a key as stored; the key itself is only known when created
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Created is a new key with its secret value, shown to the user once
+type Created struct {
+	APIKey *APIKey
+	Key    string
+}
+
+// Identity is who a request authenticated with a key acts as
+type Identity struct {
+	KeyID  uuid.UUID
+	UserID uuid.UUID
+	Email  string
+	Role   string
+	Scopes []string
+}
+
+type CreateInput struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+// IsKey reports whether a bearer token looks like an API key rather than
+// a JWT
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// Allows reports whether the key's scopes cover the HTTP method
+func (i *Identity) Allows(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return i.has(ScopeRead) || i.has(ScopeWrite)
+	default:
+		return i.has(ScopeWrite)
+	}
+}
+
+func (i *Identity) has(scope Scope) bool {
+	for _, s := range i.Scopes {
+		if Scope(s) == scope {
+			return true
+		}
+	}
+	return false
+}
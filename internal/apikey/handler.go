@@ -0,0 +1,137 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	CreateKeyHandler(c *gin.Context)
+	ListKeysHandler(c *gin.Context)
+	RevokeKeyHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/users/api-keys - create a key, its value is only shown here
+func (h *GinHandler) CreateKeyHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req CreateInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	created, err := h.service.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "api key created, copy it now as it will not be shown again",
+		"api_key": created.APIKey,
+		"key":     created.Key,
+	})
+}
+
+// GET /api/users/api-keys - keys of the user that are not revoked
+func (h *GinHandler) ListKeysHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.List(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+		"total":    len(keys),
+	})
+}
+
+// DELETE /api/users/api-keys/:id - revoke a key
+func (h *GinHandler) RevokeKeyHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid api key id format",
+		})
+		return
+	}
+
+	if err := h.service.Revoke(c.Request.Context(), userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "api key revoked",
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTooManyKeys):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidName),
+		errors.Is(err, ErrNameTooLong),
+		errors.Is(err, ErrInvalidScopes):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
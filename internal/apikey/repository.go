@@ -0,0 +1,25 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("api key not found")
+
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) (*APIKey, error)
+	// List returns the user's keys that were not revoked, newest first
+	List(ctx context.Context, userID uuid.UUID) ([]*APIKey, error)
+	Count(ctx context.Context, userID uuid.UUID) (int, error)
+	// GetActiveByHash returns a key that was not revoked together with
+	// who it acts as
+	GetActiveByHash(ctx context.Context, keyHash string) (*Identity, error)
+	// Touch updates the last used time, at most once a minute
+	Touch(ctx context.Context, id uuid.UUID) error
+	// Revoke returns ErrNotFound unless the user has the key and it is
+	// not revoked yet
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+}
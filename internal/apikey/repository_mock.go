@@ -0,0 +1,118 @@
+package apikey
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]*APIKey
+	// owners stands in for the users joined when authenticating
+	owners map[uuid.UUID]owner
+}
+
+type owner struct {
+	email string
+	role  string
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		keys:   make(map[uuid.UUID]*APIKey),
+		owners: make(map[uuid.UUID]owner),
+	}
+}
+
+// setOwner registers the email and role keys of a user act with
+func (m *mockRepository) setOwner(userID uuid.UUID, email, role string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.owners[userID] = owner{email: email, role: role}
+}
+
+func (m *mockRepository) Create(ctx context.Context, key *APIKey) (*APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key.ID == uuid.Nil {
+		key.ID = uuid.New()
+	}
+	key.CreatedAt = time.Now()
+
+	stored := *key
+	m.keys[key.ID] = &stored
+	return key, nil
+}
+
+func (m *mockRepository) List(ctx context.Context, userID uuid.UUID) ([]*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := []*APIKey{}
+	for _, key := range m.keys {
+		if key.UserID == userID && key.RevokedAt == nil {
+			found := *key
+			keys = append(keys, &found)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (m *mockRepository) Count(ctx context.Context, userID uuid.UUID) (int, error) {
+	keys, err := m.List(ctx, userID)
+	return len(keys), err
+}
+
+func (m *mockRepository) GetActiveByHash(ctx context.Context, keyHash string) (*Identity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash && key.RevokedAt == nil {
+			o := m.owners[key.UserID]
+			return &Identity{
+				KeyID:  key.ID,
+				UserID: key.UserID,
+				Email:  o.email,
+				Role:   o.role,
+				Scopes: key.Scopes,
+			}, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key, exists := m.keys[id]; exists {
+		now := time.Now()
+		key.LastUsedAt = &now
+	}
+	return nil
+}
+
+func (m *mockRepository) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, exists := m.keys[id]
+	if !exists || key.UserID != userID || key.RevokedAt != nil {
+		return ErrNotFound
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	return nil
+}
@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, key *APIKey) (*APIKey, error) {
+	dbKey, err := r.queries.CreateAPIKey(ctx, database.CreateAPIKeyParams{
+		UserID:  key.UserID,
+		Name:    key.Name,
+		Prefix:  key.Prefix,
+		KeyHash: key.KeyHash,
+		Scopes:  key.Scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbKeyToKey(&dbKey), nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context, userID uuid.UUID) ([]*APIKey, error) {
+	dbKeys, err := r.queries.ListUserAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*APIKey, 0, len(dbKeys))
+	for i := range dbKeys {
+		keys = append(keys, dbKeyToKey(&dbKeys[i]))
+	}
+	return keys, nil
+}
+
+func (r *PostgresRepository) Count(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountUserAPIKeys(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *PostgresRepository) GetActiveByHash(ctx context.Context, keyHash string) (*Identity, error) {
+	row, err := r.queries.GetActiveAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &Identity{
+		KeyID:  row.ID,
+		UserID: row.UserID,
+		Email:  row.UserEmail,
+		Role:   row.UserRole,
+		Scopes: row.Scopes,
+	}, nil
+}
+
+func (r *PostgresRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	return r.queries.TouchAPIKey(ctx, id)
+}
+
+func (r *PostgresRepository) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	rows, err := r.queries.RevokeAPIKey(ctx, database.RevokeAPIKeyParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func dbKeyToKey(dbKey *database.ApiKey) *APIKey {
+	return &APIKey{
+		ID:         dbKey.ID,
+		UserID:     dbKey.UserID,
+		Name:       dbKey.Name,
+		Prefix:     dbKey.Prefix,
+		KeyHash:    dbKey.KeyHash,
+		Scopes:     dbKey.Scopes,
+		LastUsedAt: fromNullTime(dbKey.LastUsedAt),
+		RevokedAt:  fromNullTime(dbKey.RevokedAt),
+		CreatedAt:  dbKey.CreatedAt,
+	}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
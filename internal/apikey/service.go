@@ -0,0 +1,141 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+)
+
+var (
+	ErrInvalidName   = errors.New("name must not be empty")
+	ErrNameTooLong   = fmt.Errorf("name must be at most %d characters", MaxNameLength)
+	ErrInvalidScopes = errors.New("scopes must be read and/or write")
+	ErrTooManyKeys   = fmt.Errorf("at most %d api keys per user, revoke one first", MaxKeysPerUser)
+	ErrKeyNotFound   = errors.New("api key not found")
+	ErrInvalidKey    = errors.New("invalid or revoked api key")
+)
+
+type Service interface {
+	Create(ctx context.Context, userID uuid.UUID, input CreateInput) (*Created, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*APIKey, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+	// Authenticate resolves a key sent with a request and records its use
+	Authenticate(ctx context.Context, key string) (*Identity, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, input CreateInput) (*Created, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+	if len(name) > MaxNameLength {
+		return nil, ErrNameTooLong
+	}
+
+	scopes, err := normalizeScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.Count(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count api keys: %w", err)
+	}
+	if count >= MaxKeysPerUser {
+		return nil, ErrTooManyKeys
+	}
+
+	token, err := auth.MakeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := Prefix + token
+
+	created, err := s.repo.Create(ctx, &APIKey{
+		UserID:  userID,
+		Name:    name,
+		Prefix:  key[:displayLength],
+		KeyHash: auth.HashToken(key),
+		Scopes:  scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &Created{APIKey: created, Key: key}, nil
+}
+
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*APIKey, error) {
+	keys, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *service) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.repo.Revoke(ctx, userID, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrKeyNotFound
+		}
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, key string) (*Identity, error) {
+	if !IsKey(key) {
+		return nil, ErrInvalidKey
+	}
+
+	identity, err := s.repo.GetActiveByHash(ctx, auth.HashToken(key))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrInvalidKey
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	// the last used time is informative, a failed write must not fail the
+	// request
+	if err := s.repo.Touch(ctx, identity.KeyID); err != nil {
+		log.Printf("failed to record use of api key %s: %v", identity.KeyID, err)
+	}
+	return identity, nil
+}
+
+// normalizeScopes validates and deduplicates scopes, in the order of Scopes
+func normalizeScopes(requested []string) ([]string, error) {
+	wanted := make(map[Scope]bool, len(requested))
+	for _, scope := range requested {
+		scope := Scope(strings.ToLower(strings.TrimSpace(scope)))
+		if !scope.IsValid() {
+			return nil, ErrInvalidScopes
+		}
+		wanted[scope] = true
+	}
+
+	scopes := []string{}
+	for _, scope := range Scopes {
+		if wanted[scope] {
+			scopes = append(scopes, string(scope))
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, ErrInvalidScopes
+	}
+	return scopes, nil
+}
@@ -0,0 +1,103 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate_AuthenticateAndRevoke(t *testing.T) {
+	// Setup
+	repo := NewMockRepository().(*mockRepository)
+	service := NewService(repo)
+	userID := uuid.New()
+	repo.setOwner(userID, "ada@example.com", "recruiter")
+
+	// Execute
+	created, err := service.Create(context.Background(), userID, CreateInput{Name: " deploy script ", Scopes: []string{"WRITE", "read", "read"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, Prefix))
+	assert.Equal(t, created.Key[:displayLength], created.APIKey.Prefix)
+	assert.NotContains(t, created.APIKey.KeyHash, created.Key)
+	assert.Equal(t, "deploy script", created.APIKey.Name)
+	assert.Equal(t, []string{"read", "write"}, created.APIKey.Scopes)
+
+	identity, err := service.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, userID, identity.UserID)
+	assert.Equal(t, "ada@example.com", identity.Email)
+	assert.Equal(t, "recruiter", identity.Role)
+
+	keys, err := service.List(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	assert.ErrorIs(t, service.Revoke(context.Background(), uuid.New(), created.APIKey.ID), ErrKeyNotFound)
+	require.NoError(t, service.Revoke(context.Background(), userID, created.APIKey.ID))
+
+	_, err = service.Authenticate(context.Background(), created.Key)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	keys, _ = service.List(context.Background(), userID)
+	assert.Empty(t, keys)
+}
+
+func TestCreate_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CreateInput
+		err   error
+	}{
+		{"blank name", CreateInput{Name: "  ", Scopes: []string{"read"}}, ErrInvalidName},
+		{"long name", CreateInput{Name: strings.Repeat("a", MaxNameLength+1), Scopes: []string{"read"}}, ErrNameTooLong},
+		{"no scopes", CreateInput{Name: "ci", Scopes: []string{}}, ErrInvalidScopes},
+		{"unknown scope", CreateInput{Name: "ci", Scopes: []string{"read", "admin"}}, ErrInvalidScopes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			service := NewService(NewMockRepository())
+
+			// Execute
+			_, err := service.Create(context.Background(), uuid.New(), tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCreate_TooManyKeys(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository())
+	userID := uuid.New()
+	for i := 0; i < MaxKeysPerUser; i++ {
+		_, err := service.Create(context.Background(), userID, CreateInput{Name: fmt.Sprint("key ", i), Scopes: []string{"read"}})
+		require.NoError(t, err)
+	}
+
+	// Execute
+	_, err := service.Create(context.Background(), userID, CreateInput{Name: "one more", Scopes: []string{"read"}})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTooManyKeys)
+}
+
+func TestIdentity_Allows(t *testing.T) {
+	read := &Identity{Scopes: []string{"read"}}
+	write := &Identity{Scopes: []string{"write"}}
+
+	assert.True(t, read.Allows("GET"))
+	assert.False(t, read.Allows("POST"))
+	assert.False(t, read.Allows("DELETE"))
+	assert.True(t, write.Allows("GET"))
+	assert.True(t, write.Allows("PATCH"))
+}
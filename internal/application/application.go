@@ -4,8 +4,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/pipeline"
 )
 
+// ApplicationStatus is the key of the application's pipeline stage. The
+// constants are the stages of the system pipeline, custom pipelines define
+// their own keys and statuses are checked against them
 type ApplicationStatus string
 
 const (
@@ -14,66 +18,43 @@ const (
 	StatusOffer        ApplicationStatus = "offer"
 	StatusRejected     ApplicationStatus = "rejected"
 	StatusAccepted     ApplicationStatus = "accepted"
+	StatusWithdrawn    ApplicationStatus = "withdrawn"
+	StatusGhosted      ApplicationStatus = "ghosted"
 )
 
 type Application struct {
-	ID            uuid.UUID         `json:"id"`
-	UserID        uuid.UUID         `json:"user_id"`
-	JobID         uuid.UUID         `json:"job_id"`
-	Status        ApplicationStatus `json:"status"`
-	AppliedAt     time.Time         `json:"applied_at"`
-	UpdatedAt     time.Time         `json:"updated_at"`
-	InterviewDate *time.Time        `json:"interview_date,omitempty"` // pointer, because it can be nil
-	OfferDate     *time.Time        `json:"offer_date,omitempty"`
-	Notes         string            `json:"notes,omitempty"`
-	SalaryOffer   string            `json:"salary_offer,omitempty"`
-	ReminderSent  bool              `json:"reminder_sent,omitempty"`
-	FollowUpDate  *time.Time        `json:"follow_up_date,omitempty"`
+	ID              uuid.UUID         `json:"id"`
+	UserID          uuid.UUID         `json:"user_id"`
+	JobID           uuid.UUID         `json:"job_id"`
+	Status          ApplicationStatus `json:"status"`
+	StageID         uuid.UUID         `json:"stage_id"`
+	AppliedAt       time.Time         `json:"applied_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	InterviewDate   *time.Time        `json:"interview_date,omitempty"` // read-only, the date tracked before interview rounds
+	OfferDate       *time.Time        `json:"offer_date,omitempty"`
+	Notes           string            `json:"notes,omitempty"`        // read-only, the free text kept before the notes timeline
+	SalaryOffer     string            `json:"salary_offer,omitempty"` // free text, superseded by structured offers
+	ReminderSent    bool              `json:"reminder_sent,omitempty"`
+	FollowUpDate    *time.Time        `json:"follow_up_date,omitempty"`
+	GhostedAt       *time.Time        `json:"ghosted_at,omitempty"`        // set while the application is ghosted
+	FirstResponseAt *time.Time        `json:"first_response_at,omitempty"` // first move past applied, other than withdrawn or ghosted
 }
 
 type CreateApplicationInput struct {
-	JobID uuid.UUID `json:"job_id" binding:"required"`
-	Notes string    `json:"notes,omitempty"`
+	JobID      uuid.UUID  `json:"job_id" binding:"required"`
+	Notes      string     `json:"notes,omitempty"`       // added as the first note of the timeline
+	PipelineID *uuid.UUID `json:"pipeline_id,omitempty"` // defaults to the user's default pipeline
 }
 
 type UpdateApplicationInput struct {
-	InterviewDate *time.Time `json:"interview_date,omitempty"`
-	OfferDate     *time.Time `json:"offer_date,omitempty"`
-	Notes         string     `json:"notes,omitempty"`
-	SalaryOffer   string     `json:"salary_offer,omitempty"`
-	ReminderSent  bool       `json:"reminder_sent,omitempty"`
-	FollowUpDate  *time.Time `json:"follow_up_date,omitempty"`
-}
-
-// IsValid validate the application status
-func (s ApplicationStatus) IsValid() bool {
-	switch s {
-	case StatusApplied, StatusInterviewing, StatusOffer, StatusRejected, StatusAccepted:
-		return true
-	}
-	return false
+	OfferDate    *time.Time `json:"offer_date,omitempty"`
+	SalaryOffer  string     `json:"salary_offer,omitempty"`
+	ReminderSent bool       `json:"reminder_sent,omitempty"`
+	FollowUpDate *time.Time `json:"follow_up_date,omitempty"`
 }
 
 // CanTransitionTo checks if a status of an Application can transition to
-// a new state based on the business rules
-func (a *Application) CanTransitionTo(newStatus ApplicationStatus) bool {
-	transitions := map[ApplicationStatus][]ApplicationStatus{
-		StatusApplied:      {StatusInterviewing, StatusRejected},
-		StatusInterviewing: {StatusOffer, StatusRejected},
-		StatusOffer:        {StatusAccepted, StatusRejected},
-		StatusAccepted:     {}, // final state
-		StatusRejected:     {}, // final state
-	}
-
-	allowed, exists := transitions[a.Status]
-	if !exists {
-		return false
-	}
-
-	for _, status := range allowed {
-		if status == newStatus {
-			return true
-		}
-	}
-	return false
+// a new state based on the stages and transitions of its pipeline
+func (a *Application) CanTransitionTo(p *pipeline.Pipeline, newStatus ApplicationStatus) bool {
+	return p.CanTransition(string(a.Status), string(newStatus))
 }
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+func TestUpdateApplicationStatus_CustomPipelineStages(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pipelines := pipeline.NewService(pipeline.NewMockRepository())
+	repo := NewMockRepository()
+	service := NewService(repo, nil, nil, pipelines, nil)
+
+	custom, err := pipelines.CreatePipeline(ctx, userID, pipeline.CreatePipelineInput{
+		Name:        "Agency",
+		Stages:      []pipeline.StageInput{{Key: "sent", Name: "Sent"}, {Key: "final_round", Name: "Final round"}},
+		Transitions: []pipeline.Transition{{From: "sent", To: "final_round"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	app, err := repo.Create(ctx, &Application{UserID: userID, JobID: uuid.New(), Status: "sent", StageID: custom.Stages[0].ID})
+	if err != nil {
+		t.Fatalf("failed to create application: %v", err)
+	}
+
+	// Keys of the system pipeline mean nothing on a custom one
+	if err := service.UpdateApplicationStatus(ctx, app.ID, StatusInterviewing); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+
+	if err := service.UpdateApplicationStatus(ctx, app.ID, "final_round"); err != nil {
+		t.Fatalf("expected a custom stage to be a valid status, got %v", err)
+	}
+	if app.Status != "final_round" || app.StageID != custom.Stages[1].ID {
+		t.Fatalf("expected the application in the final round stage, got %s", app.Status)
+	}
+}
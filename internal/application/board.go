@@ -0,0 +1,112 @@
+package application
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Board groups a user's applications into one column per status
+type Board struct {
+	PipelineID uuid.UUID     `json:"pipeline_id"`
+	Columns    []BoardColumn `json:"columns"`
+	Total      int           `json:"total"`
+}
+
+// BoardColumn holds the cards of a status in board order. Columns follow
+// the stages of the user's default pipeline, statuses of other pipelines
+// come after them
+type BoardColumn struct {
+	Status     ApplicationStatus `json:"status"`
+	Name       string            `json:"name"`
+	IsTerminal bool              `json:"is_terminal"`
+	Cards      []*BoardCard      `json:"cards"`
+	Total      int               `json:"total"`
+}
+
+// BoardCard is an application with the job summary the board shows
+type BoardCard struct {
+	ID              uuid.UUID         `json:"id"`
+	Status          ApplicationStatus `json:"status"`
+	StageID         uuid.UUID         `json:"stage_id"`
+	AppliedAt       time.Time         `json:"applied_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	NextInterviewAt *time.Time        `json:"next_interview_at,omitempty"` // earliest pending interview round to come
+	FollowUpDate    *time.Time        `json:"follow_up_date,omitempty"`
+	Job             BoardJob          `json:"job"`
+}
+
+type BoardJob struct {
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Company  string    `json:"company"`
+	Location string    `json:"location"`
+	Link     string    `json:"link"`
+	Source   string    `json:"source"`
+}
+
+// MoveInput moves a card to a position of a column. Status defaults to the
+// current one, which only reorders the card
+type MoveInput struct {
+	Status   ApplicationStatus `json:"status,omitempty"`
+	Position *int              `json:"position" binding:"required"`
+}
+
+// placeCard returns the column order with id at position, which is clamped
+// to the end of the column
+func placeCard(column []uuid.UUID, id uuid.UUID, position int) []uuid.UUID {
+	ordered := make([]uuid.UUID, 0, len(column)+1)
+	for _, other := range column {
+		if other != id {
+			ordered = append(ordered, other)
+		}
+	}
+
+	if position > len(ordered) {
+		position = len(ordered)
+	}
+
+	ordered = append(ordered, uuid.Nil)
+	copy(ordered[position+1:], ordered[position:])
+	ordered[position] = id
+	return ordered
+}
+
+// buildBoard lays the cards, already in board order within each status,
+// into the stage columns. Every stage gets a column even when empty
+func buildBoard(pipelineID uuid.UUID, stages []BoardColumn, cards []*BoardCard) *Board {
+	board := &Board{PipelineID: pipelineID, Columns: stages}
+
+	index := make(map[ApplicationStatus]int, len(stages))
+	for i := range board.Columns {
+		board.Columns[i].Cards = []*BoardCard{}
+		index[board.Columns[i].Status] = i
+	}
+
+	extra := []BoardColumn{}
+	extraIndex := map[ApplicationStatus]int{}
+	for _, card := range cards {
+		board.Total++
+		if i, ok := index[card.Status]; ok {
+			board.Columns[i].Cards = append(board.Columns[i].Cards, card)
+			continue
+		}
+
+		i, ok := extraIndex[card.Status]
+		if !ok {
+			i = len(extra)
+			extraIndex[card.Status] = i
+			extra = append(extra, BoardColumn{Status: card.Status, Name: string(card.Status), Cards: []*BoardCard{}})
+		}
+		extra[i].Cards = append(extra[i].Cards, card)
+	}
+
+	sort.Slice(extra, func(i, j int) bool { return extra[i].Status < extra[j].Status })
+	board.Columns = append(board.Columns, extra...)
+
+	for i := range board.Columns {
+		board.Columns[i].Total = len(board.Columns[i].Cards)
+	}
+	return board
+}
@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/job"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+func newBoardTestService(t *testing.T) (Service, *mockRepository, uuid.UUID, []*Application) {
+	t.Helper()
+
+	repo := NewMockRepository().(*mockRepository)
+	service := NewService(repo, nil, nil, pipeline.NewService(pipeline.NewMockRepository()), nil)
+	userID := uuid.New()
+
+	applied, _ := pipeline.DefaultPipeline().StageByKey("applied")
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	apps := make([]*Application, 3)
+	for i := range apps {
+		j := &job.Job{ID: uuid.New(), Title: "Go Developer", Company: "Acme", Source: "linkedin"}
+		repo.addJob(j)
+
+		app, err := repo.Create(context.Background(), &Application{
+			UserID:    userID,
+			JobID:     j.ID,
+			Status:    StatusApplied,
+			StageID:   applied.ID,
+			AppliedAt: base.AddDate(0, 0, i),
+		})
+		if err != nil {
+			t.Fatalf("failed to create application: %v", err)
+		}
+		apps[i] = app
+	}
+
+	return service, repo, userID, apps
+}
+
+func columnIDs(t *testing.T, board *Board, status ApplicationStatus) []uuid.UUID {
+	t.Helper()
+
+	for _, column := range board.Columns {
+		if column.Status == status {
+			ids := make([]uuid.UUID, len(column.Cards))
+			for i, card := range column.Cards {
+				ids[i] = card.ID
+			}
+			return ids
+		}
+	}
+	t.Fatalf("no %s column on the board", status)
+	return nil
+}
+
+func TestGetBoard_ColumnsFollowPipeline(t *testing.T) {
+	service, _, userID, apps := newBoardTestService(t)
+
+	board, err := service.GetBoard(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stages := pipeline.DefaultPipeline().Stages
+	if len(board.Columns) != len(stages) || board.Total != 3 {
+		t.Fatalf("expected %d columns and 3 cards, got %d and %d", len(stages), len(board.Columns), board.Total)
+	}
+	for i, stage := range stages {
+		if string(board.Columns[i].Status) != stage.Key || board.Columns[i].Cards == nil {
+			t.Fatalf("expected column %d to be %s, got %+v", i, stage.Key, board.Columns[i])
+		}
+	}
+
+	ids := columnIDs(t, board, StatusApplied)
+	want := []uuid.UUID{apps[2].ID, apps[1].ID, apps[0].ID}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected newest applications first, got %v", ids)
+		}
+	}
+	if board.Columns[0].Cards[0].Job.Company != "Acme" {
+		t.Fatalf("expected job summary on cards, got %+v", board.Columns[0].Cards[0].Job)
+	}
+}
+
+func TestGetBoard_ShowsNextInterview(t *testing.T) {
+	service, repo, userID, apps := newBoardTestService(t)
+
+	now := time.Now()
+	next := now.Add(24 * time.Hour).Truncate(time.Second)
+	repo.addInterview(apps[0].ID, now.Add(-24*time.Hour))
+	repo.addInterview(apps[0].ID, now.Add(72*time.Hour))
+	repo.addInterview(apps[0].ID, next)
+
+	board, err := service.GetBoard(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, card := range board.Columns[0].Cards {
+		switch {
+		case card.ID == apps[0].ID && (card.NextInterviewAt == nil || !card.NextInterviewAt.Equal(next)):
+			t.Fatalf("expected the earliest upcoming round %s, got %v", next, card.NextInterviewAt)
+		case card.ID != apps[0].ID && card.NextInterviewAt != nil:
+			t.Fatalf("expected no interview on card %s, got %s", card.ID, card.NextInterviewAt)
+		}
+	}
+}
+
+func TestMoveApplication_ReordersAndChangesColumn(t *testing.T) {
+	service, _, userID, apps := newBoardTestService(t)
+	ctx := context.Background()
+
+	// Move the newest card to the bottom of its column
+	if err := service.MoveApplication(ctx, apps[2].ID, MoveInput{Position: intPtr(10)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	board, _ := service.GetBoard(ctx, userID)
+	ids := columnIDs(t, board, StatusApplied)
+	want := []uuid.UUID{apps[1].ID, apps[0].ID, apps[2].ID}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected manual order %v, got %v", want, ids)
+		}
+	}
+
+	// Moving to another column goes through the pipeline transitions
+	if err := service.MoveApplication(ctx, apps[0].ID, MoveInput{Status: StatusInterviewing, Position: intPtr(0)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	board, _ = service.GetBoard(ctx, userID)
+	if ids := columnIDs(t, board, StatusInterviewing); len(ids) != 1 || ids[0] != apps[0].ID {
+		t.Fatalf("expected the card in the interviewing column, got %v", ids)
+	}
+	if ids := columnIDs(t, board, StatusApplied); len(ids) != 2 || ids[0] != apps[1].ID {
+		t.Fatalf("expected the applied column to keep its order, got %v", ids)
+	}
+
+	if err := service.MoveApplication(ctx, apps[0].ID, MoveInput{Status: StatusAccepted, Position: intPtr(0)}); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+	if err := service.MoveApplication(ctx, apps[0].ID, MoveInput{Position: intPtr(-1)}); err != ErrInvalidPosition {
+		t.Fatalf("expected ErrInvalidPosition, got %v", err)
+	}
+}
+
+// failingMoveRepo fails every board move, status changes must only be
+// saved by MoveCard itself
+type failingMoveRepo struct {
+	*mockRepository
+}
+
+func (r failingMoveRepo) MoveCard(ctx context.Context, userID, id uuid.UUID, status ApplicationStatus, stageID *uuid.UUID, position int) error {
+	return errors.New("connection reset")
+}
+
+func TestMoveApplication_FailedMoveKeepsStatus(t *testing.T) {
+	_, repo, _, apps := newBoardTestService(t)
+	service := NewService(failingMoveRepo{repo}, nil, nil, pipeline.NewService(pipeline.NewMockRepository()), nil)
+	ctx := context.Background()
+
+	if err := service.MoveApplication(ctx, apps[0].ID, MoveInput{Status: StatusInterviewing, Position: intPtr(0)}); err == nil {
+		t.Fatal("expected the move to fail")
+	}
+
+	app, _ := repo.GetByID(ctx, apps[0].ID)
+	if app.Status != StatusApplied {
+		t.Fatalf("expected the application to stay applied, got %s", app.Status)
+	}
+}
+
+func TestPlaceCard(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	got := placeCard([]uuid.UUID{a, b, c}, c, 0)
+	if len(got) != 3 || got[0] != c || got[1] != a || got[2] != b {
+		t.Fatalf("unexpected order %v", got)
+	}
+
+	got = placeCard([]uuid.UUID{a, b}, c, 5)
+	if len(got) != 3 || got[2] != c {
+		t.Fatalf("expected new card at the end, got %v", got)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,191 @@
+package application
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportFormat string
+
+const (
+	FormatCSV  ExportFormat = "csv"
+	FormatJSON ExportFormat = "json"
+	FormatXLSX ExportFormat = "xlsx"
+)
+
+// IsValid validate the export format
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case FormatCSV, FormatJSON, FormatXLSX:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the MIME type of files in the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// ExportFilter narrows the exported applications. AppliedTo is exclusive
+type ExportFilter struct {
+	Statuses    []ApplicationStatus
+	AppliedFrom *time.Time
+	AppliedTo   *time.Time
+}
+
+// matches reports whether an application passes the filter
+func (f ExportFilter) matches(app *Application) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, app.Status) {
+		return false
+	}
+	if f.AppliedFrom != nil && app.AppliedAt.Before(*f.AppliedFrom) {
+		return false
+	}
+	if f.AppliedTo != nil && !app.AppliedAt.Before(*f.AppliedTo) {
+		return false
+	}
+	return true
+}
+
+// ExportRow is an application joined with the job it was made for
+type ExportRow struct {
+	ID             uuid.UUID         `json:"id"`
+	Status         ApplicationStatus `json:"status"`
+	AppliedAt      time.Time         `json:"applied_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	InterviewDate  *time.Time        `json:"interview_date"`
+	OfferDate      *time.Time        `json:"offer_date"`
+	FollowUpDate   *time.Time        `json:"follow_up_date"`
+	Notes          string            `json:"notes"`
+	SalaryOffer    string            `json:"salary_offer"`
+	JobTitle       string            `json:"job_title"`
+	JobCompany     string            `json:"job_company"`
+	JobLocation    string            `json:"job_location"`
+	JobLink        string            `json:"job_link"`
+	JobSalaryRange string            `json:"job_salary_range"`
+}
+
+// exportColumns is the header of tabular exports, in the order of
+// exportCell values
+var exportColumns = []string{
+	"id", "status", "applied_at", "updated_at", "interview_date", "offer_date", "follow_up_date",
+	"job_title", "job_company", "job_location", "job_link", "job_salary_range", "salary_offer", "notes",
+}
+
+// exportCell is a value of a tabular export. Times are kept apart from text
+// so each format can render them natively
+type exportCell struct {
+	text string
+	time *time.Time
+}
+
+func (r *ExportRow) cells() []exportCell {
+	applied := r.AppliedAt
+	updated := r.UpdatedAt
+
+	return []exportCell{
+		{text: r.ID.String()},
+		{text: string(r.Status)},
+		{time: &applied},
+		{time: &updated},
+		{time: r.InterviewDate},
+		{time: r.OfferDate},
+		{time: r.FollowUpDate},
+		{text: r.JobTitle},
+		{text: r.JobCompany},
+		{text: r.JobLocation},
+		{text: r.JobLink},
+		{text: r.JobSalaryRange},
+		{text: r.SalaryOffer},
+		{text: r.Notes},
+	}
+}
+
+// WriteExport encodes rows in the given format, writing them out as they are
+// encoded
+func WriteExport(w io.Writer, format ExportFormat, rows []*ExportRow) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatXLSX:
+		return writeXLSX(w, rows)
+	default:
+		return writeJSON(w, rows)
+	}
+}
+
+func writeCSV(w io.Writer, rows []*ExportRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(exportColumns))
+	for _, row := range rows {
+		for i, cell := range row.cells() {
+			switch {
+			case cell.time != nil:
+				record[i] = cell.time.UTC().Format(time.RFC3339)
+			case cell.text != "":
+				record[i] = neutralizeFormula(cell.text)
+			default:
+				record[i] = ""
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJSON writes rows as a JSON array, one element at a time
+func writeJSON(w io.Writer, rows []*ExportRow) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		b, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
+
+// neutralizeFormula prefixes text that spreadsheet apps would evaluate as a
+// formula, since notes and scraped job fields are user controlled
+func neutralizeFormula(s string) string {
+	if strings.ContainsAny(s[:1], "=+-@\t\r") {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,177 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/job"
+)
+
+func TestExportApplications_FiltersAndJoinsJobs(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	service := NewService(repo, nil, nil, nil, nil)
+	userID := uuid.New()
+
+	j := &job.Job{ID: uuid.New(), Title: "Go Developer", Company: "Acme", Location: "Remote", Link: "https://jobs.example.com/1", SalaryRange: "100k-120k"}
+	repo.addJob(j)
+
+	march := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, app := range []*Application{
+		{UserID: userID, JobID: j.ID, Status: StatusApplied, AppliedAt: march},
+		{UserID: userID, JobID: j.ID, Status: StatusRejected, AppliedAt: march.AddDate(0, 0, 1)},
+		{UserID: userID, JobID: j.ID, Status: StatusApplied, AppliedAt: march.AddDate(0, 1, 0)},
+		{UserID: uuid.New(), JobID: j.ID, Status: StatusApplied, AppliedAt: march},
+	} {
+		if _, err := repo.Create(context.Background(), app); err != nil {
+			t.Fatalf("failed to create application: %v", err)
+		}
+	}
+
+	to := march.AddDate(0, 0, 10)
+	rows, err := service.ExportApplications(context.Background(), userID, ExportFilter{
+		Statuses:  []ApplicationStatus{StatusApplied},
+		AppliedTo: &to,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if rows[0].JobCompany != "Acme" || rows[0].JobSalaryRange != "100k-120k" {
+		t.Fatalf("expected job data to be joined, got %+v", rows[0])
+	}
+
+	from := march.AddDate(0, 2, 0)
+	if _, err := service.ExportApplications(context.Background(), userID, ExportFilter{AppliedFrom: &from, AppliedTo: &to}); err != ErrInvalidDateRange {
+		t.Fatalf("expected ErrInvalidDateRange, got %v", err)
+	}
+}
+
+func TestWriteExport_CSV(t *testing.T) {
+	interview := time.Date(2026, 3, 5, 15, 30, 0, 0, time.UTC)
+	rows := []*ExportRow{testExportRow(&interview)}
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, FormatCSV, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+
+	if len(records) != 2 || len(records[1]) != len(exportColumns) {
+		t.Fatalf("expected header and one row of %d columns, got %v", len(exportColumns), records)
+	}
+
+	record := map[string]string{}
+	for i, column := range records[0] {
+		record[column] = records[1][i]
+	}
+
+	if record["job_company"] != "Acme, Inc." {
+		t.Fatalf("unexpected company %q", record["job_company"])
+	}
+	if record["interview_date"] != "2026-03-05T15:30:00Z" || record["offer_date"] != "" {
+		t.Fatalf("unexpected dates %q and %q", record["interview_date"], record["offer_date"])
+	}
+	if record["notes"] != `'=HYPERLINK("http://evil")` {
+		t.Fatalf("expected formula to be neutralized, got %q", record["notes"])
+	}
+}
+
+func TestWriteExport_JSON(t *testing.T) {
+	rows := []*ExportRow{testExportRow(nil), testExportRow(nil)}
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, FormatJSON, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded []ExportRow
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].JobTitle != "Go Developer" {
+		t.Fatalf("unexpected rows %+v", decoded)
+	}
+}
+
+func TestWriteExport_XLSX(t *testing.T) {
+	interview := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, FormatXLSX, []*ExportRow{testExportRow(&interview)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(b)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("expected workbook part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">id</t></is></c>`,
+		`<t xml:space="preserve">Acme, Inc.</t>`,
+		// 2026-03-05 12:00 is serial day 46086.5
+		`<c r="E2" s="2"><v>46086.5</v></c>`,
+		`=HYPERLINK(&#34;http://evil&#34;)`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Fatalf("expected sheet to contain %q, got:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestCellRef(t *testing.T) {
+	tests := map[int]string{0: "A1", 25: "Z1", 26: "AA1", 27: "AB1", 701: "ZZ1", 702: "AAA1"}
+	for col, want := range tests {
+		if got := cellRef(col, 1); got != want {
+			t.Fatalf("column %d: expected %s, got %s", col, want, got)
+		}
+	}
+}
+
+func testExportRow(interview *time.Time) *ExportRow {
+	return &ExportRow{
+		ID:            uuid.New(),
+		Status:        StatusInterviewing,
+		AppliedAt:     time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		InterviewDate: interview,
+		Notes:         `=HYPERLINK("http://evil")`,
+		JobTitle:      "Go Developer",
+		JobCompany:    "Acme, Inc.",
+		JobLocation:   "Remote",
+		JobLink:       "https://jobs.example.com/1",
+	}
+}
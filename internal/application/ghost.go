@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// GhostDetector periodically flags applications that never got an answer
+// as ghosted. Moving a ghosted application to another stage reverts it
+type GhostDetector struct {
+	service     Service
+	inactiveFor time.Duration
+	interval    time.Duration
+	logger      *log.Logger
+}
+
+func NewGhostDetector(service Service, inactiveFor, interval time.Duration, logger *log.Logger) *GhostDetector {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	if inactiveFor <= 0 {
+		inactiveFor = 21 * 24 * time.Hour
+	}
+
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &GhostDetector{
+		service:     service,
+		inactiveFor: inactiveFor,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+// RunOnce marks stale applications and returns how many were ghosted
+func (d *GhostDetector) RunOnce(ctx context.Context) (int, error) {
+	applications, err := d.service.MarkGhostedApplications(ctx, d.inactiveFor)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(applications), nil
+}
+
+func (d *GhostDetector) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.Printf("ghost detector started: inactive_for=%s interval=%s", d.inactiveFor, d.interval)
+
+	for {
+		count, err := d.RunOnce(ctx)
+		if err != nil {
+			d.logger.Printf("ghost detector run failed: %v", err)
+		} else if count > 0 {
+			d.logger.Printf("ghost detector marked %d applications as ghosted", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Println("ghost detector stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/webhook"
+)
+
+func TestGhostDetector_RunOnce_MarksStaleApplications(t *testing.T) {
+	repo := NewMockRepository()
+	service := NewService(repo, nil, nil, nil, nil)
+
+	stale, _ := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusInterviewing})
+	fresh, _ := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusApplied})
+	offer, _ := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusOffer})
+
+	stale.UpdatedAt = time.Now().Add(-30 * 24 * time.Hour)
+	offer.UpdatedAt = time.Now().Add(-30 * 24 * time.Hour)
+
+	detector := NewGhostDetector(service, 21*24*time.Hour, time.Hour, log.Default())
+	count, err := detector.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce returned error: %v", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("expected 1 ghosted application, got %d", count)
+	}
+
+	if stale.Status != StatusGhosted || stale.GhostedAt == nil {
+		t.Errorf("expected stale application to be ghosted, got status %s", stale.Status)
+	}
+
+	if fresh.Status != StatusApplied {
+		t.Errorf("expected fresh application to stay applied, got %s", fresh.Status)
+	}
+
+	if offer.Status != StatusOffer {
+		t.Errorf("expected offer to stay untouched, got %s", offer.Status)
+	}
+}
+
+func TestMarkGhostedApplications_PublishesStatusChanges(t *testing.T) {
+	repo := NewMockRepository()
+	events := &recordingPublisher{}
+	service := NewService(repo, nil, nil, nil, events)
+
+	applied, _ := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusApplied})
+	interviewing, _ := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusInterviewing})
+	applied.UpdatedAt = time.Now().Add(-30 * 24 * time.Hour)
+	interviewing.UpdatedAt = time.Now().Add(-30 * 24 * time.Hour)
+
+	ghosted, err := service.MarkGhostedApplications(context.Background(), 21*24*time.Hour)
+	if err != nil {
+		t.Fatalf("MarkGhostedApplications returned error: %v", err)
+	}
+
+	if len(ghosted) != 2 || len(events.events) != 2 {
+		t.Fatalf("expected 2 ghosted applications and 2 events, got %d and %d", len(ghosted), len(events.events))
+	}
+
+	from := map[uuid.UUID]ApplicationStatus{}
+	for _, event := range events.events {
+		change, ok := event.Data.(*StatusChange)
+		if event.Type != webhook.EventApplicationStatusChanged || !ok {
+			t.Fatalf("expected a status change event, got %s with %T", event.Type, event.Data)
+		}
+		if event.UserID != change.Application.UserID || change.To != StatusGhosted {
+			t.Fatalf("unexpected event %+v for change %+v", event, change)
+		}
+		from[change.Application.ID] = change.From
+	}
+	if from[applied.ID] != StatusApplied || from[interviewing.ID] != StatusInterviewing {
+		t.Fatalf("expected each event to carry the status left, got %v", from)
+	}
+}
+
+// recordingPublisher keeps the events published
+type recordingPublisher struct {
+	events []webhook.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestMockRepository_UpdateStatus_ClearsGhostedAt(t *testing.T) {
+	repo := NewMockRepository()
+
+	app, _ := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusApplied})
+	app.UpdatedAt = time.Now().Add(-48 * time.Hour)
+
+	if _, err := repo.MarkGhosted(context.Background(), time.Now().Add(-24*time.Hour)); err != nil {
+		t.Fatalf("MarkGhosted returned error: %v", err)
+	}
+
+	if err := repo.UpdateStatus(context.Background(), app.ID, StatusInterviewing, uuid.New()); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	if app.GhostedAt != nil {
+		t.Errorf("expected ghosted_at to be cleared after leaving ghosted")
+	}
+}
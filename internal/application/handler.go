@@ -1,11 +1,17 @@
 package application
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
 )
 
 type Handler interface {
@@ -16,6 +22,11 @@ type Handler interface {
 	UpdateApplicationHandler(c *gin.Context)
 	UpdateStatusHandler(c *gin.Context)
 	DeleteApplicationHandler(c *gin.Context)
+	ExportApplicationsHandler(c *gin.Context)
+	ImportApplicationsHandler(c *gin.Context)
+	GetStatsHandler(c *gin.Context)
+	GetBoardHandler(c *gin.Context)
+	MoveApplicationHandler(c *gin.Context)
 }
 
 type GinHandler struct {
@@ -60,7 +71,7 @@ func (h *GinHandler) CreateApplicationHandler(c *gin.Context) {
 			status = http.StatusConflict
 		}
 
-		if errors.Is(err, ErrJobNotFound) {
+		if errors.Is(err, ErrJobNotFound) || errors.Is(err, ErrPipelineNotFound) {
 			status = http.StatusNotFound
 		}
 
@@ -110,8 +121,13 @@ func (h *GinHandler) GetUserApplicationsHandler(c *gin.Context) {
 	}
 
 	response := make([]gin.H, 0)
+	ghosted := 0
 
 	for _, app := range applications {
+		if app.Status == StatusGhosted {
+			ghosted++
+		}
+
 		response = append(response, gin.H{
 			"id":             app.ID,
 			"job_id":         app.JobID,
@@ -122,12 +138,14 @@ func (h *GinHandler) GetUserApplicationsHandler(c *gin.Context) {
 			"interview_date": app.InterviewDate,
 			"offer_date":     app.OfferDate,
 			"follow_up_date": app.FollowUpDate,
+			"ghosted_at":     app.GhostedAt,
 		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"applications": response,
 		"total":        len(response),
+		"ghosted":      ghosted,
 	})
 }
 
@@ -183,21 +201,32 @@ func (h *GinHandler) GetApplicationHandler(c *gin.Context) {
 			"interview_date": app.InterviewDate,
 			"offer_date":     app.OfferDate,
 			"follow_up_date": app.FollowUpDate,
+			"ghosted_at":     app.GhostedAt,
 		},
 	})
 }
 
-// 4. GET /api/jobs/:jobID/applications - sees who applied
+// 4. GET /api/jobs/:jobID/applications - sees who applied, for the job's
+// recruiter or an admin
 func (h *GinHandler) GetJobApplicationsHandler(c *gin.Context) {
-	// verify if user is admin
-	userRole, exists := c.Get("userRole")
-	if !exists || userRole != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "admin access required",
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
 		})
 		return
 	}
 
+	role := auth.Role(c.GetString("userRole"))
+
 	jobIDStr := c.Param("jobID")
 	if jobIDStr == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -214,9 +243,16 @@ func (h *GinHandler) GetJobApplicationsHandler(c *gin.Context) {
 		return
 	}
 
-	jobApplications, err := h.service.GetJobApplications(c.Request.Context(), jobID)
+	jobApplications, err := h.service.GetJobApplications(c.Request.Context(), userID, role, jobID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrJobNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrNotJobOwner):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -359,7 +395,11 @@ func (h *GinHandler) UpdateStatusHandler(c *gin.Context) {
 
 	err = h.service.UpdateApplicationStatus(c.Request.Context(), app.ID, input.Status)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidStatus) || errors.Is(err, ErrInvalidTransition) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -417,3 +457,349 @@ func (h *GinHandler) DeleteApplicationHandler(c *gin.Context) {
 		"message": "application deleted successfully",
 	})
 }
+
+// 8. GET /api/applications/export?format=csv|json|xlsx&status=&from=&to= - download applications with job data
+func (h *GinHandler) ExportApplicationsHandler(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return
+	}
+
+	format := ExportFormat(strings.ToLower(c.DefaultQuery("format", string(FormatCSV))))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "format must be one of csv, json or xlsx",
+		})
+		return
+	}
+
+	filter := ExportFilter{}
+	for _, value := range c.QueryArray("status") {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filter.Statuses = append(filter.Statuses, ApplicationStatus(status))
+			}
+		}
+	}
+
+	if filter.AppliedFrom, err = parseExportDate(c.Query("from"), false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from must be a date (YYYY-MM-DD) or RFC 3339 time",
+		})
+		return
+	}
+	if filter.AppliedTo, err = parseExportDate(c.Query("to"), true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "to must be a date (YYYY-MM-DD) or RFC 3339 time",
+		})
+		return
+	}
+
+	rows, err := h.service.ExportApplications(c.Request.Context(), userID, filter)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidDateRange) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	filename := "applications-" + time.Now().UTC().Format("20060102") + "." + string(format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	// The body is streamed, so a failure past this point can only be logged
+	if err := WriteExport(c.Writer, format, rows); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// maxImportSize bounds the size of an import request body
+const maxImportSize = 5 << 20
+
+// 9. POST /api/applications/import?dry_run=true - import applications from a CSV file
+//
+// The multipart form carries the CSV in "file" and optionally a JSON
+// "mapping" of fields to header names, a one character "delimiter" and the
+// "pipeline_id" whose stages the status column refers to
+func (h *GinHandler) ImportApplicationsHandler(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "a csv file is required in the file field",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	input := ImportInput{}
+
+	if input.DryRun, err = strconv.ParseBool(c.DefaultQuery("dry_run", c.DefaultPostForm("dry_run", "false"))); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "dry_run must be a boolean",
+		})
+		return
+	}
+
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &input.Mapping); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "mapping must be a JSON object of field to column name",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	if raw := c.PostForm("delimiter"); raw != "" {
+		if utf8.RuneCountInString(raw) != 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "delimiter must be a single character",
+			})
+			return
+		}
+		input.Delimiter, _ = utf8.DecodeRuneInString(raw)
+	}
+
+	if raw := c.PostForm("pipeline_id"); raw != "" {
+		pipelineID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid pipeline id format",
+			})
+			return
+		}
+		input.PipelineID = &pipelineID
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+	input.File = file
+
+	report, err := h.service.ImportApplications(c.Request.Context(), userID, input)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrEmptyImport),
+			errors.Is(err, ErrTooManyRows),
+			errors.Is(err, ErrInvalidMapping),
+			errors.Is(err, ErrMalformedCSV),
+			errors.Is(err, ErrMissingImportCol):
+			status = http.StatusBadRequest
+		case errors.Is(err, ErrPipelineNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if report.Created > 0 {
+		status = http.StatusCreated
+	}
+	c.JSON(status, report)
+}
+
+// 10. GET /api/applications/stats?weeks=12 - funnel metrics for the dashboard
+func (h *GinHandler) GetStatsHandler(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return
+	}
+
+	weeks := 0
+	if value := c.Query("weeks"); value != "" {
+		if weeks, err = strconv.Atoi(value); err != nil || weeks < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": ErrInvalidStatsWeeks.Error(),
+			})
+			return
+		}
+	}
+
+	stats, err := h.service.GetStats(c.Request.Context(), userID, weeks)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidStatsWeeks) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// 11. GET /api/applications/board - applications grouped in status columns with job summaries
+func (h *GinHandler) GetBoardHandler(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return
+	}
+
+	board, err := h.service.GetBoard(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// 12. PATCH /api/applications/:id/move - move a card to a position, optionally in another status column
+func (h *GinHandler) MoveApplicationHandler(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return
+	}
+
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return
+	}
+
+	app, err := h.service.GetApplicationByID(c.Request.Context(), appID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "application not found",
+		})
+		return
+	}
+
+	if app.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "you can only move your own applications",
+		})
+		return
+	}
+
+	var input MoveInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "position is required",
+		})
+		return
+	}
+
+	if err := h.service.MoveApplication(c.Request.Context(), app.ID, input); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrInvalidPosition),
+			errors.Is(err, ErrInvalidStatus),
+			errors.Is(err, ErrInvalidTransition):
+			status = http.StatusBadRequest
+		case errors.Is(err, ErrApplicationNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "application moved successfully",
+	})
+}
+
+// parseExportDate parses a YYYY-MM-DD date or RFC 3339 time. A plain date
+// used as an upper bound includes that whole day
+func parseExportDate(value string, upper bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
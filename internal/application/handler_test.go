@@ -4,13 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/job"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -215,15 +220,224 @@ func TestUpdateStatusHandler_ValidTransition(t *testing.T) {
 	assert.Equal(t, "status updated successfully", response["message"])
 }
 
+func TestUpdateStatusHandler_InvalidTransition(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+
+	application := &Application{
+		ID:        appID,
+		UserID:    userID,
+		JobID:     uuid.New(),
+		Status:    StatusApplied,
+		AppliedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockService := &mockApplicationService{
+		mockGetApplicationByID: func(ctx context.Context, id uuid.UUID) (*Application, error) {
+			return application, nil
+		},
+		mockUpdateApplicationStatus: func(ctx context.Context, id uuid.UUID, status ApplicationStatus) error {
+			return ErrInvalidTransition
+		},
+	}
+
+	handler := NewGinHandler(mockService)
+
+	reqBody := map[string]string{"status": string(StatusAccepted)}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PATCH", "/applications/"+appID.String()+"/status", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.UpdateStatusHandler(c)
+
+	// Assert - transitions not allowed by the pipeline are client errors
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportApplicationsHandler_CSVWithFilters(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	var received ExportFilter
+
+	mockService := &mockApplicationService{
+		mockExportApplications: func(ctx context.Context, uID uuid.UUID, filter ExportFilter) ([]*ExportRow, error) {
+			assert.Equal(t, userID, uID)
+			received = filter
+			return []*ExportRow{{ID: uuid.New(), Status: StatusApplied, JobTitle: "Go Developer", JobCompany: "Acme"}}, nil
+		},
+	}
+	handler := NewGinHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/applications/export?format=csv&status=applied,interviewing&from=2026-03-01&to=2026-03-31", nil)
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.ExportApplicationsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+	assert.Contains(t, w.Body.String(), "Go Developer,Acme")
+
+	assert.Equal(t, []ApplicationStatus{StatusApplied, StatusInterviewing}, received.Statuses)
+	require.NotNil(t, received.AppliedFrom)
+	require.NotNil(t, received.AppliedTo)
+	assert.Equal(t, "2026-04-01", received.AppliedTo.Format(time.DateOnly), "a plain to date includes the whole day")
+}
+
+func TestExportApplicationsHandler_InvalidFormat(t *testing.T) {
+	// Setup
+	handler := NewGinHandler(&mockApplicationService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/applications/export?format=pdf", nil)
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.ExportApplicationsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestImportApplicationsHandler_DryRun(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	var received ImportInput
+	var content []byte
+
+	mockService := &mockApplicationService{
+		mockImportApplications: func(ctx context.Context, uID uuid.UUID, input ImportInput) (*ImportReport, error) {
+			received = input
+			content, _ = io.ReadAll(input.File)
+			return &ImportReport{DryRun: input.DryRun, Total: 1, Valid: 1, Rows: []ImportRowResult{{Line: 2, Status: RowValid}}}, nil
+		},
+	}
+	handler := NewGinHandler(mockService)
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, _ := form.CreateFormFile("file", "applications.csv")
+	part.Write([]byte("Position;Empresa;URL\nGo;Acme;https://jobs.example.com/1\n"))
+	form.WriteField("mapping", `{"title":"Position","company":"Empresa","link":"URL"}`)
+	form.WriteField("delimiter", ";")
+	form.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/applications/import?dry_run=true", body)
+	c.Request.Header.Set("Content-Type", form.FormDataContentType())
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.ImportApplicationsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, received.DryRun)
+	assert.Equal(t, ';', received.Delimiter)
+	assert.Equal(t, "Position", received.Mapping[ImportFieldTitle])
+	assert.Contains(t, string(content), "Go;Acme")
+
+	var report ImportReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, 1, report.Valid)
+}
+
+func TestImportApplicationsHandler_MissingFile(t *testing.T) {
+	// Setup
+	handler := NewGinHandler(&mockApplicationService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/applications/import", strings.NewReader(""))
+	c.Request.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.ImportApplicationsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetJobApplicationsHandler_Access(t *testing.T) {
+	// Setup
+	recruiter := uuid.New()
+	service, repo, jobs := newImportService()
+	posted, err := jobs.CreateJob(context.Background(), job.CreateJobInput{Title: "SRE", Company: "Initech", Source: "manual", Link: "https://jobs.example.com/sre", CreatedBy: &recruiter})
+	require.NoError(t, err)
+
+	_, err = repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: posted.ID, Status: StatusApplied, AppliedAt: time.Now()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		role   string
+		status int
+		total  int
+	}{
+		{"job recruiter", recruiter, "recruiter", http.StatusOK, 1},
+		{"other recruiter", uuid.New(), "recruiter", http.StatusForbidden, 0},
+		{"candidate", uuid.New(), "candidate", http.StatusForbidden, 0},
+		{"admin", uuid.New(), "admin", http.StatusOK, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewGinHandler(service)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/jobs/"+posted.ID.String()+"/applications", nil)
+			c.Params = gin.Params{{Key: "jobID", Value: posted.ID.String()}}
+			c.Set("userID", tt.userID.String())
+			c.Set("userRole", tt.role)
+
+			// Execute
+			handler.GetJobApplicationsHandler(c)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				var response struct {
+					Applications []map[string]any `json:"applications"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.Applications, tt.total)
+			}
+		})
+	}
+}
+
 // Mock service for testing
 type mockApplicationService struct {
 	mockCreateApplication       func(context.Context, uuid.UUID, CreateApplicationInput) (*Application, error)
 	mockGetApplicationByID      func(context.Context, uuid.UUID) (*Application, error)
 	mockGetUserApplications     func(context.Context, uuid.UUID) ([]*Application, error)
-	mockGetJobApplications      func(context.Context, uuid.UUID) ([]*Application, error)
+	mockGetJobApplications      func(context.Context, uuid.UUID, auth.Role, uuid.UUID) ([]*Application, error)
 	mockUpdateApplication       func(context.Context, uuid.UUID, UpdateApplicationInput) error
 	mockUpdateApplicationStatus func(context.Context, uuid.UUID, ApplicationStatus) error
 	mockDelete                  func(context.Context, uuid.UUID) error
+	mockMarkGhosted             func(context.Context, time.Duration) ([]*Application, error)
+	mockExportApplications      func(context.Context, uuid.UUID, ExportFilter) ([]*ExportRow, error)
+	mockImportApplications      func(context.Context, uuid.UUID, ImportInput) (*ImportReport, error)
+	mockGetStats                func(context.Context, uuid.UUID, int) (*Stats, error)
+	mockGetBoard                func(context.Context, uuid.UUID) (*Board, error)
+	mockMoveApplication         func(context.Context, uuid.UUID, MoveInput) error
 }
 
 func (m *mockApplicationService) CreateApplication(ctx context.Context, userID uuid.UUID, input CreateApplicationInput) (*Application, error) {
@@ -247,9 +461,9 @@ func (m *mockApplicationService) GetUserApplications(ctx context.Context, userID
 	return nil, nil
 }
 
-func (m *mockApplicationService) GetJobApplications(ctx context.Context, jobID uuid.UUID) ([]*Application, error) {
+func (m *mockApplicationService) GetJobApplications(ctx context.Context, userID uuid.UUID, role auth.Role, jobID uuid.UUID) ([]*Application, error) {
 	if m.mockGetJobApplications != nil {
-		return m.mockGetJobApplications(ctx, jobID)
+		return m.mockGetJobApplications(ctx, userID, role, jobID)
 	}
 	return nil, nil
 }
@@ -274,3 +488,149 @@ func (m *mockApplicationService) Delete(ctx context.Context, id uuid.UUID) error
 	}
 	return nil
 }
+
+func (m *mockApplicationService) MarkGhostedApplications(ctx context.Context, inactiveFor time.Duration) ([]*Application, error) {
+	if m.mockMarkGhosted != nil {
+		return m.mockMarkGhosted(ctx, inactiveFor)
+	}
+	return nil, nil
+}
+
+func (m *mockApplicationService) ExportApplications(ctx context.Context, userID uuid.UUID, filter ExportFilter) ([]*ExportRow, error) {
+	if m.mockExportApplications != nil {
+		return m.mockExportApplications(ctx, userID, filter)
+	}
+	return nil, nil
+}
+
+func (m *mockApplicationService) ImportApplications(ctx context.Context, userID uuid.UUID, input ImportInput) (*ImportReport, error) {
+	if m.mockImportApplications != nil {
+		return m.mockImportApplications(ctx, userID, input)
+	}
+	return nil, nil
+}
+
+func (m *mockApplicationService) GetStats(ctx context.Context, userID uuid.UUID, weeks int) (*Stats, error) {
+	if m.mockGetStats != nil {
+		return m.mockGetStats(ctx, userID, weeks)
+	}
+	return nil, nil
+}
+
+func (m *mockApplicationService) GetBoard(ctx context.Context, userID uuid.UUID) (*Board, error) {
+	if m.mockGetBoard != nil {
+		return m.mockGetBoard(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockApplicationService) MoveApplication(ctx context.Context, id uuid.UUID, input MoveInput) error {
+	if m.mockMoveApplication != nil {
+		return m.mockMoveApplication(ctx, id, input)
+	}
+	return nil
+}
+
+func TestGetStatsHandler(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	mockService := &mockApplicationService{
+		mockGetStats: func(ctx context.Context, uID uuid.UUID, weeks int) (*Stats, error) {
+			assert.Equal(t, userID, uID)
+			assert.Equal(t, 8, weeks)
+			return &Stats{Total: 3, ByStatus: map[string]int{"applied": 3}}, nil
+		},
+	}
+	handler := NewGinHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/applications/stats?weeks=8", nil)
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.GetStatsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response Stats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.Total)
+	assert.Equal(t, 3, response.ByStatus["applied"])
+}
+
+func TestGetStatsHandler_InvalidWeeks(t *testing.T) {
+	// Setup
+	handler := NewGinHandler(&mockApplicationService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/applications/stats?weeks=abc", nil)
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.GetStatsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMoveApplicationHandler(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	var received MoveInput
+
+	mockService := &mockApplicationService{
+		mockGetApplicationByID: func(ctx context.Context, id uuid.UUID) (*Application, error) {
+			return &Application{ID: id, UserID: userID, Status: StatusApplied}, nil
+		},
+		mockMoveApplication: func(ctx context.Context, id uuid.UUID, input MoveInput) error {
+			assert.Equal(t, appID, id)
+			received = input
+			return nil
+		},
+	}
+	handler := NewGinHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PATCH", "/applications/"+appID.String()+"/move", strings.NewReader(`{"status":"interviewing","position":0}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.MoveApplicationHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, StatusInterviewing, received.Status)
+	require.NotNil(t, received.Position)
+	assert.Equal(t, 0, *received.Position)
+}
+
+func TestMoveApplicationHandler_OtherUser(t *testing.T) {
+	// Setup
+	mockService := &mockApplicationService{
+		mockGetApplicationByID: func(ctx context.Context, id uuid.UUID) (*Application, error) {
+			return &Application{ID: id, UserID: uuid.New()}, nil
+		},
+	}
+	handler := NewGinHandler(mockService)
+
+	appID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PATCH", "/applications/"+appID.String()+"/move", strings.NewReader(`{"position":1}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.MoveApplicationHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,247 @@
+package application
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fields an import column can be mapped to
+const (
+	ImportFieldTitle       = "title"
+	ImportFieldCompany     = "company"
+	ImportFieldLink        = "link"
+	ImportFieldLocation    = "location"
+	ImportFieldSalaryRange = "salary_range"
+	ImportFieldStatus      = "status"
+	ImportFieldAppliedAt   = "applied_at"
+	ImportFieldNotes       = "notes"
+)
+
+// MaxImportRows bounds the number of data rows of a single import
+const MaxImportRows = 1000
+
+var (
+	ErrEmptyImport      = errors.New("import file has no rows")
+	ErrTooManyRows      = fmt.Errorf("import file has more than %d rows", MaxImportRows)
+	ErrInvalidMapping   = errors.New("invalid column mapping")
+	ErrMalformedCSV     = errors.New("malformed csv")
+	ErrMissingImportCol = errors.New("required column is not mapped")
+)
+
+// importFieldAliases are the header names recognized when no explicit
+// mapping is given, so that files produced by the export import back
+var importFieldAliases = map[string][]string{
+	ImportFieldTitle:       {"title", "job_title", "position", "role"},
+	ImportFieldCompany:     {"company", "job_company"},
+	ImportFieldLink:        {"link", "job_link", "url"},
+	ImportFieldLocation:    {"location", "job_location"},
+	ImportFieldSalaryRange: {"salary_range", "job_salary_range", "salary"},
+	ImportFieldStatus:      {"status"},
+	ImportFieldAppliedAt:   {"applied_at", "applied", "date"},
+	ImportFieldNotes:       {"notes"},
+}
+
+var requiredImportFields = []string{ImportFieldTitle, ImportFieldCompany, ImportFieldLink}
+
+// ImportInput is a CSV file of applications to import
+type ImportInput struct {
+	File io.Reader
+	// Mapping maps import fields to CSV header names. Fields left out are
+	// matched against well known header names
+	Mapping    map[string]string
+	Delimiter  rune
+	DryRun     bool
+	PipelineID *uuid.UUID
+}
+
+type ImportRowStatus string
+
+const (
+	RowCreated ImportRowStatus = "created"
+	RowValid   ImportRowStatus = "valid" // dry run only: the row would be created
+	RowSkipped ImportRowStatus = "skipped"
+	RowFailed  ImportRowStatus = "failed"
+)
+
+type ImportRowResult struct {
+	// Line is the line of the row in the file, the header being line 1
+	Line          int             `json:"line"`
+	Status        ImportRowStatus `json:"status"`
+	ApplicationID *uuid.UUID      `json:"application_id,omitempty"`
+	JobID         *uuid.UUID      `json:"job_id,omitempty"`
+	JobCreated    bool            `json:"job_created"`
+	Error         string          `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Valid   int               `json:"valid"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+func (r *ImportReport) add(result ImportRowResult) {
+	switch result.Status {
+	case RowCreated:
+		r.Created++
+	case RowValid:
+		r.Valid++
+	case RowSkipped:
+		r.Skipped++
+	case RowFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+// importRecord is a data row read with the column mapping applied
+type importRecord struct {
+	line   int
+	values map[string]string
+}
+
+func (r importRecord) get(field string) string {
+	return strings.TrimSpace(r.values[field])
+}
+
+// readImport reads every data row of the file, resolving the column of each
+// mapped field from the header
+func readImport(input ImportInput) ([]importRecord, error) {
+	reader := csv.NewReader(input.File)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if input.Delimiter != 0 {
+		reader.Comma = input.Delimiter
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmptyImport
+		}
+		return nil, fmt.Errorf("%w: %v", ErrMalformedCSV, err)
+	}
+
+	columns, err := mapColumns(header, input.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []importRecord{}
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedCSV, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isBlank(fields) {
+			continue
+		}
+
+		if len(records) == MaxImportRows {
+			return nil, ErrTooManyRows
+		}
+
+		record := importRecord{line: line, values: make(map[string]string, len(columns))}
+		for field, index := range columns {
+			if index < len(fields) {
+				record.values[field] = fields[index]
+			}
+		}
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	return records, nil
+}
+
+// mapColumns returns the column index of every import field found in the
+// header
+func mapColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// spreadsheet apps often save CSV files with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int)
+	for field, column := range mapping {
+		if _, known := importFieldAliases[field]; !known {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidMapping, field)
+		}
+
+		index, found := positions[strings.ToLower(strings.TrimSpace(column))]
+		if !found {
+			return nil, fmt.Errorf("%w: column %q of field %q is not in the header", ErrInvalidMapping, column, field)
+		}
+		columns[field] = index
+	}
+
+	for field, aliases := range importFieldAliases {
+		if _, mapped := columns[field]; mapped {
+			continue
+		}
+		for _, alias := range aliases {
+			if index, found := positions[alias]; found {
+				columns[field] = index
+				break
+			}
+		}
+	}
+
+	for _, field := range requiredImportFields {
+		if _, mapped := columns[field]; !mapped {
+			return nil, fmt.Errorf("%w: %s", ErrMissingImportCol, field)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseImportDate accepts RFC 3339 times and plain dates
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, time.DateTime, "2006-01-02 15:04", time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid applied_at %q, expected YYYY-MM-DD or RFC 3339", value)
+}
+
+// isWebURL reports whether link is an absolute http or https URL with a host
+func isWebURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return false
+	}
+	return u.Host != ""
+}
+
+func isBlank(fields []string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,283 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/job"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+const importCSV = "\ufeffPosition;Empresa;URL;Estado;Applied;Notes\n" +
+	"Go Developer;Acme;https://jobs.example.com/1;interviewing;2026-01-15;referral\n" +
+	"Backend Engineer;Globex;https://jobs.example.com/2;;2026-02-01T10:00:00Z;\n" +
+	";NoTitle;https://jobs.example.com/3;;;\n" +
+	"Go Developer;Acme;https://jobs.example.com/1;applied;;\n" +
+	"SRE;Initech;https://jobs.example.com/4;hired;;\n" +
+	"\n" +
+	"Platform;Umbrella;https://jobs.example.com/5;;2999-01-01;\n"
+
+var importMapping = map[string]string{
+	ImportFieldTitle:     "Position",
+	ImportFieldCompany:   "Empresa",
+	ImportFieldLink:      "URL",
+	ImportFieldStatus:    "Estado",
+	ImportFieldAppliedAt: "Applied",
+}
+
+func newImportService() (Service, *mockRepository, job.Service) {
+	repo := NewMockRepository().(*mockRepository)
+	jobs := job.NewService(job.NewMockRepository(), nil)
+	pipelines := pipeline.NewService(pipeline.NewMockRepository())
+	return NewService(repo, jobs, nil, pipelines, nil), repo, jobs
+}
+
+func TestImportApplications_CreatesJobsAndApplications(t *testing.T) {
+	service, repo, jobs := newImportService()
+	userID := uuid.New()
+
+	// The second job is already known and must be reused
+	known, err := jobs.CreateJob(context.Background(), job.CreateJobInput{Title: "Backend Engineer", Company: "Globex", Source: "manual", Link: "https://jobs.example.com/2"})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	report, err := service.ImportApplications(context.Background(), userID, ImportInput{
+		File:      strings.NewReader(importCSV),
+		Mapping:   importMapping,
+		Delimiter: ';',
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Total != 6 || report.Created != 2 || report.Skipped != 1 || report.Failed != 3 {
+		t.Fatalf("unexpected report totals: %+v", report)
+	}
+
+	expected := []struct {
+		line   int
+		status ImportRowStatus
+		err    string
+	}{
+		{2, RowCreated, ""},
+		{3, RowCreated, ""},
+		{4, RowFailed, "title is required"},
+		{5, RowSkipped, "duplicate of line 2"},
+		{6, RowFailed, `status "hired" is not a stage`},
+		{8, RowFailed, "future"},
+	}
+	for i, want := range expected {
+		got := report.Rows[i]
+		if got.Line != want.line || got.Status != want.status || !strings.Contains(got.Error, want.err) {
+			t.Fatalf("row %d: expected line %d %s %q, got %+v", i, want.line, want.status, want.err, got)
+		}
+	}
+
+	if !report.Rows[0].JobCreated || report.Rows[1].JobCreated || *report.Rows[1].JobID != known.ID {
+		t.Fatalf("expected only the unknown job to be created, got %+v and %+v", report.Rows[0], report.Rows[1])
+	}
+
+	first, _ := repo.GetByID(context.Background(), *report.Rows[0].ApplicationID)
+	if first.Status != StatusInterviewing || !first.AppliedAt.Equal(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected historical status and date, got %s at %s", first.Status, first.AppliedAt)
+	}
+
+	second, _ := repo.GetByID(context.Background(), *report.Rows[1].ApplicationID)
+	if second.Status != StatusApplied {
+		t.Fatalf("expected empty status to use the initial stage, got %s", second.Status)
+	}
+
+	// Importing the same file again skips applications already tracked
+	report, _ = service.ImportApplications(context.Background(), userID, ImportInput{
+		File:      strings.NewReader(importCSV),
+		Mapping:   importMapping,
+		Delimiter: ';',
+	})
+	if report.Created != 0 || report.Rows[0].Status != RowSkipped || report.Rows[0].Error != ErrAlreadyApplied.Error() {
+		t.Fatalf("expected re-import to skip existing applications, got %+v", report.Rows[0])
+	}
+}
+
+func TestImportApplications_NotesGoToTimeline(t *testing.T) {
+	service, repo, _ := newImportService()
+	userID := uuid.New()
+
+	mapping := map[string]string{ImportFieldNotes: "Notes"}
+	for field, column := range importMapping {
+		mapping[field] = column
+	}
+
+	report, err := service.ImportApplications(context.Background(), userID, ImportInput{
+		File:      strings.NewReader(importCSV),
+		Mapping:   mapping,
+		Delimiter: ';',
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id := *report.Rows[0].ApplicationID
+	app, _ := repo.GetByID(context.Background(), id)
+	if app.Notes != "" {
+		t.Fatalf("expected the legacy notes column to stay empty, got %q", app.Notes)
+	}
+	if got := repo.notes[id]; len(got) != 1 || got[0] != "referral" {
+		t.Fatalf("expected the notes column as the first timeline note, got %v", got)
+	}
+	if got := repo.notes[*report.Rows[1].ApplicationID]; len(got) != 0 {
+		t.Fatalf("expected no note for an empty notes column, got %v", got)
+	}
+}
+
+func TestImportApplications_SetsResponseAndGhostedDates(t *testing.T) {
+	service, repo, _ := newImportService()
+	userID := uuid.New()
+
+	csv := "title,company,link,status,applied_at\n" +
+		"Go Developer,Acme,https://jobs.example.com/1,applied,2026-01-10\n" +
+		"SRE,Globex,https://jobs.example.com/2,interviewing,2026-01-15\n" +
+		"Platform,Initech,https://jobs.example.com/3,ghosted,2026-01-20\n" +
+		"Backend,Umbrella,https://jobs.example.com/4,withdrawn,2026-01-25\n"
+
+	report, err := service.ImportApplications(context.Background(), userID, ImportInput{File: strings.NewReader(csv)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Created != 4 {
+		t.Fatalf("expected 4 created applications, got %+v", report)
+	}
+
+	apps := make([]*Application, len(report.Rows))
+	for i, row := range report.Rows {
+		apps[i], _ = repo.GetByID(context.Background(), *row.ApplicationID)
+	}
+
+	if apps[0].FirstResponseAt != nil || apps[0].GhostedAt != nil {
+		t.Fatalf("expected no dates on an applied application, got %+v", apps[0])
+	}
+	if apps[1].FirstResponseAt == nil || !apps[1].FirstResponseAt.Equal(apps[1].AppliedAt) || apps[1].GhostedAt != nil {
+		t.Fatalf("expected an interviewing application to have responded, got %+v", apps[1])
+	}
+	if apps[2].GhostedAt == nil || !apps[2].GhostedAt.Equal(apps[2].AppliedAt) || apps[2].FirstResponseAt != nil {
+		t.Fatalf("expected a ghosted application to be ghosted since it was sent, got %+v", apps[2])
+	}
+	if apps[3].FirstResponseAt != nil || apps[3].GhostedAt != nil {
+		t.Fatalf("expected withdrawing not to count as a response, got %+v", apps[3])
+	}
+}
+
+func TestImportApplications_DryRun(t *testing.T) {
+	service, repo, jobs := newImportService()
+	userID := uuid.New()
+
+	report, err := service.ImportApplications(context.Background(), userID, ImportInput{
+		File:      strings.NewReader(importCSV),
+		Mapping:   importMapping,
+		Delimiter: ';',
+		DryRun:    true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.DryRun || report.Valid != 2 || report.Created != 0 {
+		t.Fatalf("expected 2 valid rows and nothing created, got %+v", report)
+	}
+	if !report.Rows[0].JobCreated {
+		t.Fatal("expected dry run to report the job that would be created")
+	}
+
+	apps, _ := repo.GetUserApplications(context.Background(), userID)
+	if len(apps) != 0 {
+		t.Fatalf("expected no applications after a dry run, got %d", len(apps))
+	}
+	if _, err := jobs.GetJobByLink(context.Background(), "https://jobs.example.com/1"); !errors.Is(err, job.ErrJobNotFound) {
+		t.Fatalf("expected no job after a dry run, got %v", err)
+	}
+}
+
+func TestImportApplications_RejectsInvalidLinks(t *testing.T) {
+	service, _, _ := newImportService()
+
+	links := []string{"httpfoo", "http:/jobs", "https://", "ftp://jobs.example.com/1", "javascript:alert(1)"}
+	csv := "title,company,link\n"
+	for _, link := range links {
+		csv += "Go Developer,Acme," + link + "\n"
+	}
+
+	report, err := service.ImportApplications(context.Background(), uuid.New(), ImportInput{
+		File:   strings.NewReader(csv),
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Failed != len(links) {
+		t.Fatalf("expected every row to fail, got %+v", report.Rows)
+	}
+	for _, row := range report.Rows {
+		if row.Error != "link must be a valid URL" {
+			t.Fatalf("line %d: unexpected error %q", row.Line, row.Error)
+		}
+	}
+}
+
+func TestImportApplications_HeaderErrors(t *testing.T) {
+	service, _, _ := newImportService()
+
+	tests := []struct {
+		name    string
+		csv     string
+		mapping map[string]string
+		err     error
+	}{
+		{"empty file", "", nil, ErrEmptyImport},
+		{"header only", "title,company,link\n", nil, ErrEmptyImport},
+		{"missing link column", "title,company\nGo,Acme\n", nil, ErrMissingImportCol},
+		{"unknown field", "title,company,link\nGo,Acme,https://x\n", map[string]string{"salary": "title"}, ErrInvalidMapping},
+		{"column not in header", "title,company,link\nGo,Acme,https://x\n", map[string]string{"title": "Position"}, ErrInvalidMapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ImportApplications(context.Background(), uuid.New(), ImportInput{
+				File:    strings.NewReader(tt.csv),
+				Mapping: tt.mapping,
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestImportApplications_ExportedFileRoundTrips(t *testing.T) {
+	service, _, _ := newImportService()
+
+	var buf strings.Builder
+	err := WriteExport(&buf, FormatCSV, []*ExportRow{{
+		ID:         uuid.New(),
+		Status:     StatusOffer,
+		AppliedAt:  time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC),
+		JobTitle:   "Go Developer",
+		JobCompany: "Acme",
+		JobLink:    "https://jobs.example.com/1",
+	}})
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	report, err := service.ImportApplications(context.Background(), uuid.New(), ImportInput{File: strings.NewReader(buf.String())})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Created != 1 {
+		t.Fatalf("expected exported row to import without a mapping, got %+v", report.Rows)
+	}
+}
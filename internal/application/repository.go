@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,12 +16,33 @@ var (
 )
 
 type Repository interface {
+	// Create stores a new application. A non-empty Notes becomes the first
+	// note of its timeline rather than being written to the legacy column.
 	Create(ctx context.Context, app *Application) (*Application, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Application, error)
 	GetUserApplications(ctx context.Context, userID uuid.UUID) ([]*Application, error)
 	GetUserJobApplication(ctx context.Context, userID, jobID uuid.UUID) (*Application, error)
 	GetJobApplications(ctx context.Context, jobID uuid.UUID) ([]*Application, error)
 	Update(ctx context.Context, app *Application) error
-	UpdateStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus, stageID uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// MarkGhosted ghosts applied and interviewing applications without
+	// activity since inactiveSince. Interviews still to come and interviews,
+	// notes, offers or contact interactions written since count as activity.
+	// Each change carries the status the application left
+	MarkGhosted(ctx context.Context, inactiveSince time.Time) ([]*StatusChange, error)
+	// GetExportRows returns the user's applications joined with job data,
+	// newest first
+	GetExportRows(ctx context.Context, userID uuid.UUID, filter ExportFilter) ([]*ExportRow, error)
+	// GetStats returns the counts of the user's funnel, weeks since the
+	// given time and the top companies. Rates are left to the caller
+	GetStats(ctx context.Context, userID uuid.UUID, since time.Time, topCompanies int) (*Stats, error)
+	// GetBoardCards returns the user's applications with job summaries,
+	// grouped by status and in board order within each status
+	GetBoardCards(ctx context.Context, userID uuid.UUID) ([]*BoardCard, error)
+	// MoveCard places the application at position in the column of status,
+	// renumbering the rest of the column. When stageID is set the
+	// application moves to status and that stage first, both changes are
+	// saved together or not at all
+	MoveCard(ctx context.Context, userID, id uuid.UUID, status ApplicationStatus, stageID *uuid.UUID, position int) error
 }
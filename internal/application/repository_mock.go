@@ -2,21 +2,90 @@ package application
 
 import (
 	"context"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/job"
+	"github.com/luis-octavius/cintia/internal/pipeline"
 )
 
 type mockRepository struct {
 	mu           sync.RWMutex
 	applications map[uuid.UUID]*Application
+	// jobs stands in for the jobs table joined by export queries
+	jobs map[uuid.UUID]*job.Job
+	// positions holds the manual board order, kept per status like the
+	// application_board_positions table
+	positions map[uuid.UUID]boardPosition
+	// notes stands in for the notes timeline seeded on creation
+	notes map[uuid.UUID][]string
+	// interviews stands in for the pending interview rounds the board reads
+	interviews map[uuid.UUID][]time.Time
+	// stages holds the stages of custom pipelines, the system pipeline is
+	// always known
+	stages map[uuid.UUID]pipeline.Stage
+}
+
+type boardPosition struct {
+	status   ApplicationStatus
+	position int
 }
 
 func NewMockRepository() Repository {
 	return &mockRepository{
 		applications: make(map[uuid.UUID]*Application),
+		jobs:         make(map[uuid.UUID]*job.Job),
+		positions:    make(map[uuid.UUID]boardPosition),
+		notes:        make(map[uuid.UUID][]string),
+		interviews:   make(map[uuid.UUID][]time.Time),
+		stages:       make(map[uuid.UUID]pipeline.Stage),
+	}
+}
+
+// addInterview schedules a pending interview round of an application
+func (m *mockRepository) addInterview(applicationID uuid.UUID, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.interviews[applicationID] = append(m.interviews[applicationID], at)
+}
+
+// addPipeline registers the stages of a custom pipeline
+func (m *mockRepository) addPipeline(p *pipeline.Pipeline) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, stage := range p.Stages {
+		m.stages[stage.ID] = stage
+	}
+}
+
+// stageKind stands in for the join on pipeline_stages. Applications
+// created without a stage fall back to the system stage of their status
+func (m *mockRepository) stageKind(app *Application) pipeline.StageKind {
+	if stage, exists := m.stages[app.StageID]; exists {
+		return stage.Kind
 	}
+
+	def := pipeline.DefaultPipeline()
+	if stage, ok := def.StageByID(app.StageID); ok {
+		return stage.Kind
+	}
+	if stage, ok := def.StageByKey(string(app.Status)); ok {
+		return stage.Kind
+	}
+	return pipeline.KindActive
+}
+
+// addJob registers a job so that exports can join it
+func (m *mockRepository) addJob(j *job.Job) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.jobs[j.ID] = j
 }
 
 func (m *mockRepository) Create(ctx context.Context, app *Application) (*Application, error) {
@@ -37,6 +106,11 @@ func (m *mockRepository) Create(ctx context.Context, app *Application) (*Applica
 	}
 	app.UpdatedAt = now
 
+	if app.Notes != "" {
+		m.notes[app.ID] = append(m.notes[app.ID], app.Notes)
+		app.Notes = ""
+	}
+
 	m.applications[app.ID] = app
 	return app, nil
 }
@@ -105,17 +179,8 @@ func (m *mockRepository) Update(ctx context.Context, app *Application) error {
 		return ErrNotFound
 	}
 
-	if app.Status != application.Status {
-		if !app.Status.IsValid() {
-			return ErrInvalidStatus
-		}
-		if !application.CanTransitionTo(app.Status) {
-			return ErrInvalidTransition
-		}
-	}
-
-	application.Status = app.Status
-	application.InterviewDate = app.InterviewDate
+	// Like the postgres repository the status is left alone, it only
+	// changes through UpdateStatus once the pipeline allowed the move
 	application.OfferDate = app.OfferDate
 	application.SalaryOffer = app.SalaryOffer
 	application.ReminderSent = app.ReminderSent
@@ -126,7 +191,7 @@ func (m *mockRepository) Update(ctx context.Context, app *Application) error {
 	return nil
 }
 
-func (m *mockRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus) error {
+func (m *mockRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus, stageID uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -135,17 +200,30 @@ func (m *mockRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 		return ErrNotFound
 	}
 
-	if ok := status.IsValid(); !ok {
-		return ErrInvalidStatus
-	}
+	m.setStatus(application, status, stageID)
+	return nil
+}
+
+// setStatus moves the application to status and stageID. The caller holds
+// the lock
+func (m *mockRepository) setStatus(application *Application, status ApplicationStatus, stageID uuid.UUID) {
+	application.Status = status
+	application.StageID = stageID
+	application.UpdatedAt = time.Now()
 
-	if ok := application.CanTransitionTo(status); !ok {
-		return ErrInvalidTransition
+	if status == StatusGhosted {
+		if application.GhostedAt == nil {
+			now := time.Now()
+			application.GhostedAt = &now
+		}
+	} else {
+		application.GhostedAt = nil
 	}
 
-	m.applications[id].Status = status
-	m.applications[id].UpdatedAt = time.Now()
-	return nil
+	if application.FirstResponseAt == nil && isResponse(status) {
+		now := time.Now()
+		application.FirstResponseAt = &now
+	}
 }
 
 func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -160,3 +238,271 @@ func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	delete(m.applications, id)
 	return nil
 }
+
+func (m *mockRepository) MarkGhosted(ctx context.Context, inactiveSince time.Time) ([]*StatusChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := pipeline.DefaultPipeline()
+	ghosted, _ := p.StageByKey(string(StatusGhosted))
+
+	changes := []*StatusChange{}
+	for _, app := range m.applications {
+		if app.Status != StatusApplied && app.Status != StatusInterviewing {
+			continue
+		}
+		if !app.UpdatedAt.Before(inactiveSince) {
+			continue
+		}
+
+		from := app.Status
+		now := time.Now()
+		app.Status = StatusGhosted
+		app.StageID = ghosted.ID
+		app.GhostedAt = &now
+		app.UpdatedAt = now
+		changes = append(changes, &StatusChange{Application: app, From: from, To: StatusGhosted})
+	}
+
+	return changes, nil
+}
+
+func (m *mockRepository) GetExportRows(ctx context.Context, userID uuid.UUID, filter ExportFilter) ([]*ExportRow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rows := []*ExportRow{}
+	for _, app := range m.applications {
+		if app.UserID != userID || !filter.matches(app) {
+			continue
+		}
+
+		j, exists := m.jobs[app.JobID]
+		if !exists {
+			continue
+		}
+
+		rows = append(rows, &ExportRow{
+			ID:             app.ID,
+			Status:         app.Status,
+			AppliedAt:      app.AppliedAt,
+			UpdatedAt:      app.UpdatedAt,
+			InterviewDate:  app.InterviewDate,
+			OfferDate:      app.OfferDate,
+			FollowUpDate:   app.FollowUpDate,
+			Notes:          app.Notes,
+			SalaryOffer:    app.SalaryOffer,
+			JobTitle:       j.Title,
+			JobCompany:     j.Company,
+			JobLocation:    j.Location,
+			JobLink:        j.Link,
+			JobSalaryRange: j.SalaryRange,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].AppliedAt.After(rows[j].AppliedAt)
+	})
+	return rows, nil
+}
+
+func (m *mockRepository) GetStats(ctx context.Context, userID uuid.UUID, since time.Time, topCompanies int) (*Stats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := &Stats{ByStatus: map[string]int{}}
+	weeks := map[time.Time]int{}
+	sources := map[string]*Breakdown{}
+	companies := map[string]*Breakdown{}
+	responseDays := []float64{}
+	var funnel Breakdown
+
+	for _, app := range m.applications {
+		if app.UserID != userID {
+			continue
+		}
+
+		stats.Total++
+		stats.ByStatus[string(app.Status)]++
+
+		kind := m.stageKind(app)
+		interview := kind.ReachedInterview() || len(m.interviews[app.ID]) > 0
+		offer := kind.ReachedOffer() || app.OfferDate != nil
+		accepted := kind == pipeline.KindAccepted
+		count := func(b *Breakdown) {
+			b.Total++
+			if interview {
+				b.Interviewing++
+			}
+			if offer {
+				b.Offer++
+			}
+			if accepted {
+				b.Accepted++
+			}
+		}
+
+		count(&funnel)
+
+		if app.FirstResponseAt != nil {
+			stats.Responded++
+			responseDays = append(responseDays, app.FirstResponseAt.Sub(app.AppliedAt).Hours()/24)
+		}
+
+		if !app.AppliedAt.Before(since) {
+			weeks[weekStart(app.AppliedAt)]++
+		}
+
+		if j, exists := m.jobs[app.JobID]; exists {
+			if sources[j.Source] == nil {
+				sources[j.Source] = &Breakdown{Name: j.Source}
+			}
+			count(sources[j.Source])
+			if companies[j.Company] == nil {
+				companies[j.Company] = &Breakdown{Name: j.Company}
+			}
+			count(companies[j.Company])
+		}
+	}
+
+	stats.Funnel = Funnel{Applied: funnel.Total, Interviewing: funnel.Interviewing, Offer: funnel.Offer, Accepted: funnel.Accepted}
+
+	if len(responseDays) > 0 {
+		sort.Float64s(responseDays)
+		mid := len(responseDays) / 2
+		median := responseDays[mid]
+		if len(responseDays)%2 == 0 {
+			median = (responseDays[mid-1] + responseDays[mid]) / 2
+		}
+		median = math.Round(median*10) / 10
+		stats.MedianDaysToResponse = &median
+	}
+
+	stats.PerWeek = []WeekCount{}
+	for week, count := range weeks {
+		stats.PerWeek = append(stats.PerWeek, WeekCount{WeekStart: week, Count: count})
+	}
+	sort.Slice(stats.PerWeek, func(i, j int) bool {
+		return stats.PerWeek[i].WeekStart.Before(stats.PerWeek[j].WeekStart)
+	})
+
+	stats.BySource = sortedBreakdowns(sources, 0)
+	stats.ByCompany = sortedBreakdowns(companies, topCompanies)
+	return stats, nil
+}
+
+func (m *mockRepository) GetBoardCards(ctx context.Context, userID uuid.UUID) ([]*BoardCard, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	cards := []*BoardCard{}
+	for _, app := range m.applications {
+		j, exists := m.jobs[app.JobID]
+		if app.UserID != userID || !exists {
+			continue
+		}
+
+		var next *time.Time
+		for _, at := range m.interviews[app.ID] {
+			if at.After(now) && (next == nil || at.Before(*next)) {
+				scheduled := at
+				next = &scheduled
+			}
+		}
+
+		cards = append(cards, &BoardCard{
+			ID:              app.ID,
+			Status:          app.Status,
+			StageID:         app.StageID,
+			AppliedAt:       app.AppliedAt,
+			UpdatedAt:       app.UpdatedAt,
+			NextInterviewAt: next,
+			FollowUpDate:    app.FollowUpDate,
+			Job: BoardJob{
+				ID:       j.ID,
+				Title:    j.Title,
+				Company:  j.Company,
+				Location: j.Location,
+				Link:     j.Link,
+				Source:   j.Source,
+			},
+		})
+	}
+
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Status != cards[j].Status {
+			return cards[i].Status < cards[j].Status
+		}
+		return m.boardLess(cards[i].ID, cards[j].ID)
+	})
+	return cards, nil
+}
+
+func (m *mockRepository) MoveCard(ctx context.Context, userID, id uuid.UUID, status ApplicationStatus, stageID *uuid.UUID, position int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	app, exists := m.applications[id]
+	if !exists || app.UserID != userID {
+		return ErrNotFound
+	}
+	if stageID != nil {
+		m.setStatus(app, status, *stageID)
+	}
+	if app.Status != status {
+		return ErrNotFound
+	}
+
+	column := []uuid.UUID{}
+	for _, other := range m.applications {
+		if other.UserID == userID && other.Status == status {
+			column = append(column, other.ID)
+		}
+	}
+	sort.Slice(column, func(i, j int) bool { return m.boardLess(column[i], column[j]) })
+
+	for i, other := range placeCard(column, id, position) {
+		m.positions[other] = boardPosition{status: status, position: i}
+	}
+	return nil
+}
+
+// boardLess orders two applications of a column like the board queries:
+// cards without a position first, then by position, then newest first.
+// The caller holds the lock
+func (m *mockRepository) boardLess(a, b uuid.UUID) bool {
+	appA, appB := m.applications[a], m.applications[b]
+	posA, okA := m.positions[a]
+	posB, okB := m.positions[b]
+	okA = okA && posA.status == appA.Status
+	okB = okB && posB.status == appB.Status
+
+	if okA != okB {
+		return !okA
+	}
+	if okA && posA.position != posB.position {
+		return posA.position < posB.position
+	}
+	return appA.AppliedAt.After(appB.AppliedAt)
+}
+
+// sortedBreakdowns orders breakdowns like the stats queries: most
+// applications first, then by name
+func sortedBreakdowns(byName map[string]*Breakdown, limit int) []Breakdown {
+	breakdowns := make([]Breakdown, 0, len(byName))
+	for _, b := range byName {
+		breakdowns = append(breakdowns, *b)
+	}
+
+	sort.Slice(breakdowns, func(i, j int) bool {
+		if breakdowns[i].Total != breakdowns[j].Total {
+			return breakdowns[i].Total > breakdowns[j].Total
+		}
+		return breakdowns[i].Name < breakdowns[j].Name
+	})
+	if limit > 0 && len(breakdowns) > limit {
+		breakdowns = breakdowns[:limit]
+	}
+	return breakdowns
+}
@@ -4,27 +4,48 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/luis-octavius/cintia/internal/database"
 )
 
 type PostgresRepository struct {
+	db      *sql.DB
 	queries *database.Queries
 }
 
 func NewPostgresRepository(db *sql.DB) Repository {
 	return &PostgresRepository{
+		db:      db,
 		queries: database.New(db),
 	}
 }
 
 func (r *PostgresRepository) Create(ctx context.Context, app *Application) (*Application, error) {
-	dbApp, err := r.queries.CreateApplication(ctx, database.CreateApplicationParams{
-		UserID: app.UserID,
-		JobID:  app.JobID,
-		Notes:  toNullString(app.Notes),
+	appliedAt := app.AppliedAt
+	if appliedAt.IsZero() {
+		appliedAt = time.Now()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	dbApp, err := qtx.CreateApplication(ctx, database.CreateApplicationParams{
+		UserID:          app.UserID,
+		JobID:           app.JobID,
+		Status:          string(app.Status),
+		StageID:         app.StageID,
+		AppliedAt:       appliedAt,
+		GhostedAt:       toNullTime(app.GhostedAt),
+		FirstResponseAt: toNullTime(app.FirstResponseAt),
 	})
 	if err != nil {
 		// Check for unique constraint violation (duplicate application)
@@ -34,6 +55,20 @@ func (r *PostgresRepository) Create(ctx context.Context, app *Application) (*App
 		return nil, err
 	}
 
+	if app.Notes != "" {
+		if _, err := qtx.CreateNote(ctx, database.CreateNoteParams{
+			ApplicationID: dbApp.ID,
+			UserID:        app.UserID,
+			Body:          app.Notes,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return dbAppToApp(&dbApp), nil
 }
 
@@ -97,15 +132,9 @@ func (r *PostgresRepository) Update(ctx context.Context, app *Application) error
 		ID: app.ID,
 	}
 
-	if app.InterviewDate != nil {
-		params.InterviewDate = sql.NullTime{Time: *app.InterviewDate, Valid: true}
-	}
 	if app.OfferDate != nil {
 		params.OfferDate = sql.NullTime{Time: *app.OfferDate, Valid: true}
 	}
-	if app.Notes != "" {
-		params.Notes = toNullString(app.Notes)
-	}
 	if app.SalaryOffer != "" {
 		params.SalaryOffer = toNullString(app.SalaryOffer)
 	}
@@ -125,14 +154,11 @@ func (r *PostgresRepository) Update(ctx context.Context, app *Application) error
 	return nil
 }
 
-func (r *PostgresRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus) error {
-	if !status.IsValid() {
-		return ErrInvalidStatus
-	}
-
+func (r *PostgresRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus, stageID uuid.UUID) error {
 	_, err := r.queries.UpdateApplicationStatus(ctx, database.UpdateApplicationStatusParams{
-		ID:     id,
-		Status: string(status),
+		ID:      id,
+		Status:  string(status),
+		StageID: stageID,
 	})
 	return err
 }
@@ -141,6 +167,246 @@ func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.queries.DeleteApplication(ctx, id)
 }
 
+func (r *PostgresRepository) MarkGhosted(ctx context.Context, inactiveSince time.Time) ([]*StatusChange, error) {
+	rows, err := r.queries.MarkGhostedApplications(ctx, inactiveSince)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*StatusChange, len(rows))
+	for i, row := range rows {
+		app := dbAppToApp(&database.Application{
+			ID:              row.ID,
+			UserID:          row.UserID,
+			JobID:           row.JobID,
+			Status:          row.Status,
+			AppliedAt:       row.AppliedAt,
+			UpdatedAt:       row.UpdatedAt,
+			InterviewDate:   row.InterviewDate,
+			OfferDate:       row.OfferDate,
+			Notes:           row.Notes,
+			SalaryOffer:     row.SalaryOffer,
+			ReminderSent:    row.ReminderSent,
+			FollowUpDate:    row.FollowUpDate,
+			StageID:         row.StageID,
+			GhostedAt:       row.GhostedAt,
+			FirstResponseAt: row.FirstResponseAt,
+		})
+		changes[i] = &StatusChange{
+			Application: app,
+			From:        ApplicationStatus(row.PreviousStatus),
+			To:          app.Status,
+		}
+	}
+
+	return changes, nil
+}
+
+func (r *PostgresRepository) GetExportRows(ctx context.Context, userID uuid.UUID, filter ExportFilter) ([]*ExportRow, error) {
+	params := database.ListApplicationsForExportParams{
+		UserID:      userID,
+		AppliedFrom: toNullTime(filter.AppliedFrom),
+		AppliedTo:   toNullTime(filter.AppliedTo),
+	}
+	if len(filter.Statuses) > 0 {
+		params.Statuses = make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			params.Statuses[i] = string(status)
+		}
+	}
+
+	dbRows, err := r.queries.ListApplicationsForExport(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*ExportRow, len(dbRows))
+	for i, dbRow := range dbRows {
+		rows[i] = &ExportRow{
+			ID:             dbRow.ID,
+			Status:         ApplicationStatus(dbRow.Status),
+			AppliedAt:      dbRow.AppliedAt,
+			UpdatedAt:      dbRow.UpdatedAt,
+			InterviewDate:  fromNullTime(dbRow.InterviewDate),
+			OfferDate:      fromNullTime(dbRow.OfferDate),
+			FollowUpDate:   fromNullTime(dbRow.FollowUpDate),
+			Notes:          fromNullString(dbRow.Notes),
+			SalaryOffer:    fromNullString(dbRow.SalaryOffer),
+			JobTitle:       dbRow.JobTitle,
+			JobCompany:     dbRow.JobCompany,
+			JobLocation:    dbRow.JobLocation,
+			JobLink:        dbRow.JobLink,
+			JobSalaryRange: fromNullString(dbRow.JobSalaryRange),
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *PostgresRepository) GetStats(ctx context.Context, userID uuid.UUID, since time.Time, topCompanies int) (*Stats, error) {
+	stats := &Stats{ByStatus: map[string]int{}}
+
+	statusCounts, err := r.queries.GetApplicationStatusCounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range statusCounts {
+		stats.ByStatus[sc.Status] = int(sc.Count)
+	}
+
+	funnel, err := r.queries.GetApplicationFunnel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stats.Total = int(funnel.Total)
+	stats.Funnel = Funnel{
+		Applied:      int(funnel.Total),
+		Interviewing: int(funnel.Interviewing),
+		Offer:        int(funnel.Offer),
+		Accepted:     int(funnel.Accepted),
+	}
+	stats.Responded = int(funnel.Responded)
+	if funnel.MedianDaysToResponse.Valid {
+		median := math.Round(funnel.MedianDaysToResponse.Float64*10) / 10
+		stats.MedianDaysToResponse = &median
+	}
+
+	weeks, err := r.queries.GetApplicationsPerWeek(ctx, database.GetApplicationsPerWeekParams{
+		UserID:    userID,
+		AppliedAt: since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats.PerWeek = make([]WeekCount, len(weeks))
+	for i, week := range weeks {
+		stats.PerWeek[i] = WeekCount{WeekStart: week.Week, Count: int(week.Count)}
+	}
+
+	sources, err := r.queries.GetApplicationStatsBySource(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stats.BySource = make([]Breakdown, len(sources))
+	for i, row := range sources {
+		stats.BySource[i] = Breakdown{
+			Name:         row.Source,
+			Total:        int(row.Total),
+			Interviewing: int(row.Interviewing),
+			Offer:        int(row.Offer),
+			Accepted:     int(row.Accepted),
+		}
+	}
+
+	companies, err := r.queries.GetApplicationStatsByCompany(ctx, database.GetApplicationStatsByCompanyParams{
+		UserID: userID,
+		Limit:  int32(topCompanies),
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats.ByCompany = make([]Breakdown, len(companies))
+	for i, row := range companies {
+		stats.ByCompany[i] = Breakdown{
+			Name:         row.Company,
+			Total:        int(row.Total),
+			Interviewing: int(row.Interviewing),
+			Offer:        int(row.Offer),
+			Accepted:     int(row.Accepted),
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *PostgresRepository) GetBoardCards(ctx context.Context, userID uuid.UUID) ([]*BoardCard, error) {
+	rows, err := r.queries.ListBoardCards(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*BoardCard, len(rows))
+	for i, row := range rows {
+		cards[i] = &BoardCard{
+			ID:              row.ID,
+			Status:          ApplicationStatus(row.Status),
+			StageID:         row.StageID,
+			AppliedAt:       row.AppliedAt,
+			UpdatedAt:       row.UpdatedAt,
+			NextInterviewAt: fromNullTime(row.NextInterviewAt),
+			FollowUpDate:    fromNullTime(row.FollowUpDate),
+			Job: BoardJob{
+				ID:       row.JobID,
+				Title:    row.JobTitle,
+				Company:  row.JobCompany,
+				Location: row.JobLocation,
+				Link:     row.JobLink,
+				Source:   row.JobSource,
+			},
+		}
+	}
+	return cards, nil
+}
+
+// MoveCard locks the destination column while renumbering it, so moves
+// racing into the same column do not interleave their positions
+func (r *PostgresRepository) MoveCard(ctx context.Context, userID, id uuid.UUID, status ApplicationStatus, stageID *uuid.UUID, position int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if stageID != nil {
+		if _, err := qtx.UpdateApplicationStatus(ctx, database.UpdateApplicationStatusParams{
+			ID:      id,
+			Status:  string(status),
+			StageID: *stageID,
+		}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+	}
+
+	column, err := qtx.LockBoardColumn(ctx, database.LockBoardColumnParams{
+		UserID: userID,
+		Status: string(status),
+	})
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, other := range column {
+		if other == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrNotFound
+	}
+
+	ordered := placeCard(column, id, position)
+	ids := make([]string, len(ordered))
+	for i, other := range ordered {
+		ids[i] = other.String()
+	}
+
+	if err := qtx.SetBoardPositions(ctx, database.SetBoardPositionsParams{
+		Status: string(status),
+		Ids:    ids,
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // Helper functions to convert between domain and database models
 
 func dbAppToApp(dbApp *database.Application) *Application {
@@ -149,6 +415,7 @@ func dbAppToApp(dbApp *database.Application) *Application {
 		UserID:       dbApp.UserID,
 		JobID:        dbApp.JobID,
 		Status:       ApplicationStatus(dbApp.Status),
+		StageID:      dbApp.StageID,
 		AppliedAt:    dbApp.AppliedAt,
 		UpdatedAt:    dbApp.UpdatedAt,
 		Notes:        fromNullString(dbApp.Notes),
@@ -165,6 +432,12 @@ func dbAppToApp(dbApp *database.Application) *Application {
 	if dbApp.FollowUpDate.Valid {
 		app.FollowUpDate = &dbApp.FollowUpDate.Time
 	}
+	if dbApp.GhostedAt.Valid {
+		app.GhostedAt = &dbApp.GhostedAt.Time
+	}
+	if dbApp.FirstResponseAt.Valid {
+		app.FirstResponseAt = &dbApp.FirstResponseAt.Time
+	}
 
 	return app
 }
@@ -179,3 +452,17 @@ func fromNullString(ns sql.NullString) string {
 	}
 	return ""
 }
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func fromNullTime(nt sql.NullTime) *time.Time {
+	if nt.Valid {
+		return &nt.Time
+	}
+	return nil
+}
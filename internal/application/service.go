@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
 	"github.com/luis-octavius/cintia/internal/job"
+	"github.com/luis-octavius/cintia/internal/pipeline"
 	"github.com/luis-octavius/cintia/internal/user"
+	"github.com/luis-octavius/cintia/internal/webhook"
 )
 
 var (
@@ -17,29 +21,46 @@ var (
 	ErrJobInactive         = errors.New("job inactive")
 	ErrUserNotFound        = errors.New("user not found")
 	ErrApplicationNotFound = errors.New("application not found")
+	ErrPipelineNotFound    = errors.New("pipeline not found")
+	ErrInvalidDateRange    = errors.New("from date must be before to date")
+	ErrInvalidStatsWeeks   = fmt.Errorf("weeks must be between 1 and %d", maxStatsWeeks)
+	ErrInvalidPosition     = errors.New("position must not be negative")
+	ErrNotJobOwner         = errors.New("only the recruiter who posted the job or an admin can see its applicants")
 )
 
 type Service interface {
 	CreateApplication(ctx context.Context, userID uuid.UUID, input CreateApplicationInput) (*Application, error)
 	GetApplicationByID(ctx context.Context, id uuid.UUID) (*Application, error)
 	GetUserApplications(ctx context.Context, userID uuid.UUID) ([]*Application, error)
-	GetJobApplications(ctx context.Context, jobID uuid.UUID) ([]*Application, error)
+	GetJobApplications(ctx context.Context, userID uuid.UUID, role auth.Role, jobID uuid.UUID) ([]*Application, error)
 	UpdateApplication(ctx context.Context, id uuid.UUID, updates UpdateApplicationInput) error
 	UpdateApplicationStatus(ctx context.Context, id uuid.UUID, status ApplicationStatus) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	MarkGhostedApplications(ctx context.Context, inactiveFor time.Duration) ([]*Application, error)
+	ExportApplications(ctx context.Context, userID uuid.UUID, filter ExportFilter) ([]*ExportRow, error)
+	ImportApplications(ctx context.Context, userID uuid.UUID, input ImportInput) (*ImportReport, error)
+	GetStats(ctx context.Context, userID uuid.UUID, weeks int) (*Stats, error)
+	GetBoard(ctx context.Context, userID uuid.UUID) (*Board, error)
+	MoveApplication(ctx context.Context, id uuid.UUID, input MoveInput) error
 }
 
 type service struct {
-	repo        Repository
-	jobService  job.Service
-	userService user.Service
+	repo            Repository
+	jobService      job.Service
+	userService     user.Service
+	pipelineService pipeline.Service
+	events          webhook.Publisher
 }
 
-func NewService(repo Repository, jobService job.Service, userService user.Service) Service {
+// NewService builds the application service. events may be nil when no
+// webhook subscribers need to hear about application changes
+func NewService(repo Repository, jobService job.Service, userService user.Service, pipelineService pipeline.Service, events webhook.Publisher) Service {
 	return &service{
-		repo:        repo,
-		jobService:  jobService,
-		userService: userService,
+		repo:            repo,
+		jobService:      jobService,
+		userService:     userService,
+		pipelineService: pipelineService,
+		events:          events,
 	}
 }
 
@@ -63,17 +84,34 @@ func (s *service) CreateApplication(ctx context.Context, userID uuid.UUID, input
 		return nil, ErrJobInactive
 	}
 
+	p, err := s.resolvePipeline(ctx, userID, input.PipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := p.InitialStage()
+	if stage == nil {
+		return nil, ErrPipelineNotFound
+	}
+
 	app := &Application{
 		ID:        uuid.New(),
 		UserID:    userID,
 		JobID:     input.JobID,
-		Status:    StatusApplied,
+		Status:    ApplicationStatus(stage.Key),
+		StageID:   stage.ID,
 		AppliedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		Notes:     input.Notes,
 	}
 
-	return s.repo.Create(ctx, app)
+	created, err := s.repo.Create(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, webhook.NewEvent(webhook.EventApplicationCreated, userID, created))
+	return created, nil
 }
 
 func (s *service) GetApplicationByID(ctx context.Context, id uuid.UUID) (*Application, error) {
@@ -103,12 +141,18 @@ func (s *service) GetUserApplications(ctx context.Context, userID uuid.UUID) ([]
 	return applications, nil
 }
 
-func (s *service) GetJobApplications(ctx context.Context, jobID uuid.UUID) ([]*Application, error) {
-	_, err := s.jobService.GetJob(ctx, jobID)
+// GetJobApplications lists who applied to a job. Only the recruiter who
+// posted it and admins may see them
+func (s *service) GetJobApplications(ctx context.Context, userID uuid.UUID, role auth.Role, jobID uuid.UUID) ([]*Application, error) {
+	j, err := s.jobService.GetJob(ctx, jobID)
 	if err != nil {
 		return nil, ErrJobNotFound
 	}
 
+	if role != auth.RoleAdmin && !(role.Can(auth.PermViewApplicants) && j.IsOwnedBy(userID)) {
+		return nil, ErrNotJobOwner
+	}
+
 	applications, err := s.repo.GetJobApplications(ctx, jobID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job applications: %w", err)
@@ -126,21 +170,11 @@ func (s *service) UpdateApplication(ctx context.Context, id uuid.UUID, updates U
 	// validate updates
 	updated := false
 
-	if updates.InterviewDate != nil {
-		application.InterviewDate = updates.InterviewDate
-		updated = true
-	}
-
 	if updates.OfferDate != nil {
 		application.OfferDate = updates.OfferDate
 		updated = true
 	}
 
-	if updates.Notes != "" {
-		application.Notes = updates.Notes
-		updated = true
-	}
-
 	if updates.SalaryOffer != "" {
 		application.SalaryOffer = updates.SalaryOffer
 		updated = true
@@ -175,15 +209,60 @@ func (s *service) UpdateApplicationStatus(ctx context.Context, id uuid.UUID, sta
 		return ErrApplicationNotFound
 	}
 
-	if !app.CanTransitionTo(status) {
-		return fmt.Errorf("cannot transition from %s to %s", app.Status, status)
+	stage, err := s.transitionStage(ctx, app, status)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateStatus(ctx, id, status, stage.ID); err != nil {
+		return err
+	}
+
+	s.publishStatusChange(ctx, app, status)
+	return nil
+}
+
+// transitionStage returns the stage of the application's pipeline matching
+// status, if the pipeline allows moving there
+func (s *service) transitionStage(ctx context.Context, app *Application, status ApplicationStatus) (*pipeline.Stage, error) {
+	p, err := s.pipelineService.GetPipelineForStage(ctx, app.StageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application pipeline: %w", err)
+	}
+
+	stage, ok := p.StageByKey(string(status))
+	if !ok {
+		return nil, ErrInvalidStatus
+	}
+
+	if !app.CanTransitionTo(p, status) {
+		return nil, fmt.Errorf("%w: cannot transition from %s to %s", ErrInvalidTransition, app.Status, status)
+	}
+
+	return stage, nil
+}
+
+// resolvePipeline returns the pipeline chosen for a new application, which
+// must be a system pipeline or one owned by the user
+func (s *service) resolvePipeline(ctx context.Context, userID uuid.UUID, pipelineID *uuid.UUID) (*pipeline.Pipeline, error) {
+	if pipelineID == nil {
+		p, err := s.pipelineService.GetDefaultPipeline(ctx, userID)
+		if err != nil {
+			return nil, ErrPipelineNotFound
+		}
+		return p, nil
+	}
+
+	p, err := s.pipelineService.GetPipeline(ctx, *pipelineID)
+	if err != nil {
+		return nil, ErrPipelineNotFound
 	}
 
-	if !status.IsValid() {
-		return ErrInvalidStatus
+	if p.UserID != nil && !p.IsOwnedBy(userID) {
+		return nil, ErrPipelineNotFound
 	}
 
-	return s.repo.UpdateStatus(ctx, id, status)
+	return p, nil
 }
 
 func (s *service) Delete(ctx context.Context, id uuid.UUID) error {
@@ -199,3 +278,297 @@ func (s *service) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// MarkGhostedApplications flags applications still waiting in applied or
+// interviewing with no updates for the given period as ghosted. Each one is
+// published as a status change, like a manual move
+func (s *service) MarkGhostedApplications(ctx context.Context, inactiveFor time.Duration) ([]*Application, error) {
+	if inactiveFor <= 0 {
+		return nil, errors.New("inactivity period must be positive")
+	}
+
+	changes, err := s.repo.MarkGhosted(ctx, time.Now().Add(-inactiveFor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark ghosted applications: %w", err)
+	}
+
+	applications := make([]*Application, len(changes))
+	for i, change := range changes {
+		s.publish(ctx, webhook.NewEvent(webhook.EventApplicationStatusChanged, change.Application.UserID, change))
+		applications[i] = change.Application
+	}
+
+	return applications, nil
+}
+
+// ExportApplications returns the user's applications joined with job data
+// for reporting
+func (s *service) ExportApplications(ctx context.Context, userID uuid.UUID, filter ExportFilter) ([]*ExportRow, error) {
+	if filter.AppliedFrom != nil && filter.AppliedTo != nil && !filter.AppliedFrom.Before(*filter.AppliedTo) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.repo.GetExportRows(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export applications: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ImportApplications creates applications from a CSV file, reporting the
+// outcome of every row. Jobs are matched by link and created as manual jobs
+// when unknown. Rows are independent: a failed row does not stop the import.
+// In dry run mode rows are only validated
+func (s *service) ImportApplications(ctx context.Context, userID uuid.UUID, input ImportInput) (*ImportReport, error) {
+	records, err := readImport(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.resolvePipeline(ctx, userID, input.PipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: input.DryRun, Total: len(records), Rows: make([]ImportRowResult, 0, len(records))}
+	// seen maps job links to the line that first used them
+	seen := make(map[string]int, len(records))
+
+	for _, record := range records {
+		result := s.importRow(ctx, userID, p, record, seen, input.DryRun)
+		report.add(result)
+	}
+
+	return report, nil
+}
+
+func (s *service) importRow(ctx context.Context, userID uuid.UUID, p *pipeline.Pipeline, record importRecord, seen map[string]int, dryRun bool) ImportRowResult {
+	result := ImportRowResult{Line: record.line}
+	fail := func(format string, args ...any) ImportRowResult {
+		result.Status = RowFailed
+		result.Error = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	title, company, link := record.get(ImportFieldTitle), record.get(ImportFieldCompany), record.get(ImportFieldLink)
+	switch {
+	case title == "":
+		return fail("title is required")
+	case company == "":
+		return fail("company is required")
+	case link == "":
+		return fail("link is required to match or create the job")
+	case !isWebURL(link):
+		return fail("link must be a valid URL")
+	}
+
+	stage := p.InitialStage()
+	if status := strings.ToLower(record.get(ImportFieldStatus)); status != "" {
+		var ok bool
+		if stage, ok = p.StageByKey(status); !ok {
+			return fail("status %q is not a stage of pipeline %s", status, p.Name)
+		}
+	}
+
+	appliedAt := time.Now()
+	if value := record.get(ImportFieldAppliedAt); value != "" {
+		parsed, err := parseImportDate(value)
+		if err != nil {
+			return fail("%s", err.Error())
+		}
+		if parsed.After(appliedAt) {
+			return fail("applied_at cannot be in the future")
+		}
+		appliedAt = parsed
+	}
+
+	if line, dup := seen[link]; dup {
+		result.Status = RowSkipped
+		result.Error = fmt.Sprintf("duplicate of line %d", line)
+		return result
+	}
+	seen[link] = record.line
+
+	existing, err := s.jobService.GetJobByLink(ctx, link)
+	if err != nil && !errors.Is(err, job.ErrJobNotFound) {
+		return fail("failed to look up job: %v", err)
+	}
+
+	if existing != nil {
+		result.JobID = &existing.ID
+		if app, _ := s.repo.GetUserJobApplication(ctx, userID, existing.ID); app != nil {
+			result.Status = RowSkipped
+			result.ApplicationID = &app.ID
+			result.Error = ErrAlreadyApplied.Error()
+			return result
+		}
+	}
+
+	if dryRun {
+		result.Status = RowValid
+		result.JobCreated = existing == nil
+		return result
+	}
+
+	if existing == nil {
+		existing, err = s.jobService.CreateJob(ctx, job.CreateJobInput{
+			Title:       title,
+			Company:     company,
+			Location:    record.get(ImportFieldLocation),
+			SalaryRange: record.get(ImportFieldSalaryRange),
+			Source:      "manual",
+			Link:        link,
+			PostedDate:  appliedAt,
+		})
+		if err != nil {
+			return fail("failed to create job: %v", err)
+		}
+		result.JobID = &existing.ID
+		result.JobCreated = true
+	}
+
+	app := &Application{
+		ID:        uuid.New(),
+		UserID:    userID,
+		JobID:     existing.ID,
+		Status:    ApplicationStatus(stage.Key),
+		StageID:   stage.ID,
+		AppliedAt: appliedAt,
+		UpdatedAt: time.Now(),
+		Notes:     record.get(ImportFieldNotes),
+	}
+
+	// Set the dates moving to the stage would have set. The file has no
+	// date for the answer, the applied date stands in for it
+	switch {
+	case stage.Kind == pipeline.KindGhosted:
+		app.GhostedAt = &appliedAt
+	case stage.Kind != pipeline.KindWithdrawn && stage.ID != p.InitialStage().ID:
+		app.FirstResponseAt = &appliedAt
+	}
+
+	created, err := s.repo.Create(ctx, app)
+	if err != nil {
+		return fail("failed to create application: %v", err)
+	}
+
+	s.publish(ctx, webhook.NewEvent(webhook.EventApplicationCreated, userID, created))
+
+	result.Status = RowCreated
+	result.ApplicationID = &created.ID
+	return result
+}
+
+// StatusChange is the data of an application.status_changed event
+type StatusChange struct {
+	Application *Application      `json:"application"`
+	From        ApplicationStatus `json:"from"`
+	To          ApplicationStatus `json:"to"`
+}
+
+// GetStats returns the dashboard metrics of the user's applications with
+// one entry per week for the last weeks, the current one included. Zero
+// weeks falls back to the default
+func (s *service) GetStats(ctx context.Context, userID uuid.UUID, weeks int) (*Stats, error) {
+	if weeks == 0 {
+		weeks = defaultStatsWeeks
+	}
+	if weeks < 1 || weeks > maxStatsWeeks {
+		return nil, ErrInvalidStatsWeeks
+	}
+
+	now := time.Now().UTC()
+	since := weekStart(now).AddDate(0, 0, -7*(weeks-1))
+
+	stats, err := s.repo.GetStats(ctx, userID, since, statsTopCompanies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application stats: %w", err)
+	}
+
+	stats.computeRates()
+	stats.PerWeek = fillWeeks(stats.PerWeek, since, now)
+	return stats, nil
+}
+
+// GetBoard returns the user's applications as board columns following the
+// stages of their default pipeline
+func (s *service) GetBoard(ctx context.Context, userID uuid.UUID) (*Board, error) {
+	p, err := s.pipelineService.GetDefaultPipeline(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default pipeline: %w", err)
+	}
+
+	cards, err := s.repo.GetBoardCards(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	columns := make([]BoardColumn, len(p.Stages))
+	for i, stage := range p.Stages {
+		columns[i] = BoardColumn{
+			Status:     ApplicationStatus(stage.Key),
+			Name:       stage.Name,
+			IsTerminal: stage.IsTerminal,
+		}
+	}
+
+	return buildBoard(p.ID, columns, cards), nil
+}
+
+// MoveApplication moves the application to another column, going through
+// the same transition checks as a status update, and stores its position
+// in the column
+func (s *service) MoveApplication(ctx context.Context, id uuid.UUID, input MoveInput) error {
+	if input.Position == nil || *input.Position < 0 {
+		return ErrInvalidPosition
+	}
+
+	app, err := s.GetApplicationByID(ctx, id)
+	if err != nil {
+		return ErrApplicationNotFound
+	}
+
+	// a status change is saved with the new position, a failed move must not
+	// leave the application in the new column at a stale position
+	status := app.Status
+	var stageID *uuid.UUID
+	if input.Status != "" && input.Status != app.Status {
+		stage, err := s.transitionStage(ctx, app, input.Status)
+		if err != nil {
+			return err
+		}
+		status, stageID = input.Status, &stage.ID
+	}
+
+	if err := s.repo.MoveCard(ctx, app.UserID, id, status, stageID, *input.Position); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrApplicationNotFound
+		}
+		return fmt.Errorf("failed to move application: %w", err)
+	}
+
+	if stageID != nil {
+		s.publishStatusChange(ctx, app, status)
+	}
+	return nil
+}
+
+func (s *service) publishStatusChange(ctx context.Context, app *Application, to ApplicationStatus) {
+	from := app.Status
+	changed := *app
+	changed.Status = to
+
+	s.publish(ctx, webhook.NewEvent(webhook.EventApplicationStatusChanged, app.UserID, StatusChange{
+		Application: &changed,
+		From:        from,
+		To:          to,
+	}))
+}
+
+func (s *service) publish(ctx context.Context, event webhook.Event) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(ctx, event)
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"math"
+	"time"
+)
+
+// Stats are the funnel metrics of a user's applications
+type Stats struct {
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"by_status"`
+	Funnel   Funnel         `json:"funnel"`
+	// Responded counts applications that got an answer, the base of
+	// MedianDaysToResponse
+	Responded            int         `json:"responded"`
+	MedianDaysToResponse *float64    `json:"median_days_to_response"`
+	PerWeek              []WeekCount `json:"per_week"`
+	BySource             []Breakdown `json:"by_source"`
+	ByCompany            []Breakdown `json:"by_company"`
+}
+
+// Funnel counts applications that reached each step and the conversion
+// rate between consecutive steps, from 0 to 1
+type Funnel struct {
+	Applied               int     `json:"applied"`
+	Interviewing          int     `json:"interviewing"`
+	Offer                 int     `json:"offer"`
+	Accepted              int     `json:"accepted"`
+	AppliedToInterviewing float64 `json:"applied_to_interviewing"`
+	InterviewingToOffer   float64 `json:"interviewing_to_offer"`
+	OfferToAccepted       float64 `json:"offer_to_accepted"`
+}
+
+type WeekCount struct {
+	WeekStart time.Time `json:"week_start"`
+	Count     int       `json:"count"`
+}
+
+// Breakdown is the funnel of the applications sharing a job source or
+// company
+type Breakdown struct {
+	Name          string  `json:"name"`
+	Total         int     `json:"total"`
+	Interviewing  int     `json:"interviewing"`
+	Offer         int     `json:"offer"`
+	Accepted      int     `json:"accepted"`
+	InterviewRate float64 `json:"interview_rate"`
+}
+
+const (
+	defaultStatsWeeks = 12
+	maxStatsWeeks     = 104
+	// statsTopCompanies bounds the company breakdown
+	statsTopCompanies = 10
+)
+
+// computeRates fills the conversion rates from the counts
+func (s *Stats) computeRates() {
+	s.Funnel.AppliedToInterviewing = rate(s.Funnel.Interviewing, s.Funnel.Applied)
+	s.Funnel.InterviewingToOffer = rate(s.Funnel.Offer, s.Funnel.Interviewing)
+	s.Funnel.OfferToAccepted = rate(s.Funnel.Accepted, s.Funnel.Offer)
+
+	for i := range s.BySource {
+		s.BySource[i].InterviewRate = rate(s.BySource[i].Interviewing, s.BySource[i].Total)
+	}
+	for i := range s.ByCompany {
+		s.ByCompany[i].InterviewRate = rate(s.ByCompany[i].Interviewing, s.ByCompany[i].Total)
+	}
+}
+
+// fillWeeks returns one entry per week from the week of since to the week
+// of now, with zero for weeks without applications
+func fillWeeks(counts []WeekCount, since, now time.Time) []WeekCount {
+	byWeek := make(map[time.Time]int, len(counts))
+	for _, wc := range counts {
+		byWeek[weekStart(wc.WeekStart)] = wc.Count
+	}
+
+	weeks := []WeekCount{}
+	for week := weekStart(since); !week.After(now); week = week.AddDate(0, 0, 7) {
+		weeks = append(weeks, WeekCount{WeekStart: week, Count: byWeek[week]})
+	}
+	return weeks
+}
+
+// weekStart returns midnight UTC of the Monday starting the week of t, the
+// same as date_trunc('week') in postgres
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func rate(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 10000
+}
+
+// isResponse reports whether moving to status means the company answered
+func isResponse(status ApplicationStatus) bool {
+	switch status {
+	case StatusApplied, StatusWithdrawn, StatusGhosted:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/job"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+func TestGetStats_FunnelAndBreakdowns(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	service := NewService(repo, nil, nil, nil, nil)
+	userID := uuid.New()
+
+	acme := &job.Job{ID: uuid.New(), Company: "Acme", Source: "linkedin"}
+	globex := &job.Job{ID: uuid.New(), Company: "Globex", Source: "manual"}
+	repo.addJob(acme)
+	repo.addJob(globex)
+
+	now := time.Now().UTC()
+	firstResponse := now.AddDate(0, 0, -1)
+	for _, app := range []*Application{
+		{UserID: userID, JobID: acme.ID, Status: StatusApplied, AppliedAt: now},
+		{UserID: userID, JobID: acme.ID, Status: StatusInterviewing, AppliedAt: now.AddDate(0, 0, -5), FirstResponseAt: &firstResponse},
+		{UserID: userID, JobID: acme.ID, Status: StatusAccepted, AppliedAt: now.AddDate(0, 0, -7), FirstResponseAt: &firstResponse},
+		{UserID: userID, JobID: globex.ID, Status: StatusRejected, AppliedAt: now.AddDate(0, -6, 0)},
+		{UserID: uuid.New(), JobID: globex.ID, Status: StatusOffer, AppliedAt: now},
+	} {
+		if _, err := repo.Create(context.Background(), app); err != nil {
+			t.Fatalf("failed to create application: %v", err)
+		}
+	}
+
+	stats, err := service.GetStats(context.Background(), userID, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Total != 4 || stats.ByStatus["applied"] != 1 || stats.ByStatus["rejected"] != 1 {
+		t.Fatalf("unexpected counts: total %d, by status %v", stats.Total, stats.ByStatus)
+	}
+
+	want := Funnel{
+		Applied: 4, Interviewing: 2, Offer: 1, Accepted: 1,
+		AppliedToInterviewing: 0.5, InterviewingToOffer: 0.5, OfferToAccepted: 1,
+	}
+	if stats.Funnel != want {
+		t.Fatalf("expected funnel %+v, got %+v", want, stats.Funnel)
+	}
+
+	if stats.Responded != 2 || stats.MedianDaysToResponse == nil || *stats.MedianDaysToResponse != 5 {
+		t.Fatalf("expected a median of 5 days over 2 responses, got %d and %v", stats.Responded, stats.MedianDaysToResponse)
+	}
+
+	if len(stats.PerWeek) != 4 {
+		t.Fatalf("expected 4 weeks, got %d", len(stats.PerWeek))
+	}
+	total := 0
+	for _, week := range stats.PerWeek {
+		if week.WeekStart.Weekday() != time.Monday {
+			t.Fatalf("expected weeks to start on monday, got %v", week.WeekStart)
+		}
+		total += week.Count
+	}
+	if total != 3 {
+		t.Fatalf("expected 3 applications in the last 4 weeks, got %d", total)
+	}
+	if last := stats.PerWeek[len(stats.PerWeek)-1]; !last.WeekStart.Equal(weekStart(now)) {
+		t.Fatalf("expected the current week last, got %v", last.WeekStart)
+	}
+
+	if len(stats.ByCompany) != 2 || stats.ByCompany[0].Name != "Acme" || stats.ByCompany[0].Total != 3 {
+		t.Fatalf("expected Acme first in the company breakdown, got %+v", stats.ByCompany)
+	}
+	if got := stats.ByCompany[0].InterviewRate; got != 0.6667 {
+		t.Fatalf("expected Acme interview rate 0.6667, got %v", got)
+	}
+	if len(stats.BySource) != 2 || stats.BySource[0].Name != "linkedin" {
+		t.Fatalf("expected linkedin first in the source breakdown, got %+v", stats.BySource)
+	}
+}
+
+func TestGetStats_CustomPipelineStages(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	service := NewService(repo, nil, nil, nil, nil)
+	userID := uuid.New()
+
+	custom := &pipeline.Pipeline{
+		ID: uuid.New(),
+		Stages: []pipeline.Stage{
+			{ID: uuid.New(), Key: "sent", Kind: pipeline.KindActive},
+			{ID: uuid.New(), Key: "final_round", Kind: pipeline.KindInterview},
+			{ID: uuid.New(), Key: "hired", Kind: pipeline.KindAccepted, IsTerminal: true},
+		},
+	}
+	repo.addPipeline(custom)
+
+	acme := &job.Job{ID: uuid.New(), Company: "Acme", Source: "linkedin"}
+	repo.addJob(acme)
+
+	now := time.Now().UTC()
+	for _, stage := range custom.Stages {
+		app, err := repo.Create(context.Background(), &Application{
+			UserID: userID, JobID: acme.ID, Status: ApplicationStatus(stage.Key), StageID: stage.ID, AppliedAt: now,
+		})
+		if err != nil {
+			t.Fatalf("failed to create application: %v", err)
+		}
+		// An interview round counts even while the stage is not one
+		if stage.Key == "sent" {
+			repo.addInterview(app.ID, now.Add(time.Hour))
+		}
+	}
+
+	stats, err := service.GetStats(context.Background(), userID, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f := stats.Funnel; f.Applied != 3 || f.Interviewing != 3 || f.Offer != 1 || f.Accepted != 1 {
+		t.Fatalf("expected the funnel to follow stage kinds, got %+v", f)
+	}
+}
+
+func TestGetStats_Weeks(t *testing.T) {
+	service := NewService(NewMockRepository(), nil, nil, nil, nil)
+
+	stats, err := service.GetStats(context.Background(), uuid.New(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats.PerWeek) != defaultStatsWeeks || stats.MedianDaysToResponse != nil {
+		t.Fatalf("expected %d empty weeks and no median, got %+v", defaultStatsWeeks, stats)
+	}
+	if stats.Funnel.AppliedToInterviewing != 0 {
+		t.Fatalf("expected zero rates without applications, got %+v", stats.Funnel)
+	}
+
+	if _, err := service.GetStats(context.Background(), uuid.New(), maxStatsWeeks+1); err != ErrInvalidStatsWeeks {
+		t.Fatalf("expected ErrInvalidStatsWeeks, got %v", err)
+	}
+}
+
+func TestUpdateStatus_RecordsFirstResponse(t *testing.T) {
+	repo := NewMockRepository()
+	app, err := repo.Create(context.Background(), &Application{UserID: uuid.New(), JobID: uuid.New(), Status: StatusApplied})
+	if err != nil {
+		t.Fatalf("failed to create application: %v", err)
+	}
+
+	if err := repo.UpdateStatus(context.Background(), app.ID, StatusWithdrawn, uuid.Nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.FirstResponseAt != nil {
+		t.Fatalf("withdrawing is not a response")
+	}
+
+	if err := repo.UpdateStatus(context.Background(), app.ID, StatusInterviewing, uuid.Nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.FirstResponseAt == nil {
+		t.Fatalf("expected first response to be recorded")
+	}
+}
@@ -0,0 +1,107 @@
+package attachment
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindResume      Kind = "resume"
+	KindCoverLetter Kind = "cover_letter"
+	KindOther       Kind = "other"
+)
+
+// MaxSize bounds the size of an uploaded document
+const MaxSize = 10 << 20
+
+type Attachment struct {
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Kind          Kind      `json:"kind"`
+	Filename      string    `json:"filename"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	Checksum      string    `json:"checksum"` // hex sha256 of the content
+	StorageKey    string    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// IsValid validate the attachment kind
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindResume, KindCoverLetter, KindOther:
+		return true
+	}
+	return false
+}
+
+// fileType is a document format accepted for upload. sniffed lists what
+// http.DetectContentType reports for it, since office formats are only
+// recognized as zip archives or plain binary
+type fileType struct {
+	contentType string
+	sniffed     []string
+}
+
+var allowedTypes = map[string]fileType{
+	".pdf":  {"application/pdf", []string{"application/pdf"}},
+	".doc":  {"application/msword", []string{"application/octet-stream"}},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", []string{"application/zip"}},
+	".odt":  {"application/vnd.oasis.opendocument.text", []string{"application/zip"}},
+	".rtf":  {"application/rtf", []string{"text/plain; charset=utf-8"}},
+	".txt":  {"text/plain; charset=utf-8", []string{"text/plain; charset=utf-8"}},
+	".md":   {"text/markdown; charset=utf-8", []string{"text/plain; charset=utf-8"}},
+}
+
+// lookupType returns the format of a file from its extension
+func lookupType(filename string) (fileType, bool) {
+	t, ok := allowedTypes[strings.ToLower(filepath.Ext(filename))]
+	return t, ok
+}
+
+// matches reports whether the sniffed content agrees with the extension
+func (t fileType) matches(sniffed string) bool {
+	for _, s := range t.sniffed {
+		if s == sniffed {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFilename keeps the base name of an uploaded file without control
+// characters, capped at 255 bytes while keeping the extension
+func cleanFilename(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filepath.Base(name))
+	name = strings.TrimSpace(name)
+
+	if name == "." || name == ".." || name == "/" {
+		return ""
+	}
+
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		base := []rune(strings.TrimSuffix(name, ext))
+		for len(string(base))+len(ext) > 255 {
+			base = base[:len(base)-1]
+		}
+		name = string(base) + ext
+	}
+	return name
+}
@@ -0,0 +1,75 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	ctx := context.Background()
+
+	n, err := store.Put(ctx, "user/app/doc", strings.NewReader("resume"))
+	if err != nil || n != 6 {
+		t.Fatalf("expected 6 bytes written, got %d and %v", n, err)
+	}
+
+	r, err := store.Get(ctx, "user/app/doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if string(content) != "resume" {
+		t.Fatalf("expected stored content, got %q", content)
+	}
+
+	if err := store.Delete(ctx, "user/app/doc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Delete(ctx, "user/app/doc"); err != nil {
+		t.Fatalf("deleting a missing blob should not fail: %v", err)
+	}
+	if _, err := store.Get(ctx, "user/app/doc"); err != ErrBlobNotFound {
+		t.Fatalf("expected ErrBlobNotFound, got %v", err)
+	}
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "user/../../outside", "user//doc", `user\doc`} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x")); err != ErrInvalidBlobKey {
+			t.Fatalf("expected ErrInvalidBlobKey for %q, got %v", key, err)
+		}
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	tests := map[string]string{
+		"resume.pdf":             "resume.pdf",
+		"../../etc/resume.pdf":   "resume.pdf",
+		`C:\Users\me\resume.pdf`: "resume.pdf",
+		"cv\x00\n.pdf":           "cv.pdf",
+		"..":                     "",
+		"":                       "",
+	}
+	for in, want := range tests {
+		if got := cleanFilename(in); got != want {
+			t.Fatalf("cleanFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	long := strings.Repeat("é", 200) + ".pdf"
+	if got := cleanFilename(long); len(got) > 255 || !strings.HasSuffix(got, ".pdf") {
+		t.Fatalf("expected a name of at most 255 bytes keeping the extension, got %d bytes", len(got))
+	}
+}
@@ -0,0 +1,241 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxUploadBody leaves room for the multipart framing around the file
+const maxUploadBody = MaxSize + 1<<20
+
+type Handler interface {
+	UploadAttachmentHandler(c *gin.Context)
+	GetApplicationAttachmentsHandler(c *gin.Context)
+	DownloadAttachmentHandler(c *gin.Context)
+	DeleteAttachmentHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/applications/:id/attachments - upload a document sent with an application
+//
+// The multipart form carries the document in "file" and its "kind", one of
+// resume, cover_letter or other
+func (h *GinHandler) UploadAttachmentHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadBody)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": ErrFileTooLarge.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "a document is required in the file field",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.Upload(c.Request.Context(), userID, applicationID, UploadInput{
+		Kind:     Kind(c.PostForm("kind")),
+		Filename: fileHeader.Filename,
+		Content:  file,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "attachment uploaded successfully",
+		"attachment": attachment,
+	})
+}
+
+// GET /api/applications/:id/attachments - list the documents of an application
+func (h *GinHandler) GetApplicationAttachmentsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return
+	}
+
+	attachments, err := h.service.GetApplicationAttachments(c.Request.Context(), userID, applicationID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"attachments": attachments,
+		"total":       len(attachments),
+	})
+}
+
+// GET /api/applications/:id/attachments/:attachmentID - download a document
+func (h *GinHandler) DownloadAttachmentHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, attachmentID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.service.Open(c.Request.Context(), userID, applicationID, attachmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	defer content.Close()
+
+	// Documents are always downloaded, never rendered by the browser
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	// The body is streamed, so a failure past this point can only be logged
+	if _, err := io.Copy(c.Writer, content); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// DELETE /api/applications/:id/attachments/:attachmentID - delete a document
+func (h *GinHandler) DeleteAttachmentHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, attachmentID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), userID, applicationID, attachmentID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "attachment deleted successfully",
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrApplicationNotFound),
+		errors.Is(err, ErrAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedType),
+		errors.Is(err, ErrContentMismatch):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrInvalidKind),
+		errors.Is(err, ErrMissingFilename),
+		errors.Is(err, ErrEmptyFile):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	applicationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("attachmentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid attachment id format",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return applicationID, attachmentID, true
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
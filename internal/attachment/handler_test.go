@@ -0,0 +1,205 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPDF = []byte("%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+
+func TestUploadAttachmentHandler_Success(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, _ := newTestHandler(t, userID, appID)
+
+	w := httptest.NewRecorder()
+	c := uploadContext(t, w, userID, appID, "resume", "../My Resume.pdf", testPDF)
+
+	// Execute
+	handler.UploadAttachmentHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Attachment Attachment `json:"attachment"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, KindResume, response.Attachment.Kind)
+	assert.Equal(t, "My Resume.pdf", response.Attachment.Filename)
+	assert.Equal(t, "application/pdf", response.Attachment.ContentType)
+	assert.Equal(t, int64(len(testPDF)), response.Attachment.Size)
+	assert.Len(t, response.Attachment.Checksum, 64)
+	assert.NotContains(t, w.Body.String(), "storage_key")
+}
+
+func TestUploadAttachmentHandler_Validation(t *testing.T) {
+	userID := uuid.New()
+	appID := uuid.New()
+
+	tests := []struct {
+		name     string
+		kind     string
+		filename string
+		content  []byte
+		status   int
+	}{
+		{"unsupported extension", "resume", "resume.exe", testPDF, http.StatusUnsupportedMediaType},
+		{"content does not match extension", "resume", "resume.pdf", []byte("MZ\x90\x00 not a pdf at all \x00\x01"), http.StatusUnsupportedMediaType},
+		{"invalid kind", "photo", "resume.pdf", testPDF, http.StatusBadRequest},
+		{"empty file", "resume", "resume.pdf", nil, http.StatusBadRequest},
+		{"too large", "resume", "resume.txt", bytes.Repeat([]byte("a"), MaxSize+1), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, _ := newTestHandler(t, userID, appID)
+			w := httptest.NewRecorder()
+			c := uploadContext(t, w, userID, appID, tt.kind, tt.filename, tt.content)
+
+			// Execute
+			handler.UploadAttachmentHandler(c)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestUploadAttachmentHandler_OtherUser(t *testing.T) {
+	// Setup
+	appID := uuid.New()
+	handler, _ := newTestHandler(t, uuid.New(), appID)
+
+	otherUser := uuid.New()
+	w := httptest.NewRecorder()
+	c := uploadContext(t, w, otherUser, appID, "resume", "resume.pdf", testPDF)
+
+	// Execute
+	handler.UploadAttachmentHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDownloadAttachmentHandler(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(t, userID, appID)
+
+	attachment, err := service.Upload(context.Background(), userID, appID, UploadInput{
+		Kind:     KindCoverLetter,
+		Filename: "carta de apresentação.pdf",
+		Content:  bytes.NewReader(testPDF),
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/applications/"+appID.String()+"/attachments/"+attachment.ID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}, {Key: "attachmentID", Value: attachment.ID.String()}}
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.DownloadAttachmentHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, testPDF, w.Body.Bytes())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename*=utf-8''carta%20de%20apresenta")
+}
+
+func TestDeleteAttachmentHandler_WrongApplication(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	otherAppID := uuid.New()
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID:      {ID: appID, UserID: userID},
+		otherAppID: {ID: otherAppID, UserID: userID},
+	}}
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	service := NewService(NewMockRepository(), store, apps)
+	handler := NewGinHandler(service)
+
+	attachment, err := service.Upload(context.Background(), userID, appID, UploadInput{Filename: "notes.txt", Content: bytes.NewReader([]byte("notes"))})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/applications/"+otherAppID.String()+"/attachments/"+attachment.ID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: otherAppID.String()}, {Key: "attachmentID", Value: attachment.ID.String()}}
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.DeleteAttachmentHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	_, content, err := service.Open(context.Background(), userID, appID, attachment.ID)
+	require.NoError(t, err)
+	content.Close()
+}
+
+func newTestHandler(t *testing.T, userID, appID uuid.UUID) (*GinHandler, Service) {
+	t.Helper()
+
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID: {ID: appID, UserID: userID},
+	}}
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	service := NewService(NewMockRepository(), store, apps)
+	return NewGinHandler(service), service
+}
+
+func uploadContext(t *testing.T, w *httptest.ResponseRecorder, userID, appID uuid.UUID, kind, filename string, content []byte) *gin.Context {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	require.NoError(t, form.WriteField("kind", kind))
+	part, err := form.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/applications/"+appID.String()+"/attachments", &body)
+	c.Request.Header.Set("Content-Type", form.FormDataContentType())
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+	c.Set("userID", userID.String())
+	return c
+}
+
+// Stub application service for testing, only GetApplicationByID is used
+type stubApplicationService struct {
+	application.Service
+	apps map[uuid.UUID]*application.Application
+}
+
+func (s *stubApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*application.Application, error) {
+	app, exists := s.apps[id]
+	if !exists {
+		return nil, application.ErrApplicationNotFound
+	}
+	return app, nil
+}
@@ -0,0 +1,19 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("attachment not found")
+
+type Repository interface {
+	Create(ctx context.Context, attachment *Attachment) (*Attachment, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error)
+	GetApplicationAttachments(ctx context.Context, applicationID uuid.UUID) ([]*Attachment, error)
+	// GetUserAttachments returns the attachments of a user, oldest first
+	GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,87 @@
+package attachment
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu          sync.RWMutex
+	attachments map[uuid.UUID]*Attachment
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		attachments: make(map[uuid.UUID]*Attachment),
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, attachment *Attachment) (*Attachment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if attachment.ID == uuid.Nil {
+		attachment.ID = uuid.New()
+	}
+	attachment.CreatedAt = time.Now()
+
+	m.attachments[attachment.ID] = attachment
+	return attachment, nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	attachment, exists := m.attachments[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return attachment, nil
+}
+
+func (m *mockRepository) GetApplicationAttachments(ctx context.Context, applicationID uuid.UUID) ([]*Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	attachments := []*Attachment{}
+	for _, attachment := range m.attachments {
+		if attachment.ApplicationID == applicationID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.After(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+func (m *mockRepository) GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	attachments := []*Attachment{}
+	for _, attachment := range m.attachments {
+		if attachment.UserID == userID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.attachments, id)
+	return nil
+}
@@ -0,0 +1,96 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, attachment *Attachment) (*Attachment, error) {
+	dbAttachment, err := r.queries.CreateAttachment(ctx, database.CreateAttachmentParams{
+		ID:            attachment.ID,
+		ApplicationID: attachment.ApplicationID,
+		UserID:        attachment.UserID,
+		Kind:          string(attachment.Kind),
+		Filename:      attachment.Filename,
+		ContentType:   attachment.ContentType,
+		SizeBytes:     attachment.Size,
+		Checksum:      attachment.Checksum,
+		StorageKey:    attachment.StorageKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbAttachmentToAttachment(&dbAttachment), nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error) {
+	dbAttachment, err := r.queries.GetAttachmentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbAttachmentToAttachment(&dbAttachment), nil
+}
+
+func (r *PostgresRepository) GetApplicationAttachments(ctx context.Context, applicationID uuid.UUID) ([]*Attachment, error) {
+	dbAttachments, err := r.queries.GetApplicationAttachments(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*Attachment, len(dbAttachments))
+	for i := range dbAttachments {
+		attachments[i] = dbAttachmentToAttachment(&dbAttachments[i])
+	}
+	return attachments, nil
+}
+
+func (r *PostgresRepository) GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*Attachment, error) {
+	dbAttachments, err := r.queries.GetUserAttachments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*Attachment, len(dbAttachments))
+	for i := range dbAttachments {
+		attachments[i] = dbAttachmentToAttachment(&dbAttachments[i])
+	}
+	return attachments, nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteAttachment(ctx, id)
+}
+
+func dbAttachmentToAttachment(dbAttachment *database.Attachment) *Attachment {
+	return &Attachment{
+		ID:            dbAttachment.ID,
+		ApplicationID: dbAttachment.ApplicationID,
+		UserID:        dbAttachment.UserID,
+		Kind:          Kind(dbAttachment.Kind),
+		Filename:      dbAttachment.Filename,
+		ContentType:   dbAttachment.ContentType,
+		Size:          dbAttachment.SizeBytes,
+		Checksum:      dbAttachment.Checksum,
+		StorageKey:    dbAttachment.StorageKey,
+		CreatedAt:     dbAttachment.CreatedAt,
+	}
+}
@@ -0,0 +1,247 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+)
+
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrForbidden           = errors.New("application belongs to another user")
+	ErrInvalidKind         = errors.New("invalid attachment kind")
+	ErrMissingFilename     = errors.New("filename is required")
+	ErrUnsupportedType     = errors.New("unsupported file type, expected pdf, doc, docx, odt, rtf, txt or md")
+	ErrContentMismatch     = errors.New("file content does not match its extension")
+	ErrEmptyFile           = errors.New("file is empty")
+	ErrFileTooLarge        = fmt.Errorf("file exceeds the limit of %d MB", MaxSize>>20)
+)
+
+// sniffLen is how much of the content http.DetectContentType looks at
+const sniffLen = 512
+
+type UploadInput struct {
+	Kind     Kind
+	Filename string
+	Content  io.Reader
+}
+
+type Service interface {
+	Upload(ctx context.Context, userID, applicationID uuid.UUID, input UploadInput) (*Attachment, error)
+	GetApplicationAttachments(ctx context.Context, userID, applicationID uuid.UUID) ([]*Attachment, error)
+	// Open returns the attachment and its content, which the caller closes
+	Open(ctx context.Context, userID, applicationID, id uuid.UUID) (*Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, userID, applicationID, id uuid.UUID) error
+	// GetUserAttachments returns every attachment of the user, oldest first
+	GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*Attachment, error)
+	// DeleteUserAttachments removes every attachment of the user with its
+	// content, for accounts being deleted
+	DeleteUserAttachments(ctx context.Context, userID uuid.UUID) error
+}
+
+type service struct {
+	repo       Repository
+	store      BlobStore
+	appService application.Service
+}
+
+func NewService(repo Repository, store BlobStore, appService application.Service) Service {
+	return &service{
+		repo:       repo,
+		store:      store,
+		appService: appService,
+	}
+}
+
+// Upload validates the document against its extension and stores it. The
+// content is hashed while streamed to the store, so it is read only once
+func (s *service) Upload(ctx context.Context, userID, applicationID uuid.UUID, input UploadInput) (*Attachment, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	kind := input.Kind
+	if kind == "" {
+		kind = KindOther
+	}
+	if !kind.IsValid() {
+		return nil, ErrInvalidKind
+	}
+
+	filename := cleanFilename(input.Filename)
+	if filename == "" {
+		return nil, ErrMissingFilename
+	}
+
+	fileType, ok := lookupType(filename)
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(input.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrEmptyFile
+	}
+	head = head[:n]
+
+	if !fileType.matches(http.DetectContentType(head)) {
+		return nil, ErrContentMismatch
+	}
+
+	id := uuid.New()
+	key := userID.String() + "/" + applicationID.String() + "/" + id.String()
+
+	hash := sha256.New()
+	content := io.LimitReader(io.MultiReader(bytes.NewReader(head), input.Content), MaxSize+1)
+
+	size, err := s.store.Put(ctx, key, io.TeeReader(content, hash))
+	if err != nil {
+		s.store.Delete(ctx, key)
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if size > MaxSize {
+		s.store.Delete(ctx, key)
+		return nil, ErrFileTooLarge
+	}
+
+	attachment, err := s.repo.Create(ctx, &Attachment{
+		ID:            id,
+		ApplicationID: applicationID,
+		UserID:        userID,
+		Kind:          kind,
+		Filename:      filename,
+		ContentType:   fileType.contentType,
+		Size:          size,
+		Checksum:      hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:    key,
+	})
+	if err != nil {
+		s.store.Delete(ctx, key)
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (s *service) GetApplicationAttachments(ctx context.Context, userID, applicationID uuid.UUID) ([]*Attachment, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.GetApplicationAttachments(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (s *service) Open(ctx context.Context, userID, applicationID, id uuid.UUID) (*Attachment, io.ReadCloser, error) {
+	attachment, err := s.getOwnedAttachment(ctx, userID, applicationID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+	return attachment, content, nil
+}
+
+// Delete removes the attachment and then its content. A blob left behind
+// by a failed store delete only costs space, so that error is not reported
+func (s *service) Delete(ctx context.Context, userID, applicationID, id uuid.UUID) error {
+	attachment, err := s.getOwnedAttachment(ctx, userID, applicationID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	s.store.Delete(ctx, attachment.StorageKey)
+	return nil
+}
+
+func (s *service) GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]*Attachment, error) {
+	attachments, err := s.repo.GetUserAttachments(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// DeleteUserAttachments deletes the content first and, unlike Delete,
+// reports store errors: the rows are what points to blobs left behind
+func (s *service) DeleteUserAttachments(ctx context.Context, userID uuid.UUID) error {
+	attachments, err := s.repo.GetUserAttachments(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+			return fmt.Errorf("failed to delete attachment content: %w", err)
+		}
+		if err := s.repo.Delete(ctx, attachment.ID); err != nil {
+			return fmt.Errorf("failed to delete attachment: %w", err)
+		}
+	}
+	return nil
+}
+
+// authorize makes sure the application exists and belongs to the user
+func (s *service) authorize(ctx context.Context, userID, applicationID uuid.UUID) error {
+	app, err := s.appService.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, application.ErrApplicationNotFound) {
+			return ErrApplicationNotFound
+		}
+		return err
+	}
+
+	if app.UserID != userID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// getOwnedAttachment loads an attachment making sure it belongs to the
+// given application and that the application belongs to the user
+func (s *service) getOwnedAttachment(ctx context.Context, userID, applicationID, id uuid.UUID) (*Attachment, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	if attachment.ApplicationID != applicationID || attachment.UserID != userID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
@@ -2,50 +2,175 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// AccessTokenTTL keeps access tokens short-lived, clients renew them with
+// their refresh token
+const AccessTokenTTL = 15 * time.Minute
+
+// ChallengeTokenTTL is how long a user has to enter their second factor
+// after the password was accepted
+const ChallengeTokenTTL = 5 * time.Minute
+
+// DefaultIssuer is the iss claim of tokens unless configured otherwise
+const DefaultIssuer = "cintia"
+
+// Audiences keep access and challenge tokens from being taken for one
+// another
+const (
+	AccessAudience    = "cintia-api"
+	challengeAudience = "cintia-2fa"
+)
+
 type UserClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"sid"` // session family the token was issued for
+	Email     string `json:"email"`
+	Role      string `json:"role"`
 	jwt.RegisteredClaims
 }
 
-func MakeJWT(userID uuid.UUID, email, role, secret string) (string, error) {
+// KeySet signs tokens with its current key and verifies them with any of
+// its keys. To rotate, sign with a new key and keep the old one for
+// verification until the tokens it signed have expired
+type KeySet struct {
+	issuer  string
+	signing *SigningKey
+	keys    map[string]*SigningKey
+	// order of the keys in the JWKS, signing key first
+	ids     []string
+	methods []string
+}
+
+func NewKeySet(issuer string, signing *SigningKey, previous ...*SigningKey) (*KeySet, error) {
+	if signing == nil || signing.Private == nil {
+		return nil, ErrNoPrivateKey
+	}
+	if issuer == "" {
+		issuer = DefaultIssuer
+	}
+
+	ks := &KeySet{
+		issuer:  issuer,
+		signing: signing,
+		keys:    make(map[string]*SigningKey),
+	}
+	for _, key := range append([]*SigningKey{signing}, previous...) {
+		if _, exists := ks.keys[key.ID]; exists {
+			continue
+		}
+		ks.keys[key.ID] = key
+		ks.ids = append(ks.ids, key.ID)
+
+		known := false
+		for _, m := range ks.methods {
+			known = known || m == key.Method.Alg()
+		}
+		if !known {
+			ks.methods = append(ks.methods, key.Method.Alg())
+		}
+	}
+
+	return ks, nil
+}
+
+// JWKS returns the public keys tokens are verified with
+func (ks *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(ks.ids))}
+	for _, id := range ks.ids {
+		jwks.Keys = append(jwks.Keys, ks.keys[id].JWK())
+	}
+	return jwks
+}
+
+func (ks *KeySet) MakeJWT(userID, sessionID uuid.UUID, email, role string) (string, error) {
 	claims := UserClaims{
-		UserID: userID.String(),
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "cintia",
-		},
+		UserID:           userID.String(),
+		SessionID:        sessionID.String(),
+		Email:            email,
+		Role:             role,
+		RegisteredClaims: ks.registeredClaims(userID, AccessAudience, AccessTokenTTL),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return ks.sign(claims)
 }
 
-func ValidateJWT(tokenString, secret string) (*UserClaims, error) {
+func (ks *KeySet) ValidateJWT(tokenString string) (*UserClaims, error) {
+	claims := &UserClaims{}
+	if err := ks.parse(tokenString, claims, AccessAudience); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// MakeChallengeJWT issues the token returned by a login that still needs a
+// second factor. It only proves the password was right
+func (ks *KeySet) MakeChallengeJWT(userID uuid.UUID) (string, error) {
+	return ks.sign(ks.registeredClaims(userID, challengeAudience, ChallengeTokenTTL))
+}
+
+// ValidateChallengeJWT returns the user a challenge token was issued for
+func (ks *KeySet) ValidateChallengeJWT(tokenString string) (uuid.UUID, error) {
+	claims := &jwt.RegisteredClaims{}
+	if err := ks.parse(tokenString, claims, challengeAudience); err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(claims.Subject)
+}
+
+func (ks *KeySet) registeredClaims(userID uuid.UUID, audience string, ttl time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		Audience:  jwt.ClaimStrings{audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Issuer:    ks.issuer,
+	}
+}
+
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.Private)
+}
+
+// parse only accepts tokens signed by one of the keys, with the algorithm
+// of that key, from this issuer and for the audience
+func (ks *KeySet) parse(tokenString string, claims jwt.Claims, audience string) error {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
-		&UserClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		},
+		claims,
+		ks.keyFunc,
+		jwt.WithValidMethods(ks.methods),
+		jwt.WithIssuer(ks.issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	if claims, ok := token.Claims.(*UserClaims); ok && token.Valid {
-		return claims, nil
+	if !token.Valid {
+		return errors.New("invalid token")
 	}
+	return nil
+}
 
-	return nil, errors.New("invalid token")
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	// a key only ever signs with one algorithm
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("key %s does not sign with %s", kid, token.Method.Alg())
+	}
+	return key.Public, nil
 }
@@ -1,6 +1,10 @@
 package auth
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,75 +13,264 @@ import (
 )
 
 func TestValidateJWT_Success(t *testing.T) {
-	id := uuid.New()
-	secret := "Ponga la Ponga"
-	email := "test@example.com"
-	role := "admin"
+	for name, key := range map[string]*SigningKey{"EdDSA": newEd25519Key(t), "RS256": newRSAKey(t)} {
+		t.Run(name, func(t *testing.T) {
+			keys := newKeySet(t, key)
+			id := uuid.New()
+			email := "test@example.com"
+			role := "admin"
+			sessionID := uuid.New()
 
-	token, err := MakeJWT(id, email, role, secret)
-	if err != nil {
-		t.Fatalf("MakeJWT Error: %v", err)
-	}
+			token, err := keys.MakeJWT(id, sessionID, email, role)
+			if err != nil {
+				t.Fatalf("MakeJWT Error: %v", err)
+			}
 
-	claims, err := ValidateJWT(token, secret)
-	if err != nil {
-		t.Fatalf("ValidateJWT Error: %v", err)
-	}
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &UserClaims{})
+			if err != nil {
+				t.Fatalf("ParseUnverified Error: %v", err)
+			}
+			if parsed.Header["kid"] != key.ID || parsed.Method.Alg() != name {
+				t.Errorf("expected kid %v and alg %v, got %v and %v", key.ID, name, parsed.Header["kid"], parsed.Method.Alg())
+			}
 
-	if claims.UserID != id.String() {
-		t.Errorf("expected id %v to match %v", id.String(), claims.UserID)
-	}
+			claims, err := keys.ValidateJWT(token)
+			if err != nil {
+				t.Fatalf("ValidateJWT Error: %v", err)
+			}
 
-	if claims.Email != email {
-		t.Errorf("expected email %v to match %v", email, claims.Email)
-	}
+			if claims.UserID != id.String() || claims.Subject != id.String() {
+				t.Errorf("expected id %v to match %v and %v", id.String(), claims.UserID, claims.Subject)
+			}
+
+			if claims.Email != email {
+				t.Errorf("expected email %v to match %v", email, claims.Email)
+			}
+
+			if claims.Role != role {
+				t.Errorf("expected role %v to match %v", role, claims.Role)
+			}
+
+			if claims.SessionID != sessionID.String() {
+				t.Errorf("expected session id %v to match %v", sessionID.String(), claims.SessionID)
+			}
 
-	if claims.Role != role {
-		t.Errorf("expected role %v to match %v", role, claims.Role)
+			if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != AccessTokenTTL {
+				t.Errorf("expected token lifetime %v, got %v", AccessTokenTTL, lifetime)
+			}
+		})
 	}
 }
 
 func TestValidateJWT_ExpiredToken(t *testing.T) {
-	id := uuid.New()
-	secret := "Alright"
-	email := "test@example.com"
-	role := "user"
+	key := newEd25519Key(t)
+	keys := newKeySet(t, key)
 
 	claims := UserClaims{
-		UserID: id.String(),
-		Email:  email,
-		Role:   role,
+		UserID: uuid.New().String(),
+		Email:  "test@example.com",
+		Role:   "user",
 		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{AccessAudience},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-1 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Hour)),
-			Issuer:    "cintia",
+			Issuer:    DefaultIssuer,
 		},
 	}
 
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	token := signWith(t, key, jwt.SigningMethodEdDSA, claims)
+
+	if _, err := keys.ValidateJWT(token); err == nil {
+		t.Errorf("Expected error on validating token, got nil")
+	}
+}
+
+func TestValidateJWT_WrongKey(t *testing.T) {
+	token, err := newKeySet(t, newEd25519Key(t)).MakeJWT(uuid.New(), uuid.New(), "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("MakeJWT Error: %v", err)
+	}
+
+	if _, err := newKeySet(t, newEd25519Key(t)).ValidateJWT(token); err == nil {
+		t.Errorf("Expected error on validating token signed by another key, got nil")
+	}
+}
+
+func TestValidateJWT_Strict(t *testing.T) {
+	key := newRSAKey(t)
+	keys := newKeySet(t, key)
+	valid := func() UserClaims {
+		return UserClaims{
+			UserID: uuid.New().String(),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Audience:  jwt.ClaimStrings{AccessAudience},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				IssuedAt:  jwt.NewNumericDate(time.Now()),
+				Issuer:    DefaultIssuer,
+			},
+		}
+	}
+
+	// the well-known confusion attack: HS256 with the public key as secret
+	publicKey := []byte(keys.JWKS().Keys[0].N)
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, valid())
+	hmacToken.Header["kid"] = key.ID
+	confused, err := hmacToken.SignedString(publicKey)
 	if err != nil {
 		t.Fatalf("Token signing error: %v", err)
 	}
 
-	_, err = ValidateJWT(token, secret)
-	if err == nil {
-		t.Errorf("Expected error on validating token, got nil")
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, valid())
+	none.Header["kid"] = key.ID
+	unsigned, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("Token signing error: %v", err)
+	}
+
+	otherIssuer := valid()
+	otherIssuer.Issuer = "someone-else"
+	noAudience := valid()
+	noAudience.Audience = nil
+	noExpiry := valid()
+	noExpiry.ExpiresAt = nil
+
+	noKid := jwt.NewWithClaims(jwt.SigningMethodRS256, valid())
+	withoutKid, err := noKid.SignedString(key.Private)
+	if err != nil {
+		t.Fatalf("Token signing error: %v", err)
+	}
+
+	tests := map[string]string{
+		"hmac with public key": confused,
+		"alg none":             unsigned,
+		"other issuer":         signWith(t, key, jwt.SigningMethodRS256, otherIssuer),
+		"no audience":          signWith(t, key, jwt.SigningMethodRS256, noAudience),
+		"no expiry":            signWith(t, key, jwt.SigningMethodRS256, noExpiry),
+		"no kid":               withoutKid,
+	}
+
+	if _, err := keys.ValidateJWT(signWith(t, key, jwt.SigningMethodRS256, valid())); err != nil {
+		t.Fatalf("expected well formed token to be valid: %v", err)
+	}
+	for name, token := range tests {
+		if _, err := keys.ValidateJWT(token); err == nil {
+			t.Errorf("%s: expected token to be refused", name)
+		}
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	newKey := newRSAKey(t)
+
+	before := newKeySet(t, oldKey)
+	token, err := before.MakeJWT(uuid.New(), uuid.New(), "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("MakeJWT Error: %v", err)
+	}
+
+	oldPublic, err := NewVerificationKey(oldKey.Public)
+	if err != nil {
+		t.Fatalf("NewVerificationKey Error: %v", err)
+	}
+	rotated, err := NewKeySet(DefaultIssuer, newKey, oldPublic)
+	if err != nil {
+		t.Fatalf("NewKeySet Error: %v", err)
+	}
+
+	if _, err := rotated.ValidateJWT(token); err != nil {
+		t.Errorf("expected token of the previous key to stay valid: %v", err)
+	}
+
+	jwks := rotated.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].KeyID != newKey.ID || jwks.Keys[1].KeyID != oldKey.ID {
+		t.Errorf("expected JWKS with the new key then the old one, got %+v", jwks.Keys)
+	}
+
+	if _, err := newKeySet(t, newKey).ValidateJWT(token); err == nil {
+		t.Error("expected token to be refused once the previous key is dropped")
+	}
+
+	if _, err := NewKeySet(DefaultIssuer, oldPublic); err == nil {
+		t.Error("expected a public key to be refused as signing key")
 	}
 }
 
-func TestValidateJWT_WrongSecret(t *testing.T) {
+func TestChallengeJWT_NotAnAccessToken(t *testing.T) {
 	id := uuid.New()
-	secret := "Sun is rising"
-	email := "test@example.com"
-	role := "user"
+	keys := newKeySet(t, newEd25519Key(t))
+
+	challenge, err := keys.MakeChallengeJWT(id)
+	if err != nil {
+		t.Fatalf("MakeChallengeJWT Error: %v", err)
+	}
+
+	userID, err := keys.ValidateChallengeJWT(challenge)
+	if err != nil {
+		t.Fatalf("ValidateChallengeJWT Error: %v", err)
+	}
+	if userID != id {
+		t.Errorf("expected user %v, got %v", id, userID)
+	}
+
+	if _, err := keys.ValidateJWT(challenge); err == nil {
+		t.Error("expected challenge token to be refused as an access token")
+	}
 
-	token, err := MakeJWT(id, email, role, "Sun is dying")
+	access, err := keys.MakeJWT(id, uuid.New(), "test@example.com", "candidate")
 	if err != nil {
 		t.Fatalf("MakeJWT Error: %v", err)
 	}
+	if _, err := keys.ValidateChallengeJWT(access); err == nil {
+		t.Error("expected access token to be refused as a challenge token")
+	}
+}
+
+func newEd25519Key(t *testing.T) *SigningKey {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey Error: %v", err)
+	}
+	key, err := NewSigningKey(private)
+	if err != nil {
+		t.Fatalf("NewSigningKey Error: %v", err)
+	}
+	return key
+}
+
+func newRSAKey(t *testing.T) *SigningKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, MinRSABits)
+	if err != nil {
+		t.Fatalf("GenerateKey Error: %v", err)
+	}
+	key, err := NewSigningKey(private)
+	if err != nil {
+		t.Fatalf("NewSigningKey Error: %v", err)
+	}
+	return key
+}
 
-	_, err = ValidateJWT(token, secret)
-	if err == nil {
-		t.Errorf("Expected error on validating token with wrong secret, got nil")
+func newKeySet(t *testing.T, key *SigningKey) *KeySet {
+	t.Helper()
+	keys, err := NewKeySet(DefaultIssuer, key)
+	if err != nil {
+		t.Fatalf("NewKeySet Error: %v", err)
+	}
+	return keys
+}
+
+func signWith(t *testing.T, key *SigningKey, method jwt.SigningMethod, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.Private)
+	if err != nil {
+		t.Fatalf("Token signing error: %v", err)
+	}
+	if strings.Count(signed, ".") != 2 {
+		t.Fatalf("unexpected token %q", signed)
 	}
+	return signed
 }
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MinRSABits is the smallest RSA key accepted for signing tokens
+const MinRSABits = 2048
+
+var (
+	ErrUnsupportedKey = errors.New("unsupported key, use an RSA or Ed25519 key")
+	ErrWeakKey        = fmt.Errorf("RSA keys must be at least %d bits", MinRSABits)
+	ErrNoPrivateKey   = errors.New("signing key has no private part")
+	ErrUnknownKeyID   = errors.New("token signed with an unknown key")
+)
+
+// SigningKey is a key tokens are signed or verified with. Private is nil
+// for keys that are only kept to verify tokens issued before a rotation
+type SigningKey struct {
+	// ID is the RFC 7638 thumbprint of the public key, sent as the kid
+	// header of tokens
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// NewSigningKey wraps an *rsa.PrivateKey (RS256) or ed25519.PrivateKey
+// (EdDSA)
+func NewSigningKey(private crypto.Signer) (*SigningKey, error) {
+	key, err := NewVerificationKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.Private = private
+	return key, nil
+}
+
+// NewVerificationKey wraps an *rsa.PublicKey or ed25519.PublicKey
+func NewVerificationKey(public crypto.PublicKey) (*SigningKey, error) {
+	var method jwt.SigningMethod
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < MinRSABits {
+			return nil, ErrWeakKey
+		}
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	key := &SigningKey{Method: method, Public: public}
+	thumbprint, err := json.Marshal(key.thumbprintMembers())
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(thumbprint)
+	key.ID = base64.RawURLEncoding.EncodeToString(sum[:])
+	return key, nil
+}
+
+// GenerateSigningKey creates an Ed25519 key, for development setups that
+// have no key configured
+func GenerateSigningKey() (*SigningKey, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigningKey(private)
+}
+
+// ParseKeyPEM reads a PEM encoded private key (PKCS #8, or PKCS #1 for
+// RSA) or public key (PKIX)
+func ParseKeyPEM(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := private.(crypto.Signer)
+		if !ok {
+			return nil, ErrUnsupportedKey
+		}
+		return NewSigningKey(signer)
+	case "RSA PRIVATE KEY":
+		private, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewSigningKey(private)
+	case "PUBLIC KEY":
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewVerificationKey(public)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+// JWK is the public part of a key as published in the JWKS
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK returns the public key in JSON Web Key form
+func (k *SigningKey) JWK() JWK {
+	members := k.thumbprintMembers()
+	return JWK{
+		KeyType:   members["kty"],
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Method.Alg(),
+		N:         members["n"],
+		E:         members["e"],
+		Curve:     members["crv"],
+		X:         members["x"],
+	}
+}
+
+// thumbprintMembers are the required members of the JWK. encoding/json
+// sorts map keys, which gives the RFC 7638 form when marshaled
+func (k *SigningKey) thumbprintMembers() map[string]string {
+	encode := base64.RawURLEncoding.EncodeToString
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   encode(pub.N.Bytes()),
+			"e":   encode(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   encode(pub),
+		}
+	default:
+		return nil
+	}
+}
+
+// JWKSHandler serves the verification keys of a key set, for services
+// checking cintia tokens on their own
+func JWKSHandler(keys *KeySet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// short enough for a rotated key to show up before it signs
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(keys.JWKS())
+	})
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSigningKey_Thumbprint(t *testing.T) {
+	// RFC 7638 section 3.1
+	n, _ := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	rsaKey, err := NewVerificationKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+	if err != nil {
+		t.Fatalf("NewVerificationKey Error: %v", err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; rsaKey.ID != want {
+		t.Errorf("expected RSA kid %v, got %v", want, rsaKey.ID)
+	}
+
+	// RFC 8037 appendix A.3
+	x, _ := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	edKey, err := NewVerificationKey(ed25519.PublicKey(x))
+	if err != nil {
+		t.Fatalf("NewVerificationKey Error: %v", err)
+	}
+	if want := "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; edKey.ID != want {
+		t.Errorf("expected Ed25519 kid %v, got %v", want, edKey.ID)
+	}
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey Error: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, MinRSABits)
+	if err != nil {
+		t.Fatalf("GenerateKey Error: %v", err)
+	}
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey Error: %v", err)
+	}
+
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(private)
+	pkix, _ := x509.MarshalPKIXPublicKey(public)
+
+	tests := []struct {
+		name    string
+		block   *pem.Block
+		alg     string
+		private bool
+		err     error
+	}{
+		{"pkcs8 ed25519", &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}, "EdDSA", true, nil},
+		{"pkix ed25519", &pem.Block{Type: "PUBLIC KEY", Bytes: pkix}, "EdDSA", false, nil},
+		{"pkcs1 rsa", &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}, "RS256", true, nil},
+		{"weak rsa", &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(weakKey)}, "", false, ErrWeakKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKeyPEM(pem.EncodeToMemory(tt.block))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected error %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseKeyPEM Error: %v", err)
+			}
+			if key.Method.Alg() != tt.alg {
+				t.Errorf("expected alg %v, got %v", tt.alg, key.Method.Alg())
+			}
+			if (key.Private != nil) != tt.private {
+				t.Errorf("expected private part %v", tt.private)
+			}
+		})
+	}
+
+	if _, err := ParseKeyPEM([]byte("not a key")); err == nil {
+		t.Error("expected error for data without PEM block")
+	}
+}
+
+func TestJWKSHandler(t *testing.T) {
+	key := newEd25519Key(t)
+	keys := newKeySet(t, key)
+
+	w := httptest.NewRecorder()
+	JWKSHandler(keys).ServeHTTP(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+
+	var jwks JWKS
+	if err := json.Unmarshal(w.Body.Bytes(), &jwks); err != nil {
+		t.Fatalf("Unmarshal Error: %v", err)
+	}
+	if len(jwks.Keys) != 1 {
+		t.Fatalf("expected one key, got %d", len(jwks.Keys))
+	}
+
+	jwk := jwks.Keys[0]
+	x, _ := base64.RawURLEncoding.DecodeString(jwk.X)
+	if jwk.KeyType != "OKP" || jwk.Curve != "Ed25519" || jwk.Algorithm != "EdDSA" || jwk.Use != "sig" || jwk.KeyID != key.ID {
+		t.Errorf("unexpected JWK %+v", jwk)
+	}
+	if !ed25519.PublicKey(x).Equal(key.Public) {
+		t.Error("expected JWK to carry the public key")
+	}
+	if containsPrivate(w.Body.String()) {
+		t.Error("expected no private key material in the JWKS")
+	}
+}
+
+func containsPrivate(body string) bool {
+	var raw struct {
+		Keys []map[string]any `json:"keys"`
+	}
+	json.Unmarshal([]byte(body), &raw)
+	for _, k := range raw.Keys {
+		if _, ok := k["d"]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package auth
+
+type Role string
+
+const (
+	RoleCandidate Role = "candidate"
+	RoleRecruiter Role = "recruiter"
+	RoleAdmin     Role = "admin"
+)
+
+// Permission names an action guarded by RequirePermission. Access to the
+// user's own data is checked by ownership instead
+type Permission string
+
+const (
+	PermCreateJobs     Permission = "jobs:create"
+	PermManageJobs     Permission = "jobs:manage"
+	PermViewApplicants Permission = "jobs:applicants"
+	PermManageUsers    Permission = "users:manage"
+)
+
+// rolePermissions lists what each role may do besides managing its own
+// data. Admins may do everything
+var rolePermissions = map[Role][]Permission{
+	RoleCandidate: {},
+	RoleRecruiter: {PermCreateJobs, PermManageJobs, PermViewApplicants},
+}
+
+// IsValid validate the role
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleCandidate, RoleRecruiter, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Can reports whether the role grants the permission
+func (r Role) Can(p Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package auth
+
+import "testing"
+
+func TestRoleCan(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		want       bool
+	}{
+		{RoleCandidate, PermCreateJobs, false},
+		{RoleCandidate, PermViewApplicants, false},
+		{RoleRecruiter, PermCreateJobs, true},
+		{RoleRecruiter, PermManageJobs, true},
+		{RoleRecruiter, PermManageUsers, false},
+		{RoleAdmin, PermManageUsers, true},
+		{Role("intern"), PermCreateJobs, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Can(tt.permission); got != tt.want {
+			t.Errorf("expected %s can %s to be %v, got %v", tt.role, tt.permission, tt.want, got)
+		}
+	}
+}
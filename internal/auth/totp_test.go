@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 4226 appendix D
+func TestHOTP_RFCVectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+	expected := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+
+	for counter, want := range expected {
+		if got := hotp(key, uint64(counter), 6); got != want {
+			t.Errorf("counter %d: expected %s, got %s", counter, want, got)
+		}
+	}
+}
+
+// RFC 6238 appendix B, SHA1 with 8 digits
+func TestTOTP_RFCVectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1234567890:  "89005924",
+		20000000000: "65353130",
+	}
+
+	for unix, want := range tests {
+		if got := hotp(key, uint64(totpStep(time.Unix(unix, 0))), 8); got != want {
+			t.Errorf("time %d: expected %s, got %s", unix, want, got)
+		}
+	}
+}
+
+func TestValidateTOTP_Skew(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111109, 0)
+
+	code, err := TOTPCode(secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "081804" {
+		t.Fatalf("expected 081804, got %s", code)
+	}
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		valid bool
+	}{
+		{"same period", now, true},
+		{"previous period", now.Add(-TOTPPeriod), true},
+		{"next period", now.Add(TOTPPeriod), true},
+		{"two periods late", now.Add(2 * TOTPPeriod), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, valid, err := ValidateTOTP(secret, code, tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if valid != tt.valid {
+				t.Errorf("expected valid=%v", tt.valid)
+			}
+			if valid && step != totpStep(now) {
+				t.Errorf("expected step %d, got %d", totpStep(now), step)
+			}
+		})
+	}
+
+	if _, valid, _ := ValidateTOTP(secret, "12345", now); valid {
+		t.Error("expected short code to be rejected")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Cintia", "ada@example.com", "JBSWY3DPEHPK3PXP")
+
+	for _, want := range []string{"otpauth://totp/Cintia:ada@example.com?", "secret=JBSWY3DPEHPK3PXP", "issuer=Cintia", "digits=6", "period=30"} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("expected %q in %s", want, uri)
+		}
+	}
+}
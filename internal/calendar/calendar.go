@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Feed is a user's private iCalendar subscription. The token itself is never
+// stored, only its hash
+type Feed struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	TokenHash      string     `json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+}
+
+// JobInfo is the part of the linked job shown in calendar entries
+type JobInfo struct {
+	Title   string
+	Company string
+	Link    string
+}
+
+// InterviewRound is a scheduled interview round of one of the user's
+// applications
+type InterviewRound struct {
+	ID              uuid.UUID
+	ApplicationID   uuid.UUID
+	RoundName       string
+	Type            string
+	ScheduledAt     time.Time
+	DurationMinutes int
+	Location        string
+	MeetingLink     string
+	Outcome         string
+	UpdatedAt       time.Time
+	Job             JobInfo
+}
+
+// ApplicationDates holds the interview and follow-up dates tracked directly
+// on an application
+type ApplicationDates struct {
+	ApplicationID uuid.UUID
+	Status        string
+	InterviewDate *time.Time
+	FollowUpDate  *time.Time
+	// Closed is set when the application reached a terminal stage
+	Closed    bool
+	UpdatedAt time.Time
+	Job       JobInfo
+}
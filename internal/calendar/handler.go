@@ -0,0 +1,163 @@
+package calendar
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const feedExtension = ".ics"
+
+type Handler interface {
+	IssueTokenHandler(c *gin.Context)
+	GetFeedHandler(c *gin.Context)
+	RevokeFeedHandler(c *gin.Context)
+	FeedHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+	// baseURL is the public address of the API used in feed URLs. When
+	// empty it is taken from the request
+	baseURL string
+}
+
+func NewGinHandler(service Service, baseURL string) *GinHandler {
+	return &GinHandler{
+		service: service,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// POST /api/calendar/feed - issue a new feed URL, revoking the previous one
+func (h *GinHandler) IssueTokenHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	token, feed, err := h.service.IssueToken(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	feedURL := h.feedURL(c, token)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "calendar feed created successfully",
+		"url":        feedURL,
+		"webcal_url": "webcal://" + strings.SplitN(feedURL, "://", 2)[1],
+		"feed":       feed,
+	})
+}
+
+// GET /api/calendar/feed - feed status, the URL is only shown when issued
+func (h *GinHandler) GetFeedHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	feed, err := h.service.GetFeed(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// DELETE /api/calendar/feed - revoke the feed URL
+func (h *GinHandler) RevokeFeedHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeFeed(c.Request.Context(), userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "calendar feed revoked successfully",
+	})
+}
+
+// GET /api/calendar/:token.ics - iCalendar feed, authenticated by the token
+func (h *GinHandler) FeedHandler(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("token"), feedExtension)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": ErrInvalidToken.Error(),
+		})
+		return
+	}
+
+	body, err := h.service.RenderFeed(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="cintia.ics"`)
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
+
+func (h *GinHandler) feedURL(c *gin.Context, token string) string {
+	base := h.baseURL
+	if base == "" {
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+			scheme = proto
+		}
+		base = scheme + "://" + c.Request.Host
+	}
+
+	return base + "/api/calendar/" + token + feedExtension
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrFeedNotFound),
+		errors.Is(err, ErrInvalidToken):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
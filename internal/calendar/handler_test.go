@@ -0,0 +1,147 @@
+package calendar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedHandler_RendersInterviewsAndFollowUps(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	repo := NewMockRepository().(*mockRepository)
+	handler := NewGinHandler(NewService(repo), "https://api.example.com/")
+
+	appID := uuid.New()
+	scheduled := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	followUp := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	job := JobInfo{Title: "Go Developer", Company: "Acme", Link: "https://jobs.example.com/1"}
+	repo.addInterviewRound(userID, &InterviewRound{
+		ID:              uuid.New(),
+		ApplicationID:   appID,
+		RoundName:       "System design",
+		Type:            "technical",
+		ScheduledAt:     scheduled,
+		DurationMinutes: 90,
+		MeetingLink:     "https://meet.example.com/abc",
+		Outcome:         "pending",
+		Job:             job,
+	})
+	repo.addApplicationDates(userID, &ApplicationDates{
+		ApplicationID: appID,
+		Status:        "interviewing",
+		InterviewDate: &scheduled,
+		FollowUpDate:  &followUp,
+		Job:           job,
+	})
+	// Entries of other users never show up
+	repo.addApplicationDates(uuid.New(), &ApplicationDates{ApplicationID: uuid.New(), FollowUpDate: &followUp, Job: JobInfo{Title: "Other"}})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/calendar/feed", nil)
+	c.Set("userID", userID.String())
+	handler.IssueTokenHandler(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var issued struct {
+		URL       string `json:"url"`
+		WebcalURL string `json:"webcal_url"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	require.True(t, strings.HasPrefix(issued.URL, "https://api.example.com/api/calendar/"))
+	assert.True(t, strings.HasPrefix(issued.WebcalURL, "webcal://api.example.com/api/calendar/"))
+	token := strings.TrimPrefix(issued.URL, "https://api.example.com/api/calendar/")
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/calendar/"+token, nil)
+	c.Params = gin.Params{{Key: "token", Value: token}}
+
+	// Execute
+	handler.FeedHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := strings.ReplaceAll(w.Body.String(), "\r\n ", "")
+	assert.Equal(t, 1, strings.Count(body, "BEGIN:VEVENT"), "interview date tracked as a round is listed once")
+	assert.Equal(t, 1, strings.Count(body, "BEGIN:VTODO"))
+	assert.Contains(t, body, "SUMMARY:System design interview: Go Developer at Acme\r\n")
+	assert.Contains(t, body, "DTEND:"+scheduled.Add(90*time.Minute).UTC().Format(utcFormat)+"\r\n")
+	assert.Contains(t, body, "SUMMARY:Follow up: Go Developer at Acme\r\n")
+	assert.Contains(t, body, "DUE:"+followUp.UTC().Format(utcFormat)+"\r\n")
+	assert.NotContains(t, body, "Other")
+
+	feed, err := repo.GetFeedByUser(c.Request.Context(), userID)
+	require.NoError(t, err)
+	assert.NotNil(t, feed.LastAccessedAt)
+}
+
+func TestFeedHandler_RevokedToken(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	service := NewService(NewMockRepository())
+	handler := NewGinHandler(service, "")
+
+	oldToken, _, err := service.IssueToken(t.Context(), userID)
+	require.NoError(t, err)
+	_, _, err = service.IssueToken(t.Context(), userID)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/calendar/"+oldToken+".ics", nil)
+	c.Params = gin.Params{{Key: "token", Value: oldToken + ".ics"}}
+
+	// Execute
+	handler.FeedHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFeedHandler_RequiresExtension(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository())
+	handler := NewGinHandler(service, "")
+
+	token, _, err := service.IssueToken(t.Context(), uuid.New())
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/calendar/"+token, nil)
+	c.Params = gin.Params{{Key: "token", Value: token}}
+
+	// Execute
+	handler.FeedHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetFeedHandler_NotIssued(t *testing.T) {
+	// Setup
+	handler := NewGinHandler(NewService(NewMockRepository()), "")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/calendar/feed", nil)
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.GetFeedHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
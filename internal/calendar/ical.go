@@ -0,0 +1,167 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	prodID = "-//Cintia//Job Application Tracker//EN"
+	// maxLineOctets is the longest content line allowed before folding
+	// (RFC 5545 section 3.1)
+	maxLineOctets = 75
+	utcFormat     = "20060102T150405Z"
+)
+
+// Event status values (RFC 5545 section 3.8.1.11)
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event is a VEVENT component
+type Event struct {
+	UID          string
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Status       string
+	LastModified time.Time
+}
+
+// Todo is a VTODO component
+type Todo struct {
+	UID          string
+	Due          time.Time
+	Summary      string
+	Description  string
+	URL          string
+	Completed    bool
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR object published as a read only feed
+type Calendar struct {
+	Name   string
+	Events []Event
+	Todos  []Todo
+}
+
+// Encode writes the calendar in iCalendar format. stamp is used as the
+// DTSTAMP of every component
+func (c *Calendar) Encode(w io.Writer, stamp time.Time) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", prodID)
+	e.line("CALSCALE", "GREGORIAN")
+	e.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		e.line("BEGIN", "VEVENT")
+		e.line("UID", event.UID)
+		e.line("DTSTAMP", formatUTC(stamp))
+		e.line("DTSTART", formatUTC(event.Start))
+		e.line("DTEND", formatUTC(event.End))
+		e.line("SUMMARY", escapeText(event.Summary))
+		e.optional("DESCRIPTION", escapeText(event.Description))
+		e.optional("LOCATION", escapeText(event.Location))
+		e.optional("URL", event.URL)
+		e.optional("STATUS", event.Status)
+		if !event.LastModified.IsZero() {
+			e.line("LAST-MODIFIED", formatUTC(event.LastModified))
+		}
+		e.line("END", "VEVENT")
+	}
+
+	for _, todo := range c.Todos {
+		e.line("BEGIN", "VTODO")
+		e.line("UID", todo.UID)
+		e.line("DTSTAMP", formatUTC(stamp))
+		e.line("DUE", formatUTC(todo.Due))
+		e.line("SUMMARY", escapeText(todo.Summary))
+		e.optional("DESCRIPTION", escapeText(todo.Description))
+		e.optional("URL", todo.URL)
+		if todo.Completed {
+			e.line("STATUS", "COMPLETED")
+		} else {
+			e.line("STATUS", "NEEDS-ACTION")
+		}
+		if !todo.LastModified.IsZero() {
+			e.line("LAST-MODIFIED", formatUTC(todo.LastModified))
+		}
+		e.line("END", "VTODO")
+	}
+
+	e.line("END", "VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) optional(name, value string) {
+	if value != "" {
+		e.line(name, value)
+	}
+}
+
+// line writes a content line, folding it into continuation lines starting
+// with a space when it exceeds 75 octets. Folds never split a UTF-8 sequence
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+
+	content := name + ":" + value
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+
+		if _, e.err = e.w.WriteString(content[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		content = content[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+
+	_, e.err = e.w.WriteString(content + "\r\n")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(utcFormat)
+}
@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEncode_WritesComponents(t *testing.T) {
+	start := time.Date(2026, 3, 10, 14, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	cal := &Calendar{
+		Name: "Test",
+		Events: []Event{{
+			UID:     "interview-1@cintia",
+			Start:   start,
+			End:     start.Add(time.Hour),
+			Summary: "Tech interview: Go Developer at Acme, Inc.",
+			Status:  StatusConfirmed,
+		}},
+		Todos: []Todo{{
+			UID:       "application-1-follow-up@cintia",
+			Due:       start,
+			Summary:   "Follow up",
+			Completed: true,
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf, start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VEVENT\r\nUID:interview-1@cintia\r\n",
+		"DTSTART:20260310T170000Z\r\n",
+		"DTEND:20260310T180000Z\r\n",
+		"SUMMARY:Tech interview: Go Developer at Acme\\, Inc.\r\n",
+		"BEGIN:VTODO\r\n",
+		"DUE:20260310T170000Z\r\n",
+		"STATUS:COMPLETED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	description := strings.Repeat("entrevista técnica; ", 12)
+	cal := &Calendar{Events: []Event{{UID: "1", Summary: "x", Description: description}}}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Fatalf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+		if !utf8.ValidString(line) {
+			t.Fatalf("fold split a UTF-8 sequence: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "DESCRIPTION:"+escapeText(description)+"\r\n") {
+		t.Fatalf("expected unfolded description to round trip, got:\n%s", unfolded)
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	got := escapeText("a\\b;c,d\ne")
+	want := `a\\b\;c\,d\ne`
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,24 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("calendar feed not found")
+
+type Repository interface {
+	// SaveFeed stores the token hash of the user's feed, replacing any
+	// previous token
+	SaveFeed(ctx context.Context, userID uuid.UUID, tokenHash string) (*Feed, error)
+	GetFeedByUser(ctx context.Context, userID uuid.UUID) (*Feed, error)
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*Feed, error)
+	TouchFeed(ctx context.Context, userID uuid.UUID) error
+	DeleteFeed(ctx context.Context, userID uuid.UUID) error
+
+	GetInterviewRounds(ctx context.Context, userID uuid.UUID, since time.Time) ([]*InterviewRound, error)
+	GetApplicationDates(ctx context.Context, userID uuid.UUID, since time.Time) ([]*ApplicationDates, error)
+}
@@ -0,0 +1,149 @@
+package calendar
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu     sync.RWMutex
+	feeds  map[uuid.UUID]*Feed
+	rounds map[uuid.UUID][]*InterviewRound
+	dates  map[uuid.UUID][]*ApplicationDates
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		feeds:  make(map[uuid.UUID]*Feed),
+		rounds: make(map[uuid.UUID][]*InterviewRound),
+		dates:  make(map[uuid.UUID][]*ApplicationDates),
+	}
+}
+
+// addInterviewRound registers an interview round of one of the user's
+// applications
+func (m *mockRepository) addInterviewRound(userID uuid.UUID, round *InterviewRound) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rounds[userID] = append(m.rounds[userID], round)
+}
+
+// addApplicationDates registers the dates tracked on one of the user's
+// applications
+func (m *mockRepository) addApplicationDates(userID uuid.UUID, dates *ApplicationDates) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dates[userID] = append(m.dates[userID], dates)
+}
+
+func (m *mockRepository) SaveFeed(ctx context.Context, userID uuid.UUID, tokenHash string) (*Feed, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	feed := &Feed{UserID: userID, TokenHash: tokenHash, CreatedAt: time.Now()}
+	m.feeds[userID] = feed
+
+	copied := *feed
+	return &copied, nil
+}
+
+func (m *mockRepository) GetFeedByUser(ctx context.Context, userID uuid.UUID) (*Feed, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	feed, exists := m.feeds[userID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	copied := *feed
+	return &copied, nil
+}
+
+func (m *mockRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*Feed, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, feed := range m.feeds {
+		if feed.TokenHash == tokenHash {
+			copied := *feed
+			return &copied, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) TouchFeed(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if feed, exists := m.feeds[userID]; exists {
+		now := time.Now()
+		feed.LastAccessedAt = &now
+	}
+	return nil
+}
+
+func (m *mockRepository) DeleteFeed(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.feeds, userID)
+	return nil
+}
+
+func (m *mockRepository) GetInterviewRounds(ctx context.Context, userID uuid.UUID, since time.Time) ([]*InterviewRound, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rounds := []*InterviewRound{}
+	for _, round := range m.rounds[userID] {
+		if !round.ScheduledAt.Before(since) {
+			rounds = append(rounds, round)
+		}
+	}
+
+	sort.Slice(rounds, func(i, j int) bool {
+		return rounds[i].ScheduledAt.Before(rounds[j].ScheduledAt)
+	})
+	return rounds, nil
+}
+
+func (m *mockRepository) GetApplicationDates(ctx context.Context, userID uuid.UUID, since time.Time) ([]*ApplicationDates, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := []*ApplicationDates{}
+	for _, dates := range m.dates[userID] {
+		copied := *dates
+		if copied.InterviewDate != nil && (copied.InterviewDate.Before(since) || m.hasRound(userID, copied.ApplicationID, *copied.InterviewDate)) {
+			copied.InterviewDate = nil
+		}
+		if copied.FollowUpDate != nil && copied.FollowUpDate.Before(since) {
+			copied.FollowUpDate = nil
+		}
+		if copied.InterviewDate != nil || copied.FollowUpDate != nil {
+			result = append(result, &copied)
+		}
+	}
+
+	return result, nil
+}
+
+// hasRound reports whether an interview date is already tracked as an
+// interview round. Callers must hold the lock
+func (m *mockRepository) hasRound(userID, applicationID uuid.UUID, at time.Time) bool {
+	for _, round := range m.rounds[userID] {
+		if round.ApplicationID == applicationID && round.ScheduledAt.Equal(at) {
+			return true
+		}
+	}
+	return false
+}
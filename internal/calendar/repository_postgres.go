@@ -0,0 +1,152 @@
+package calendar
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) SaveFeed(ctx context.Context, userID uuid.UUID, tokenHash string) (*Feed, error) {
+	dbFeed, err := r.queries.UpsertCalendarFeed(ctx, database.UpsertCalendarFeedParams{
+		UserID:    userID,
+		TokenHash: tokenHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbFeedToFeed(&dbFeed), nil
+}
+
+func (r *PostgresRepository) GetFeedByUser(ctx context.Context, userID uuid.UUID) (*Feed, error) {
+	dbFeed, err := r.queries.GetCalendarFeedByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbFeedToFeed(&dbFeed), nil
+}
+
+func (r *PostgresRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*Feed, error) {
+	dbFeed, err := r.queries.GetCalendarFeedByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbFeedToFeed(&dbFeed), nil
+}
+
+func (r *PostgresRepository) TouchFeed(ctx context.Context, userID uuid.UUID) error {
+	return r.queries.TouchCalendarFeed(ctx, userID)
+}
+
+func (r *PostgresRepository) DeleteFeed(ctx context.Context, userID uuid.UUID) error {
+	return r.queries.DeleteCalendarFeed(ctx, userID)
+}
+
+func (r *PostgresRepository) GetInterviewRounds(ctx context.Context, userID uuid.UUID, since time.Time) ([]*InterviewRound, error) {
+	rows, err := r.queries.ListCalendarInterviews(ctx, database.ListCalendarInterviewsParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := make([]*InterviewRound, len(rows))
+	for i, row := range rows {
+		rounds[i] = &InterviewRound{
+			ID:              row.ID,
+			ApplicationID:   row.ApplicationID,
+			RoundName:       row.RoundName,
+			Type:            row.Type,
+			ScheduledAt:     row.ScheduledAt,
+			DurationMinutes: int(row.DurationMinutes),
+			Location:        fromNullString(row.Location),
+			MeetingLink:     fromNullString(row.MeetingLink),
+			Outcome:         row.Outcome,
+			UpdatedAt:       row.UpdatedAt,
+			Job: JobInfo{
+				Title:   row.JobTitle,
+				Company: row.JobCompany,
+				Link:    row.JobLink,
+			},
+		}
+	}
+
+	return rounds, nil
+}
+
+func (r *PostgresRepository) GetApplicationDates(ctx context.Context, userID uuid.UUID, since time.Time) ([]*ApplicationDates, error) {
+	rows, err := r.queries.ListCalendarApplications(ctx, database.ListCalendarApplicationsParams{
+		Since:  since,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]*ApplicationDates, len(rows))
+	for i, row := range rows {
+		dates[i] = &ApplicationDates{
+			ApplicationID: row.ID,
+			Status:        row.Status,
+			Closed:        row.IsTerminal,
+			UpdatedAt:     row.UpdatedAt,
+			Job: JobInfo{
+				Title:   row.JobTitle,
+				Company: row.JobCompany,
+				Link:    row.JobLink,
+			},
+		}
+		if row.InterviewDate.Valid {
+			dates[i].InterviewDate = &row.InterviewDate.Time
+		}
+		if row.FollowUpDate.Valid {
+			dates[i].FollowUpDate = &row.FollowUpDate.Time
+		}
+	}
+
+	return dates, nil
+}
+
+func dbFeedToFeed(dbFeed *database.CalendarFeed) *Feed {
+	feed := &Feed{
+		UserID:    dbFeed.UserID,
+		TokenHash: dbFeed.TokenHash,
+		CreatedAt: dbFeed.CreatedAt,
+	}
+
+	if dbFeed.LastAccessedAt.Valid {
+		feed.LastAccessedAt = &dbFeed.LastAccessedAt.Time
+	}
+
+	return feed
+}
+
+func fromNullString(ns sql.NullString) string {
+	if ns.Valid {
+		return ns.String
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFeedNotFound = errors.New("calendar feed not found")
+	ErrInvalidToken = errors.New("invalid calendar token")
+)
+
+const (
+	// lookback is how far in the past entries are kept in the feed
+	lookback = 90 * 24 * time.Hour
+	// defaultInterviewDuration applies to interview dates tracked on the
+	// application, which carry no duration
+	defaultInterviewDuration = time.Hour
+	uidDomain                = "cintia"
+)
+
+type Service interface {
+	// IssueToken creates the user's feed token, revoking any previous one.
+	// The token is only returned here
+	IssueToken(ctx context.Context, userID uuid.UUID) (string, *Feed, error)
+	GetFeed(ctx context.Context, userID uuid.UUID) (*Feed, error)
+	RevokeFeed(ctx context.Context, userID uuid.UUID) error
+	// RenderFeed returns the iCalendar document of the feed the token
+	// belongs to
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) IssueToken(ctx context.Context, userID uuid.UUID) (string, *Feed, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+
+	feed, err := s.repo.SaveFeed(ctx, userID, hashToken(token))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return token, feed, nil
+}
+
+func (s *service) GetFeed(ctx context.Context, userID uuid.UUID) (*Feed, error) {
+	feed, err := s.repo.GetFeedByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+func (s *service) RevokeFeed(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.GetFeed(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteFeed(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+
+	feed, err := s.repo.GetFeedByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	now := time.Now()
+	since := now.Add(-lookback)
+
+	rounds, err := s.repo.GetInterviewRounds(ctx, feed.UserID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interviews: %w", err)
+	}
+
+	dates, err := s.repo.GetApplicationDates(ctx, feed.UserID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application dates: %w", err)
+	}
+
+	cal := buildCalendar(rounds, dates)
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf, now); err != nil {
+		return nil, fmt.Errorf("failed to encode calendar: %w", err)
+	}
+
+	// Last access is informative only, a failure must not break the feed
+	_ = s.repo.TouchFeed(ctx, feed.UserID)
+
+	return buf.Bytes(), nil
+}
+
+// buildCalendar turns interview rounds and application interview dates into
+// events, and follow-up dates into to-dos
+func buildCalendar(rounds []*InterviewRound, dates []*ApplicationDates) *Calendar {
+	cal := &Calendar{
+		Name:   "Cintia interviews and follow-ups",
+		Events: make([]Event, 0, len(rounds)),
+		Todos:  []Todo{},
+	}
+
+	for _, round := range rounds {
+		status := StatusConfirmed
+		if round.Outcome == "cancelled" {
+			status = StatusCancelled
+		}
+
+		description := []string{"Type: " + round.Type}
+		if round.MeetingLink != "" {
+			description = append(description, "Meeting link: "+round.MeetingLink)
+		}
+		if round.Job.Link != "" {
+			description = append(description, "Job posting: "+round.Job.Link)
+		}
+
+		location := round.Location
+		if location == "" {
+			location = round.MeetingLink
+		}
+
+		url := round.MeetingLink
+		if url == "" {
+			url = round.Job.Link
+		}
+
+		cal.Events = append(cal.Events, Event{
+			UID:          fmt.Sprintf("interview-%s@%s", round.ID, uidDomain),
+			Start:        round.ScheduledAt,
+			End:          round.ScheduledAt.Add(time.Duration(round.DurationMinutes) * time.Minute),
+			Summary:      fmt.Sprintf("%s interview: %s", round.RoundName, jobLabel(round.Job)),
+			Description:  strings.Join(description, "\n"),
+			Location:     location,
+			URL:          url,
+			Status:       status,
+			LastModified: round.UpdatedAt,
+		})
+	}
+
+	for _, app := range dates {
+		if app.InterviewDate != nil {
+			cal.Events = append(cal.Events, Event{
+				UID:          fmt.Sprintf("application-%s-interview@%s", app.ApplicationID, uidDomain),
+				Start:        *app.InterviewDate,
+				End:          app.InterviewDate.Add(defaultInterviewDuration),
+				Summary:      "Interview: " + jobLabel(app.Job),
+				Description:  applicationDescription(app),
+				URL:          app.Job.Link,
+				Status:       StatusConfirmed,
+				LastModified: app.UpdatedAt,
+			})
+		}
+
+		if app.FollowUpDate != nil {
+			cal.Todos = append(cal.Todos, Todo{
+				UID:          fmt.Sprintf("application-%s-follow-up@%s", app.ApplicationID, uidDomain),
+				Due:          *app.FollowUpDate,
+				Summary:      "Follow up: " + jobLabel(app.Job),
+				Description:  applicationDescription(app),
+				URL:          app.Job.Link,
+				Completed:    app.Closed,
+				LastModified: app.UpdatedAt,
+			})
+		}
+	}
+
+	return cal
+}
+
+func jobLabel(job JobInfo) string {
+	if job.Company == "" {
+		return job.Title
+	}
+	return job.Title + " at " + job.Company
+}
+
+func applicationDescription(app *ApplicationDates) string {
+	description := "Application status: " + app.Status
+	if app.Job.Link != "" {
+		description += "\nJob posting: " + app.Job.Link
+	}
+	return description
+}
+
+// generateToken returns a URL safe random token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,86 @@
+package contact
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InteractionType string
+
+const (
+	InteractionEmailSent     InteractionType = "email_sent"
+	InteractionEmailReceived InteractionType = "email_received"
+	InteractionCall          InteractionType = "call"
+	InteractionMessage       InteractionType = "message"
+	InteractionMeeting       InteractionType = "meeting"
+	InteractionOther         InteractionType = "other"
+)
+
+// Contact is a recruiter, hiring manager or referral met during the search
+type Contact struct {
+	ID                uuid.UUID   `json:"id"`
+	UserID            uuid.UUID   `json:"user_id"`
+	Name              string      `json:"name"`
+	Email             string      `json:"email,omitempty"`
+	LinkedInURL       string      `json:"linkedin_url,omitempty"`
+	Role              string      `json:"role,omitempty"`
+	Company           string      `json:"company,omitempty"`
+	Notes             string      `json:"notes,omitempty"`
+	ApplicationIDs    []uuid.UUID `json:"application_ids,omitempty"`     // only filled when fetching a single contact
+	LastInteractionAt *time.Time  `json:"last_interaction_at,omitempty"` // only filled in lists
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
+
+type Interaction struct {
+	ID            uuid.UUID       `json:"id"`
+	ContactID     uuid.UUID       `json:"contact_id"`
+	ApplicationID *uuid.UUID      `json:"application_id,omitempty"`
+	Type          InteractionType `json:"type"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Summary       string          `json:"summary,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type CreateContactInput struct {
+	Name        string `json:"name" binding:"required"`
+	Email       string `json:"email,omitempty"`
+	LinkedInURL string `json:"linkedin_url,omitempty"`
+	Role        string `json:"role,omitempty"`
+	Company     string `json:"company,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+}
+
+// UpdateContactInput changes the fields that are set, an empty string
+// clears an optional field
+type UpdateContactInput struct {
+	Name        *string `json:"name,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	LinkedInURL *string `json:"linkedin_url,omitempty"`
+	Role        *string `json:"role,omitempty"`
+	Company     *string `json:"company,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+}
+
+type CreateInteractionInput struct {
+	Type          InteractionType `json:"type" binding:"required"`
+	OccurredAt    *time.Time      `json:"occurred_at,omitempty"` // defaults to now
+	Summary       string          `json:"summary,omitempty"`
+	ApplicationID *uuid.UUID      `json:"application_id,omitempty"`
+}
+
+// IsValid validate the interaction type
+func (t InteractionType) IsValid() bool {
+	switch t {
+	case InteractionEmailSent, InteractionEmailReceived, InteractionCall, InteractionMessage,
+		InteractionMeeting, InteractionOther:
+		return true
+	}
+	return false
+}
+
+// IsOwnedBy checks whether the contact belongs to the user
+func (c *Contact) IsOwnedBy(userID uuid.UUID) bool {
+	return c.UserID == userID
+}
@@ -0,0 +1,365 @@
+package contact
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	CreateContactHandler(c *gin.Context)
+	GetContactsHandler(c *gin.Context)
+	GetContactHandler(c *gin.Context)
+	UpdateContactHandler(c *gin.Context)
+	DeleteContactHandler(c *gin.Context)
+	LogInteractionHandler(c *gin.Context)
+	GetInteractionsHandler(c *gin.Context)
+	GetApplicationContactsHandler(c *gin.Context)
+	LinkApplicationHandler(c *gin.Context)
+	UnlinkApplicationHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/contacts - create a contact
+func (h *GinHandler) CreateContactHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req CreateContactInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	contact, err := h.service.CreateContact(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "contact created successfully",
+		"contact": contact,
+	})
+}
+
+// GET /api/contacts?company= - list the user's contacts with their last interaction
+func (h *GinHandler) GetContactsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	contacts, err := h.service.GetUserContacts(c.Request.Context(), userID, c.Query("company"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"contacts": contacts,
+		"total":    len(contacts),
+	})
+}
+
+// GET /api/contacts/:id - get a contact with its linked applications
+func (h *GinHandler) GetContactHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseID(c, "id", "invalid contact id format")
+	if !ok {
+		return
+	}
+
+	contact, err := h.service.GetContact(c.Request.Context(), userID, contactID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+// PUT /api/contacts/:id - update a contact
+func (h *GinHandler) UpdateContactHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseID(c, "id", "invalid contact id format")
+	if !ok {
+		return
+	}
+
+	var req UpdateContactInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	contact, err := h.service.UpdateContact(c.Request.Context(), userID, contactID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "contact updated successfully",
+		"contact": contact,
+	})
+}
+
+// DELETE /api/contacts/:id - delete a contact with its interactions
+func (h *GinHandler) DeleteContactHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseID(c, "id", "invalid contact id format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteContact(c.Request.Context(), userID, contactID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "contact deleted successfully",
+	})
+}
+
+// POST /api/contacts/:id/interactions - log an email, call or message with a contact
+func (h *GinHandler) LogInteractionHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseID(c, "id", "invalid contact id format")
+	if !ok {
+		return
+	}
+
+	var req CreateInteractionInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	interaction, err := h.service.LogInteraction(c.Request.Context(), userID, contactID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "interaction logged successfully",
+		"interaction": interaction,
+	})
+}
+
+// GET /api/contacts/:id/interactions - interaction log of a contact, newest first
+func (h *GinHandler) GetInteractionsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseID(c, "id", "invalid contact id format")
+	if !ok {
+		return
+	}
+
+	interactions, err := h.service.GetInteractions(c.Request.Context(), userID, contactID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"interactions": interactions,
+		"total":        len(interactions),
+	})
+}
+
+// GET /api/applications/:id/contacts - contacts involved in an application
+func (h *GinHandler) GetApplicationContactsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	contacts, err := h.service.GetApplicationContacts(c.Request.Context(), userID, applicationID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"contacts": contacts,
+		"total":    len(contacts),
+	})
+}
+
+// POST /api/applications/:id/contacts - link a contact to an application
+func (h *GinHandler) LinkApplicationHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ContactID uuid.UUID `json:"contact_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.LinkApplication(c.Request.Context(), userID, applicationID, req.ContactID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "contact linked successfully",
+	})
+}
+
+// DELETE /api/applications/:id/contacts/:contactID - unlink a contact from an application
+func (h *GinHandler) UnlinkApplicationHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseID(c, "contactID", "invalid contact id format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnlinkApplication(c.Request.Context(), userID, applicationID, contactID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "contact unlinked successfully",
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrContactNotFound),
+		errors.Is(err, ErrApplicationNotFound),
+		errors.Is(err, ErrLinkNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrMissingName),
+		errors.Is(err, ErrInvalidEmail),
+		errors.Is(err, ErrInvalidLinkedInURL),
+		errors.Is(err, ErrInvalidInteractionType),
+		errors.Is(err, ErrInteractionInTheFuture):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
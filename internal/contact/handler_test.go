@@ -0,0 +1,223 @@
+package contact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateContactHandler_Success(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler, _ := newTestHandler(userID, uuid.New())
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "POST", "/contacts", userID, CreateContactInput{
+		Name:        " Maria Silva ",
+		Email:       "maria@acme.com",
+		LinkedInURL: "https://www.linkedin.com/in/mariasilva",
+		Role:        "Tech Recruiter",
+		Company:     "Acme",
+	})
+
+	// Execute
+	handler.CreateContactHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Contact Contact `json:"contact"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Maria Silva", response.Contact.Name)
+	assert.Equal(t, userID, response.Contact.UserID)
+}
+
+func TestCreateContactHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CreateContactInput
+	}{
+		{"invalid email", CreateContactInput{Name: "Maria", Email: "maria at acme"}},
+		{"email with display name", CreateContactInput{Name: "Maria", Email: "Maria <maria@acme.com>"}},
+		{"not linkedin", CreateContactInput{Name: "Maria", LinkedInURL: "https://evil.example.com/in/maria"}},
+		{"linkedin lookalike", CreateContactInput{Name: "Maria", LinkedInURL: "https://notlinkedin.com/in/maria"}},
+		{"blank name", CreateContactInput{Name: "   "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			userID := uuid.New()
+			handler, _ := newTestHandler(userID, uuid.New())
+			w := httptest.NewRecorder()
+			c := jsonContext(w, "POST", "/contacts", userID, tt.input)
+
+			// Execute
+			handler.CreateContactHandler(c)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestLogInteractionHandler_LinksApplication(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(userID, appID)
+
+	contact, err := service.CreateContact(context.Background(), userID, CreateContactInput{Name: "Maria"})
+	require.NoError(t, err)
+
+	occurredAt := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "POST", "/contacts/"+contact.ID.String()+"/interactions", userID, CreateInteractionInput{
+		Type:          InteractionCall,
+		OccurredAt:    &occurredAt,
+		Summary:       "Screening call",
+		ApplicationID: &appID,
+	})
+	c.Params = gin.Params{{Key: "id", Value: contact.ID.String()}}
+
+	// Execute
+	handler.LogInteractionHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	linked, err := service.GetApplicationContacts(context.Background(), userID, appID)
+	require.NoError(t, err)
+	require.Len(t, linked, 1)
+	assert.Equal(t, contact.ID, linked[0].ID)
+	require.NotNil(t, linked[0].LastInteractionAt)
+	assert.True(t, occurredAt.Equal(*linked[0].LastInteractionAt))
+
+	detail, err := service.GetContact(context.Background(), userID, contact.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{appID}, detail.ApplicationIDs)
+}
+
+func TestLogInteractionHandler_InvalidType(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler, service := newTestHandler(userID, uuid.New())
+
+	contact, err := service.CreateContact(context.Background(), userID, CreateContactInput{Name: "Maria"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "POST", "/contacts/"+contact.ID.String()+"/interactions", userID, CreateInteractionInput{Type: "fax"})
+	c.Params = gin.Params{{Key: "id", Value: contact.ID.String()}}
+
+	// Execute
+	handler.LogInteractionHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetContactHandler_OtherUser(t *testing.T) {
+	// Setup
+	owner := uuid.New()
+	handler, service := newTestHandler(owner, uuid.New())
+
+	contact, err := service.CreateContact(context.Background(), owner, CreateContactInput{Name: "Maria"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/contacts/"+contact.ID.String(), uuid.New(), nil)
+	c.Params = gin.Params{{Key: "id", Value: contact.ID.String()}}
+
+	// Execute
+	handler.GetContactHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestLinkApplicationHandler_OtherUsersApplication(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(uuid.New(), appID)
+
+	contact, err := service.CreateContact(context.Background(), userID, CreateContactInput{Name: "Maria"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "POST", "/applications/"+appID.String()+"/contacts", userID, gin.H{"contact_id": contact.ID})
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+	// Execute
+	handler.LinkApplicationHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestUnlinkApplicationHandler_NotLinked(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(userID, appID)
+
+	contact, err := service.CreateContact(context.Background(), userID, CreateContactInput{Name: "Maria"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "DELETE", "/applications/"+appID.String()+"/contacts/"+contact.ID.String(), userID, nil)
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}, {Key: "contactID", Value: contact.ID.String()}}
+
+	// Execute
+	handler.UnlinkApplicationHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func newTestHandler(userID, appID uuid.UUID) (*GinHandler, Service) {
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID: {ID: appID, UserID: userID},
+	}}
+	service := NewService(NewMockRepository(), apps)
+	return NewGinHandler(service), service
+}
+
+func jsonContext(w *httptest.ResponseRecorder, method, path string, userID uuid.UUID, body any) *gin.Context {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+	return c
+}
+
+// Stub application service for testing, only GetApplicationByID is used
+type stubApplicationService struct {
+	application.Service
+	apps map[uuid.UUID]*application.Application
+}
+
+func (s *stubApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*application.Application, error) {
+	app, exists := s.apps[id]
+	if !exists {
+		return nil, application.ErrApplicationNotFound
+	}
+	return app, nil
+}
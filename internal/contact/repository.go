@@ -0,0 +1,29 @@
+package contact
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("contact not found")
+
+type Repository interface {
+	Create(ctx context.Context, contact *Contact) (*Contact, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Contact, error)
+	// GetUserContacts returns the user's contacts by name, only those of
+	// company when it is not empty
+	GetUserContacts(ctx context.Context, userID uuid.UUID, company string) ([]*Contact, error)
+	GetApplicationContacts(ctx context.Context, applicationID uuid.UUID) ([]*Contact, error)
+	GetApplicationIDs(ctx context.Context, contactID uuid.UUID) ([]uuid.UUID, error)
+	Update(ctx context.Context, contact *Contact) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// LinkApplication is a no-op when the link already exists
+	LinkApplication(ctx context.Context, applicationID, contactID uuid.UUID) error
+	// UnlinkApplication returns ErrNotFound when there was no link
+	UnlinkApplication(ctx context.Context, applicationID, contactID uuid.UUID) error
+	CreateInteraction(ctx context.Context, interaction *Interaction) (*Interaction, error)
+	// GetInteractions returns the interactions with a contact, newest first
+	GetInteractions(ctx context.Context, contactID uuid.UUID) ([]*Interaction, error)
+}
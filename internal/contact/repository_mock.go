@@ -0,0 +1,211 @@
+package contact
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu           sync.RWMutex
+	contacts     map[uuid.UUID]*Contact
+	links        map[uuid.UUID][]uuid.UUID // contact ID to application IDs, in link order
+	interactions map[uuid.UUID]*Interaction
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		contacts:     make(map[uuid.UUID]*Contact),
+		links:        make(map[uuid.UUID][]uuid.UUID),
+		interactions: make(map[uuid.UUID]*Interaction),
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, contact *Contact) (*Contact, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if contact.ID == uuid.Nil {
+		contact.ID = uuid.New()
+	}
+
+	now := time.Now()
+	contact.CreatedAt = now
+	contact.UpdatedAt = now
+
+	m.contacts[contact.ID] = contact
+	return contact, nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Contact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	contact, exists := m.contacts[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	found := *contact
+	return &found, nil
+}
+
+func (m *mockRepository) GetUserContacts(ctx context.Context, userID uuid.UUID, company string) ([]*Contact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	contacts := []*Contact{}
+	for _, contact := range m.contacts {
+		if contact.UserID != userID {
+			continue
+		}
+		if company != "" && !strings.EqualFold(contact.Company, company) {
+			continue
+		}
+		contacts = append(contacts, m.withLastInteraction(contact))
+	}
+
+	sortByName(contacts)
+	return contacts, nil
+}
+
+func (m *mockRepository) GetApplicationContacts(ctx context.Context, applicationID uuid.UUID) ([]*Contact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	contacts := []*Contact{}
+	for contactID, applicationIDs := range m.links {
+		for _, id := range applicationIDs {
+			if id == applicationID {
+				contacts = append(contacts, m.withLastInteraction(m.contacts[contactID]))
+				break
+			}
+		}
+	}
+
+	sortByName(contacts)
+	return contacts, nil
+}
+
+func (m *mockRepository) GetApplicationIDs(ctx context.Context, contactID uuid.UUID) ([]uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]uuid.UUID{}, m.links[contactID]...), nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, contact *Contact) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.contacts[contact.ID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	contact.UpdatedAt = time.Now()
+	existing.Name = contact.Name
+	existing.Email = contact.Email
+	existing.LinkedInURL = contact.LinkedInURL
+	existing.Role = contact.Role
+	existing.Company = contact.Company
+	existing.Notes = contact.Notes
+	existing.UpdatedAt = contact.UpdatedAt
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.contacts, id)
+	delete(m.links, id)
+	for interactionID, interaction := range m.interactions {
+		if interaction.ContactID == id {
+			delete(m.interactions, interactionID)
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) LinkApplication(ctx context.Context, applicationID, contactID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range m.links[contactID] {
+		if id == applicationID {
+			return nil
+		}
+	}
+	m.links[contactID] = append(m.links[contactID], applicationID)
+	return nil
+}
+
+func (m *mockRepository) UnlinkApplication(ctx context.Context, applicationID, contactID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, id := range m.links[contactID] {
+		if id == applicationID {
+			m.links[contactID] = append(m.links[contactID][:i], m.links[contactID][i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *mockRepository) CreateInteraction(ctx context.Context, interaction *Interaction) (*Interaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if interaction.ID == uuid.Nil {
+		interaction.ID = uuid.New()
+	}
+	interaction.CreatedAt = time.Now()
+
+	m.interactions[interaction.ID] = interaction
+	return interaction, nil
+}
+
+func (m *mockRepository) GetInteractions(ctx context.Context, contactID uuid.UUID) ([]*Interaction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	interactions := []*Interaction{}
+	for _, interaction := range m.interactions {
+		if interaction.ContactID == contactID {
+			interactions = append(interactions, interaction)
+		}
+	}
+
+	sort.Slice(interactions, func(i, j int) bool {
+		return interactions[i].OccurredAt.After(interactions[j].OccurredAt)
+	})
+	return interactions, nil
+}
+
+// withLastInteraction copies the contact with the date of its latest
+// interaction, as the list queries do. The caller holds the lock
+func (m *mockRepository) withLastInteraction(contact *Contact) *Contact {
+	listed := *contact
+	for _, interaction := range m.interactions {
+		if interaction.ContactID != contact.ID {
+			continue
+		}
+		if listed.LastInteractionAt == nil || interaction.OccurredAt.After(*listed.LastInteractionAt) {
+			occurredAt := interaction.OccurredAt
+			listed.LastInteractionAt = &occurredAt
+		}
+	}
+	return &listed
+}
+
+func sortByName(contacts []*Contact) {
+	sort.Slice(contacts, func(i, j int) bool {
+		return contacts[i].Name < contacts[j].Name
+	})
+}
@@ -0,0 +1,233 @@
+package contact
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, contact *Contact) (*Contact, error) {
+	dbContact, err := r.queries.CreateContact(ctx, database.CreateContactParams{
+		UserID:      contact.UserID,
+		Name:        contact.Name,
+		Email:       toNullString(contact.Email),
+		LinkedinUrl: toNullString(contact.LinkedInURL),
+		Role:        toNullString(contact.Role),
+		Company:     toNullString(contact.Company),
+		Notes:       toNullString(contact.Notes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbContactToContact(&dbContact), nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*Contact, error) {
+	dbContact, err := r.queries.GetContactByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbContactToContact(&dbContact), nil
+}
+
+func (r *PostgresRepository) GetUserContacts(ctx context.Context, userID uuid.UUID, company string) ([]*Contact, error) {
+	rows, err := r.queries.GetUserContacts(ctx, database.GetUserContactsParams{
+		UserID:  userID,
+		Company: toNullString(company),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*Contact, len(rows))
+	for i, row := range rows {
+		contacts[i] = contactRowToContact(row)
+	}
+	return contacts, nil
+}
+
+func (r *PostgresRepository) GetApplicationContacts(ctx context.Context, applicationID uuid.UUID) ([]*Contact, error) {
+	rows, err := r.queries.GetApplicationContacts(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*Contact, len(rows))
+	for i, row := range rows {
+		// Both queries select the same columns
+		contacts[i] = contactRowToContact(database.GetUserContactsRow(row))
+	}
+	return contacts, nil
+}
+
+func (r *PostgresRepository) GetApplicationIDs(ctx context.Context, contactID uuid.UUID) ([]uuid.UUID, error) {
+	ids, err := r.queries.GetContactApplicationIDs(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []uuid.UUID{}
+	}
+	return ids, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, contact *Contact) error {
+	dbContact, err := r.queries.UpdateContact(ctx, database.UpdateContactParams{
+		ID:          contact.ID,
+		Name:        contact.Name,
+		Email:       toNullString(contact.Email),
+		LinkedinUrl: toNullString(contact.LinkedInURL),
+		Role:        toNullString(contact.Role),
+		Company:     toNullString(contact.Company),
+		Notes:       toNullString(contact.Notes),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	contact.UpdatedAt = dbContact.UpdatedAt
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteContact(ctx, id)
+}
+
+func (r *PostgresRepository) LinkApplication(ctx context.Context, applicationID, contactID uuid.UUID) error {
+	return r.queries.LinkApplicationContact(ctx, database.LinkApplicationContactParams{
+		ApplicationID: applicationID,
+		ContactID:     contactID,
+	})
+}
+
+func (r *PostgresRepository) UnlinkApplication(ctx context.Context, applicationID, contactID uuid.UUID) error {
+	removed, err := r.queries.UnlinkApplicationContact(ctx, database.UnlinkApplicationContactParams{
+		ApplicationID: applicationID,
+		ContactID:     contactID,
+	})
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) CreateInteraction(ctx context.Context, interaction *Interaction) (*Interaction, error) {
+	dbInteraction, err := r.queries.CreateContactInteraction(ctx, database.CreateContactInteractionParams{
+		ContactID:     interaction.ContactID,
+		ApplicationID: toNullUUID(interaction.ApplicationID),
+		Type:          string(interaction.Type),
+		OccurredAt:    interaction.OccurredAt,
+		Summary:       toNullString(interaction.Summary),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbInteractionToInteraction(&dbInteraction), nil
+}
+
+func (r *PostgresRepository) GetInteractions(ctx context.Context, contactID uuid.UUID) ([]*Interaction, error) {
+	dbInteractions, err := r.queries.GetContactInteractions(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions := make([]*Interaction, len(dbInteractions))
+	for i := range dbInteractions {
+		interactions[i] = dbInteractionToInteraction(&dbInteractions[i])
+	}
+	return interactions, nil
+}
+
+// Helper functions to convert between domain and database models
+
+func dbContactToContact(dbContact *database.Contact) *Contact {
+	return &Contact{
+		ID:          dbContact.ID,
+		UserID:      dbContact.UserID,
+		Name:        dbContact.Name,
+		Email:       dbContact.Email.String,
+		LinkedInURL: dbContact.LinkedinUrl.String,
+		Role:        dbContact.Role.String,
+		Company:     dbContact.Company.String,
+		Notes:       dbContact.Notes.String,
+		CreatedAt:   dbContact.CreatedAt,
+		UpdatedAt:   dbContact.UpdatedAt,
+	}
+}
+
+// contactRowToContact converts a contact listed with its last interaction
+func contactRowToContact(row database.GetUserContactsRow) *Contact {
+	contact := dbContactToContact(&database.Contact{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Name:        row.Name,
+		Email:       row.Email,
+		LinkedinUrl: row.LinkedinUrl,
+		Role:        row.Role,
+		Company:     row.Company,
+		Notes:       row.Notes,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	})
+	contact.LastInteractionAt = fromNullTime(row.LastInteractionAt)
+	return contact
+}
+
+func dbInteractionToInteraction(dbInteraction *database.ContactInteraction) *Interaction {
+	interaction := &Interaction{
+		ID:         dbInteraction.ID,
+		ContactID:  dbInteraction.ContactID,
+		Type:       InteractionType(dbInteraction.Type),
+		OccurredAt: dbInteraction.OccurredAt,
+		Summary:    dbInteraction.Summary.String,
+		CreatedAt:  dbInteraction.CreatedAt,
+	}
+	if dbInteraction.ApplicationID.Valid {
+		interaction.ApplicationID = &dbInteraction.ApplicationID.UUID
+	}
+	return interaction
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,304 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+)
+
+var (
+	ErrContactNotFound        = errors.New("contact not found")
+	ErrApplicationNotFound    = errors.New("application not found")
+	ErrLinkNotFound           = errors.New("contact is not linked to this application")
+	ErrForbidden              = errors.New("resource belongs to another user")
+	ErrMissingName            = errors.New("name is required")
+	ErrInvalidEmail           = errors.New("invalid email address")
+	ErrInvalidLinkedInURL     = errors.New("linkedin_url must be an http(s) linkedin.com address")
+	ErrInvalidInteractionType = errors.New("invalid interaction type")
+	ErrInteractionInTheFuture = errors.New("occurred_at cannot be in the future")
+)
+
+// futureTolerance absorbs clock skew between clients and the server when
+// checking that an interaction already happened
+const futureTolerance = 5 * time.Minute
+
+type Service interface {
+	CreateContact(ctx context.Context, userID uuid.UUID, input CreateContactInput) (*Contact, error)
+	GetUserContacts(ctx context.Context, userID uuid.UUID, company string) ([]*Contact, error)
+	GetContact(ctx context.Context, userID, id uuid.UUID) (*Contact, error)
+	UpdateContact(ctx context.Context, userID, id uuid.UUID, input UpdateContactInput) (*Contact, error)
+	DeleteContact(ctx context.Context, userID, id uuid.UUID) error
+	GetApplicationContacts(ctx context.Context, userID, applicationID uuid.UUID) ([]*Contact, error)
+	LinkApplication(ctx context.Context, userID, applicationID, contactID uuid.UUID) error
+	UnlinkApplication(ctx context.Context, userID, applicationID, contactID uuid.UUID) error
+	LogInteraction(ctx context.Context, userID, contactID uuid.UUID, input CreateInteractionInput) (*Interaction, error)
+	GetInteractions(ctx context.Context, userID, contactID uuid.UUID) ([]*Interaction, error)
+}
+
+type service struct {
+	repo       Repository
+	appService application.Service
+}
+
+func NewService(repo Repository, appService application.Service) Service {
+	return &service{
+		repo:       repo,
+		appService: appService,
+	}
+}
+
+func (s *service) CreateContact(ctx context.Context, userID uuid.UUID, input CreateContactInput) (*Contact, error) {
+	contact := &Contact{
+		UserID:      userID,
+		Name:        strings.TrimSpace(input.Name),
+		Email:       strings.TrimSpace(input.Email),
+		LinkedInURL: strings.TrimSpace(input.LinkedInURL),
+		Role:        strings.TrimSpace(input.Role),
+		Company:     strings.TrimSpace(input.Company),
+		Notes:       input.Notes,
+	}
+
+	if err := validateContact(contact); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, contact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create contact: %w", err)
+	}
+	return created, nil
+}
+
+func (s *service) GetUserContacts(ctx context.Context, userID uuid.UUID, company string) ([]*Contact, error) {
+	contacts, err := s.repo.GetUserContacts(ctx, userID, strings.TrimSpace(company))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+// GetContact returns the contact with the applications it is linked to
+func (s *service) GetContact(ctx context.Context, userID, id uuid.UUID) (*Contact, error) {
+	contact, err := s.getOwnedContact(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contact.ApplicationIDs, err = s.repo.GetApplicationIDs(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact applications: %w", err)
+	}
+	return contact, nil
+}
+
+func (s *service) UpdateContact(ctx context.Context, userID, id uuid.UUID, input UpdateContactInput) (*Contact, error) {
+	contact, err := s.getOwnedContact(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		contact.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Email != nil {
+		contact.Email = strings.TrimSpace(*input.Email)
+	}
+	if input.LinkedInURL != nil {
+		contact.LinkedInURL = strings.TrimSpace(*input.LinkedInURL)
+	}
+	if input.Role != nil {
+		contact.Role = strings.TrimSpace(*input.Role)
+	}
+	if input.Company != nil {
+		contact.Company = strings.TrimSpace(*input.Company)
+	}
+	if input.Notes != nil {
+		contact.Notes = *input.Notes
+	}
+
+	if err := validateContact(contact); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, contact); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to update contact: %w", err)
+	}
+	return contact, nil
+}
+
+func (s *service) DeleteContact(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.getOwnedContact(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+	return nil
+}
+
+func (s *service) GetApplicationContacts(ctx context.Context, userID, applicationID uuid.UUID) ([]*Contact, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	contacts, err := s.repo.GetApplicationContacts(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+// LinkApplication records that the contact takes part in the application.
+// Linking twice is not an error
+func (s *service) LinkApplication(ctx context.Context, userID, applicationID, contactID uuid.UUID) error {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return err
+	}
+	if _, err := s.getOwnedContact(ctx, userID, contactID); err != nil {
+		return err
+	}
+
+	if err := s.repo.LinkApplication(ctx, applicationID, contactID); err != nil {
+		return fmt.Errorf("failed to link contact: %w", err)
+	}
+	return nil
+}
+
+func (s *service) UnlinkApplication(ctx context.Context, userID, applicationID, contactID uuid.UUID) error {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return err
+	}
+	if _, err := s.getOwnedContact(ctx, userID, contactID); err != nil {
+		return err
+	}
+
+	if err := s.repo.UnlinkApplication(ctx, applicationID, contactID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrLinkNotFound
+		}
+		return fmt.Errorf("failed to unlink contact: %w", err)
+	}
+	return nil
+}
+
+// LogInteraction adds an entry to the contact's log. An interaction about
+// an application also links the contact to it
+func (s *service) LogInteraction(ctx context.Context, userID, contactID uuid.UUID, input CreateInteractionInput) (*Interaction, error) {
+	if _, err := s.getOwnedContact(ctx, userID, contactID); err != nil {
+		return nil, err
+	}
+
+	if !input.Type.IsValid() {
+		return nil, ErrInvalidInteractionType
+	}
+
+	occurredAt := time.Now()
+	if input.OccurredAt != nil {
+		if input.OccurredAt.After(occurredAt.Add(futureTolerance)) {
+			return nil, ErrInteractionInTheFuture
+		}
+		occurredAt = *input.OccurredAt
+	}
+
+	if input.ApplicationID != nil {
+		if err := s.authorize(ctx, userID, *input.ApplicationID); err != nil {
+			return nil, err
+		}
+		if err := s.repo.LinkApplication(ctx, *input.ApplicationID, contactID); err != nil {
+			return nil, fmt.Errorf("failed to link contact: %w", err)
+		}
+	}
+
+	interaction, err := s.repo.CreateInteraction(ctx, &Interaction{
+		ContactID:     contactID,
+		ApplicationID: input.ApplicationID,
+		Type:          input.Type,
+		OccurredAt:    occurredAt,
+		Summary:       strings.TrimSpace(input.Summary),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to log interaction: %w", err)
+	}
+	return interaction, nil
+}
+
+func (s *service) GetInteractions(ctx context.Context, userID, contactID uuid.UUID) ([]*Interaction, error) {
+	if _, err := s.getOwnedContact(ctx, userID, contactID); err != nil {
+		return nil, err
+	}
+
+	interactions, err := s.repo.GetInteractions(ctx, contactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interactions: %w", err)
+	}
+	return interactions, nil
+}
+
+func (s *service) getOwnedContact(ctx context.Context, userID, id uuid.UUID) (*Contact, error) {
+	contact, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	if !contact.IsOwnedBy(userID) {
+		return nil, ErrForbidden
+	}
+	return contact, nil
+}
+
+// authorize makes sure the application exists and belongs to the user
+func (s *service) authorize(ctx context.Context, userID, applicationID uuid.UUID) error {
+	app, err := s.appService.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, application.ErrApplicationNotFound) {
+			return ErrApplicationNotFound
+		}
+		return err
+	}
+
+	if app.UserID != userID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func validateContact(contact *Contact) error {
+	if contact.Name == "" {
+		return ErrMissingName
+	}
+
+	if contact.Email != "" {
+		addr, err := mail.ParseAddress(contact.Email)
+		if err != nil || addr.Address != contact.Email {
+			return ErrInvalidEmail
+		}
+	}
+
+	if contact.LinkedInURL != "" {
+		u, err := url.Parse(contact.LinkedInURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return ErrInvalidLinkedInURL
+		}
+		host := strings.ToLower(u.Hostname())
+		if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+			return ErrInvalidLinkedInURL
+		}
+	}
+
+	return nil
+}
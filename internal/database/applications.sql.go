@@ -8,29 +8,47 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createApplication = `-- name: CreateApplication :one
 INSERT INTO applications (
   user_id,
   job_id,
-  notes
+  status,
+  stage_id,
+  applied_at,
+  ghosted_at,
+  first_response_at
 )
-VALUES ($1, $2, $3)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, user_id, job_id, status, applied_at, updated_at, 
-          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 `
 
 type CreateApplicationParams struct {
-	UserID uuid.UUID      `json:"user_id"`
-	JobID  uuid.UUID      `json:"job_id"`
-	Notes  sql.NullString `json:"notes"`
+	UserID          uuid.UUID    `json:"user_id"`
+	JobID           uuid.UUID    `json:"job_id"`
+	Status          string       `json:"status"`
+	StageID         uuid.UUID    `json:"stage_id"`
+	AppliedAt       time.Time    `json:"applied_at"`
+	GhostedAt       sql.NullTime `json:"ghosted_at"`
+	FirstResponseAt sql.NullTime `json:"first_response_at"`
 }
 
 func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationParams) (Application, error) {
-	row := q.db.QueryRowContext(ctx, createApplication, arg.UserID, arg.JobID, arg.Notes)
+	row := q.db.QueryRowContext(ctx, createApplication,
+		arg.UserID,
+		arg.JobID,
+		arg.Status,
+		arg.StageID,
+		arg.AppliedAt,
+		arg.GhostedAt,
+		arg.FirstResponseAt,
+	)
 	var i Application
 	err := row.Scan(
 		&i.ID,
@@ -45,6 +63,9 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 		&i.SalaryOffer,
 		&i.ReminderSent,
 		&i.FollowUpDate,
+		&i.StageID,
+		&i.GhostedAt,
+		&i.FirstResponseAt,
 	)
 	return i, err
 }
@@ -60,7 +81,7 @@ func (q *Queries) DeleteApplication(ctx context.Context, id uuid.UUID) error {
 
 const getApplicationByID = `-- name: GetApplicationByID :one
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE id = $1
 `
@@ -81,13 +102,16 @@ func (q *Queries) GetApplicationByID(ctx context.Context, id uuid.UUID) (Applica
 		&i.SalaryOffer,
 		&i.ReminderSent,
 		&i.FollowUpDate,
+		&i.StageID,
+		&i.GhostedAt,
+		&i.FirstResponseAt,
 	)
 	return i, err
 }
 
 const getApplicationByUserAndJob = `-- name: GetApplicationByUserAndJob :one
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE user_id = $1 AND job_id = $2
 `
@@ -113,13 +137,16 @@ func (q *Queries) GetApplicationByUserAndJob(ctx context.Context, arg GetApplica
 		&i.SalaryOffer,
 		&i.ReminderSent,
 		&i.FollowUpDate,
+		&i.StageID,
+		&i.GhostedAt,
+		&i.FirstResponseAt,
 	)
 	return i, err
 }
 
 const getApplicationsByStatus = `-- name: GetApplicationsByStatus :many
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE user_id = $1 AND status = $2
 ORDER BY applied_at DESC
@@ -152,6 +179,9 @@ func (q *Queries) GetApplicationsByStatus(ctx context.Context, arg GetApplicatio
 			&i.SalaryOffer,
 			&i.ReminderSent,
 			&i.FollowUpDate,
+			&i.StageID,
+			&i.GhostedAt,
+			&i.FirstResponseAt,
 		); err != nil {
 			return nil, err
 		}
@@ -168,7 +198,7 @@ func (q *Queries) GetApplicationsByStatus(ctx context.Context, arg GetApplicatio
 
 const getJobApplications = `-- name: GetJobApplications :many
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE job_id = $1
 ORDER BY applied_at DESC
@@ -196,6 +226,9 @@ func (q *Queries) GetJobApplications(ctx context.Context, jobID uuid.UUID) ([]Ap
 			&i.SalaryOffer,
 			&i.ReminderSent,
 			&i.FollowUpDate,
+			&i.StageID,
+			&i.GhostedAt,
+			&i.FirstResponseAt,
 		); err != nil {
 			return nil, err
 		}
@@ -210,18 +243,16 @@ func (q *Queries) GetJobApplications(ctx context.Context, jobID uuid.UUID) ([]Ap
 	return items, nil
 }
 
-const getPendingReminders = `-- name: GetPendingReminders :many
+const getUserApplications = `-- name: GetUserApplications :many
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
-WHERE reminder_sent = false
-  AND interview_date IS NOT NULL
-  AND interview_date <= NOW() + INTERVAL '24 hours'
-  AND interview_date >= NOW()
+WHERE user_id = $1
+ORDER BY applied_at DESC
 `
 
-func (q *Queries) GetPendingReminders(ctx context.Context) ([]Application, error) {
-	rows, err := q.db.QueryContext(ctx, getPendingReminders)
+func (q *Queries) GetUserApplications(ctx context.Context, userID uuid.UUID) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getUserApplications, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -242,6 +273,9 @@ func (q *Queries) GetPendingReminders(ctx context.Context) ([]Application, error
 			&i.SalaryOffer,
 			&i.ReminderSent,
 			&i.FollowUpDate,
+			&i.StageID,
+			&i.GhostedAt,
+			&i.FirstResponseAt,
 		); err != nil {
 			return nil, err
 		}
@@ -256,39 +290,75 @@ func (q *Queries) GetPendingReminders(ctx context.Context) ([]Application, error
 	return items, nil
 }
 
-const getUpcomingInterviews = `-- name: GetUpcomingInterviews :many
-SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
-FROM applications
-WHERE user_id = $1 
-  AND interview_date IS NOT NULL 
-  AND interview_date >= NOW()
-  AND status = 'interviewing'
-ORDER BY interview_date ASC
+const listApplicationsForExport = `-- name: ListApplicationsForExport :many
+SELECT a.id, a.status, a.applied_at, a.updated_at, a.interview_date, a.offer_date,
+       a.follow_up_date, a.notes, a.salary_offer,
+       j.title AS job_title, j.company AS job_company, j.location AS job_location,
+       j.link AS job_link, j.salary_range AS job_salary_range
+FROM applications a
+JOIN jobs j ON j.id = a.job_id
+WHERE a.user_id = $1
+  AND ($2::text[] IS NULL OR a.status = ANY($2::text[]))
+  AND ($3::timestamptz IS NULL OR a.applied_at >= $3)
+  AND ($4::timestamptz IS NULL OR a.applied_at < $4)
+ORDER BY a.applied_at DESC
 `
 
-func (q *Queries) GetUpcomingInterviews(ctx context.Context, userID uuid.UUID) ([]Application, error) {
-	rows, err := q.db.QueryContext(ctx, getUpcomingInterviews, userID)
+type ListApplicationsForExportParams struct {
+	UserID      uuid.UUID    `json:"user_id"`
+	Statuses    []string     `json:"statuses"`
+	AppliedFrom sql.NullTime `json:"applied_from"`
+	AppliedTo   sql.NullTime `json:"applied_to"`
+}
+
+type ListApplicationsForExportRow struct {
+	ID             uuid.UUID      `json:"id"`
+	Status         string         `json:"status"`
+	AppliedAt      time.Time      `json:"applied_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	InterviewDate  sql.NullTime   `json:"interview_date"`
+	OfferDate      sql.NullTime   `json:"offer_date"`
+	FollowUpDate   sql.NullTime   `json:"follow_up_date"`
+	Notes          sql.NullString `json:"notes"`
+	SalaryOffer    sql.NullString `json:"salary_offer"`
+	JobTitle       string         `json:"job_title"`
+	JobCompany     string         `json:"job_company"`
+	JobLocation    string         `json:"job_location"`
+	JobLink        string         `json:"job_link"`
+	JobSalaryRange sql.NullString `json:"job_salary_range"`
+}
+
+// Applications of a user joined with their job, optionally filtered by
+// status and by an applied_at range [applied_from, applied_to)
+func (q *Queries) ListApplicationsForExport(ctx context.Context, arg ListApplicationsForExportParams) ([]ListApplicationsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, listApplicationsForExport,
+		arg.UserID,
+		pq.Array(arg.Statuses),
+		arg.AppliedFrom,
+		arg.AppliedTo,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Application
+	var items []ListApplicationsForExportRow
 	for rows.Next() {
-		var i Application
+		var i ListApplicationsForExportRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.JobID,
 			&i.Status,
 			&i.AppliedAt,
 			&i.UpdatedAt,
 			&i.InterviewDate,
 			&i.OfferDate,
+			&i.FollowUpDate,
 			&i.Notes,
 			&i.SalaryOffer,
-			&i.ReminderSent,
-			&i.FollowUpDate,
+			&i.JobTitle,
+			&i.JobCompany,
+			&i.JobLocation,
+			&i.JobLink,
+			&i.JobSalaryRange,
 		); err != nil {
 			return nil, err
 		}
@@ -303,23 +373,75 @@ func (q *Queries) GetUpcomingInterviews(ctx context.Context, userID uuid.UUID) (
 	return items, nil
 }
 
-const getUserApplications = `-- name: GetUserApplications :many
-SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
-FROM applications
-WHERE user_id = $1
-ORDER BY applied_at DESC
+const markGhostedApplications = `-- name: MarkGhostedApplications :many
+UPDATE applications a
+SET
+  status = g.key,
+  stage_id = g.id,
+  ghosted_at = NOW(),
+  updated_at = NOW()
+FROM pipeline_stages cur
+JOIN pipeline_stages g ON g.pipeline_id = cur.pipeline_id AND g.key = 'ghosted'
+JOIN pipeline_transitions t ON t.from_stage_id = cur.id AND t.to_stage_id = g.id
+WHERE cur.id = a.stage_id
+  AND a.status IN ('applied', 'interviewing')
+  AND a.updated_at < $1
+  AND NOT EXISTS (
+    SELECT 1 FROM interviews i
+    WHERE i.application_id = a.id
+      AND (i.scheduled_at >= $1 OR i.updated_at >= $1)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM notes n
+    WHERE n.application_id = a.id AND n.updated_at >= $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM offers o
+    WHERE o.application_id = a.id AND o.updated_at >= $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM contact_interactions ci
+    WHERE ci.application_id = a.id AND (ci.occurred_at >= $1 OR ci.created_at >= $1)
+  )
+RETURNING a.id, a.user_id, a.job_id, a.status, a.applied_at, a.updated_at,
+          a.interview_date, a.offer_date, a.notes, a.salary_offer, a.reminder_sent, a.follow_up_date, a.stage_id, a.ghosted_at, a.first_response_at,
+          cur.key AS previous_status
 `
 
-func (q *Queries) GetUserApplications(ctx context.Context, userID uuid.UUID) ([]Application, error) {
-	rows, err := q.db.QueryContext(ctx, getUserApplications, userID)
+type MarkGhostedApplicationsRow struct {
+	ID              uuid.UUID      `json:"id"`
+	UserID          uuid.UUID      `json:"user_id"`
+	JobID           uuid.UUID      `json:"job_id"`
+	Status          string         `json:"status"`
+	AppliedAt       time.Time      `json:"applied_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	InterviewDate   sql.NullTime   `json:"interview_date"`
+	OfferDate       sql.NullTime   `json:"offer_date"`
+	Notes           sql.NullString `json:"notes"`
+	SalaryOffer     sql.NullString `json:"salary_offer"`
+	ReminderSent    bool           `json:"reminder_sent"`
+	FollowUpDate    sql.NullTime   `json:"follow_up_date"`
+	StageID         uuid.UUID      `json:"stage_id"`
+	GhostedAt       sql.NullTime   `json:"ghosted_at"`
+	FirstResponseAt sql.NullTime   `json:"first_response_at"`
+	PreviousStatus  string         `json:"previous_status"`
+}
+
+// Moves applications with no activity since the cutoff into the ghosted
+// stage of their pipeline, when the pipeline has one and allows the
+// transition. Interviews, notes, offers and contact interactions do not
+// touch applications.updated_at, so they are checked here: an interview
+// still to come or anything written since the cutoff keeps the application.
+// previous_status is the key of the stage the application left
+func (q *Queries) MarkGhostedApplications(ctx context.Context, updatedAt time.Time) ([]MarkGhostedApplicationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, markGhostedApplications, updatedAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Application
+	var items []MarkGhostedApplicationsRow
 	for rows.Next() {
-		var i Application
+		var i MarkGhostedApplicationsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -333,6 +455,10 @@ func (q *Queries) GetUserApplications(ctx context.Context, userID uuid.UUID) ([]
 			&i.SalaryOffer,
 			&i.ReminderSent,
 			&i.FollowUpDate,
+			&i.StageID,
+			&i.GhostedAt,
+			&i.FirstResponseAt,
+			&i.PreviousStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -347,37 +473,23 @@ func (q *Queries) GetUserApplications(ctx context.Context, userID uuid.UUID) ([]
 	return items, nil
 }
 
-const markReminderSent = `-- name: MarkReminderSent :exec
-UPDATE applications
-SET reminder_sent = true, updated_at = NOW()
-WHERE id = $1
-`
-
-func (q *Queries) MarkReminderSent(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, markReminderSent, id)
-	return err
-}
-
 const updateApplication = `-- name: UpdateApplication :one
 UPDATE applications
 SET
-  interview_date = COALESCE($2, interview_date),
-  offer_date = COALESCE($3, offer_date),
-  notes = COALESCE($4, notes),
-  salary_offer = COALESCE($5, salary_offer),
-  reminder_sent = COALESCE($6, reminder_sent),
-  follow_up_date = COALESCE($7, follow_up_date),
+  offer_date = COALESCE($2, offer_date),
+  salary_offer = COALESCE($3, salary_offer),
+  reminder_sent = COALESCE($4, reminder_sent),
+  follow_up_date = COALESCE($5, follow_up_date),
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, user_id, job_id, status, applied_at, updated_at, 
-          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 `
 
 type UpdateApplicationParams struct {
 	ID            uuid.UUID      `json:"id"`
 	InterviewDate sql.NullTime   `json:"interview_date"`
 	OfferDate     sql.NullTime   `json:"offer_date"`
-	Notes         sql.NullString `json:"notes"`
 	SalaryOffer   sql.NullString `json:"salary_offer"`
 	ReminderSent  sql.NullBool   `json:"reminder_sent"`
 	FollowUpDate  sql.NullTime   `json:"follow_up_date"`
@@ -388,7 +500,6 @@ func (q *Queries) UpdateApplication(ctx context.Context, arg UpdateApplicationPa
 		arg.ID,
 		arg.InterviewDate,
 		arg.OfferDate,
-		arg.Notes,
 		arg.SalaryOffer,
 		arg.ReminderSent,
 		arg.FollowUpDate,
@@ -407,6 +518,9 @@ func (q *Queries) UpdateApplication(ctx context.Context, arg UpdateApplicationPa
 		&i.SalaryOffer,
 		&i.ReminderSent,
 		&i.FollowUpDate,
+		&i.StageID,
+		&i.GhostedAt,
+		&i.FirstResponseAt,
 	)
 	return i, err
 }
@@ -415,19 +529,26 @@ const updateApplicationStatus = `-- name: UpdateApplicationStatus :one
 UPDATE applications
 SET
   status = $2,
+  stage_id = $3,
+  ghosted_at = CASE WHEN $2 = 'ghosted' THEN COALESCE(ghosted_at, NOW()) ELSE NULL END,
+  first_response_at = CASE
+    WHEN $2 IN ('applied', 'withdrawn', 'ghosted') THEN first_response_at
+    ELSE COALESCE(first_response_at, NOW())
+  END,
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, user_id, job_id, status, applied_at, updated_at, 
-          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 `
 
 type UpdateApplicationStatusParams struct {
-	ID     uuid.UUID `json:"id"`
-	Status string    `json:"status"`
+	ID      uuid.UUID `json:"id"`
+	Status  string    `json:"status"`
+	StageID uuid.UUID `json:"stage_id"`
 }
 
 func (q *Queries) UpdateApplicationStatus(ctx context.Context, arg UpdateApplicationStatusParams) (Application, error) {
-	row := q.db.QueryRowContext(ctx, updateApplicationStatus, arg.ID, arg.Status)
+	row := q.db.QueryRowContext(ctx, updateApplicationStatus, arg.ID, arg.Status, arg.StageID)
 	var i Application
 	err := row.Scan(
 		&i.ID,
@@ -442,6 +563,9 @@ func (q *Queries) UpdateApplicationStatus(ctx context.Context, arg UpdateApplica
 		&i.SalaryOffer,
 		&i.ReminderSent,
 		&i.FollowUpDate,
+		&i.StageID,
+		&i.GhostedAt,
+		&i.FirstResponseAt,
 	)
 	return i, err
 }
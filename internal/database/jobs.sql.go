@@ -55,11 +55,12 @@ INSERT INTO jobs (
   requirements, 
   source, 
   link, 
-  posted_date
+  posted_date,
+  created_by
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id, title, company, location, description, salary_range, requirements, 
-          source, link, posted_date, scraped_at, is_active, created_at, updated_at
+          source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 `
 
 type CreateJobParams struct {
@@ -72,6 +73,7 @@ type CreateJobParams struct {
 	Source       string         `json:"source"`
 	Link         string         `json:"link"`
 	PostedDate   time.Time      `json:"posted_date"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
 }
 
 func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, error) {
@@ -85,6 +87,7 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		arg.Source,
 		arg.Link,
 		arg.PostedDate,
+		arg.CreatedBy,
 	)
 	var i Job
 	err := row.Scan(
@@ -102,6 +105,7 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
 	)
 	return i, err
 }
@@ -117,7 +121,7 @@ func (q *Queries) DeleteJob(ctx context.Context, id uuid.UUID) error {
 
 const getJobByID = `-- name: GetJobByID :one
 SELECT id, title, company, location, description, salary_range, requirements, 
-       source, link, posted_date, scraped_at, is_active, created_at, updated_at
+       source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 FROM jobs
 WHERE id = $1
 `
@@ -140,13 +144,14 @@ func (q *Queries) GetJobByID(ctx context.Context, id uuid.UUID) (Job, error) {
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
 	)
 	return i, err
 }
 
 const getJobByLink = `-- name: GetJobByLink :one
 SELECT id, title, company, location, description, salary_range, requirements, 
-       source, link, posted_date, scraped_at, is_active, created_at, updated_at
+       source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 FROM jobs
 WHERE link = $1
 `
@@ -169,13 +174,14 @@ func (q *Queries) GetJobByLink(ctx context.Context, link string) (Job, error) {
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
 	)
 	return i, err
 }
 
 const listJobs = `-- name: ListJobs :many
 SELECT id, title, company, location, description, salary_range, requirements, 
-       source, link, posted_date, scraped_at, is_active, created_at, updated_at
+       source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 FROM jobs
 WHERE 
   ($3::TEXT IS NULL OR title ILIKE '%' || $3::TEXT || '%')
@@ -229,6 +235,7 @@ func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, erro
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CreatedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -259,7 +266,7 @@ SET
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, title, company, location, description, salary_range, requirements, 
-          source, link, posted_date, scraped_at, is_active, created_at, updated_at
+          source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 `
 
 type UpdateJobParams struct {
@@ -306,6 +313,7 @@ func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (Job, erro
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CreatedBy,
 	)
 	return i, err
 }
@@ -11,19 +11,113 @@ import (
 	"github.com/google/uuid"
 )
 
+type AccountDeletion struct {
+	UserID       uuid.UUID `json:"user_id"`
+	RequestedAt  time.Time `json:"requested_at"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+type ApiKey struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"key_hash"`
+	Scopes     []string     `json:"scopes"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
 type Application struct {
+	ID              uuid.UUID      `json:"id"`
+	UserID          uuid.UUID      `json:"user_id"`
+	JobID           uuid.UUID      `json:"job_id"`
+	Status          string         `json:"status"`
+	AppliedAt       time.Time      `json:"applied_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	InterviewDate   sql.NullTime   `json:"interview_date"`
+	OfferDate       sql.NullTime   `json:"offer_date"`
+	Notes           sql.NullString `json:"notes"`
+	SalaryOffer     sql.NullString `json:"salary_offer"`
+	ReminderSent    bool           `json:"reminder_sent"`
+	FollowUpDate    sql.NullTime   `json:"follow_up_date"`
+	StageID         uuid.UUID      `json:"stage_id"`
+	GhostedAt       sql.NullTime   `json:"ghosted_at"`
+	FirstResponseAt sql.NullTime   `json:"first_response_at"`
+}
+
+type ApplicationBoardPosition struct {
+	ApplicationID uuid.UUID `json:"application_id"`
+	Status        string    `json:"status"`
+	Position      int32     `json:"position"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ApplicationContact struct {
+	ApplicationID uuid.UUID `json:"application_id"`
+	ContactID     uuid.UUID `json:"contact_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Attachment struct {
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Kind          string    `json:"kind"`
+	Filename      string    `json:"filename"`
+	ContentType   string    `json:"content_type"`
+	SizeBytes     int64     `json:"size_bytes"`
+	Checksum      string    `json:"checksum"`
+	StorageKey    string    `json:"storage_key"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type CalendarFeed struct {
+	UserID         uuid.UUID    `json:"user_id"`
+	TokenHash      string       `json:"token_hash"`
+	CreatedAt      time.Time    `json:"created_at"`
+	LastAccessedAt sql.NullTime `json:"last_accessed_at"`
+}
+
+type Contact struct {
+	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	Name        string         `json:"name"`
+	Email       sql.NullString `json:"email"`
+	LinkedinUrl sql.NullString `json:"linkedin_url"`
+	Role        sql.NullString `json:"role"`
+	Company     sql.NullString `json:"company"`
+	Notes       sql.NullString `json:"notes"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type ContactInteraction struct {
 	ID            uuid.UUID      `json:"id"`
-	UserID        uuid.UUID      `json:"user_id"`
-	JobID         uuid.UUID      `json:"job_id"`
-	Status        string         `json:"status"`
-	AppliedAt     time.Time      `json:"applied_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	InterviewDate sql.NullTime   `json:"interview_date"`
-	OfferDate     sql.NullTime   `json:"offer_date"`
-	Notes         sql.NullString `json:"notes"`
-	SalaryOffer   sql.NullString `json:"salary_offer"`
-	ReminderSent  bool           `json:"reminder_sent"`
-	FollowUpDate  sql.NullTime   `json:"follow_up_date"`
+	ContactID     uuid.UUID      `json:"contact_id"`
+	ApplicationID uuid.NullUUID  `json:"application_id"`
+	Type          string         `json:"type"`
+	OccurredAt    time.Time      `json:"occurred_at"`
+	Summary       sql.NullString `json:"summary"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type Interview struct {
+	ID              uuid.UUID      `json:"id"`
+	ApplicationID   uuid.UUID      `json:"application_id"`
+	RoundName       string         `json:"round_name"`
+	Type            string         `json:"type"`
+	ScheduledAt     time.Time      `json:"scheduled_at"`
+	DurationMinutes int32          `json:"duration_minutes"`
+	Interviewers    []string       `json:"interviewers"`
+	Location        sql.NullString `json:"location"`
+	MeetingLink     sql.NullString `json:"meeting_link"`
+	Outcome         string         `json:"outcome"`
+	Notes           sql.NullString `json:"notes"`
+	ReminderSent    bool           `json:"reminder_sent"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
 type Job struct {
@@ -41,14 +135,186 @@ type Job struct {
 	IsActive     bool           `json:"is_active"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
 }
 
-type User struct {
-	ID           uuid.UUID `json:"id"`
-	Name         string    `json:"name"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"password_hash"`
-	Role         string    `json:"role"`
+type LoginEvent struct {
+	ID        uuid.UUID      `json:"id"`
+	UserID    uuid.NullUUID  `json:"user_id"`
+	Email     string         `json:"email"`
+	IpAddress sql.NullString `json:"ip_address"`
+	UserAgent sql.NullString `json:"user_agent"`
+	Event     string         `json:"event"`
+	Reason    sql.NullString `json:"reason"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type LoginThrottle struct {
+	Key           string       `json:"key"`
+	Failures      int32        `json:"failures"`
+	LastFailureAt time.Time    `json:"last_failure_at"`
+	LockedUntil   sql.NullTime `json:"locked_until"`
+}
+
+type Note struct {
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type Offer struct {
+	ID                 uuid.UUID      `json:"id"`
+	ApplicationID      uuid.UUID      `json:"application_id"`
+	UserID             uuid.UUID      `json:"user_id"`
+	Currency           string         `json:"currency"`
+	BaseSalary         int64          `json:"base_salary"`
+	PayPeriod          string         `json:"pay_period"`
+	Bonus              int64          `json:"bonus"`
+	SigningBonus       int64          `json:"signing_bonus"`
+	EquityValue        int64          `json:"equity_value"`
+	EquityVestingYears int32          `json:"equity_vesting_years"`
+	Benefits           []string       `json:"benefits"`
+	BenefitsValue      int64          `json:"benefits_value"`
+	RespondBy          sql.NullTime   `json:"respond_by"`
+	Location           sql.NullString `json:"location"`
+	WorkMode           sql.NullString `json:"work_mode"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+type OidcLoginState struct {
+	StateHash    string    `json:"state_hash"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at"`
 	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type Pipeline struct {
+	ID        uuid.UUID     `json:"id"`
+	UserID    uuid.NullUUID `json:"user_id"`
+	Name      string        `json:"name"`
+	IsDefault bool          `json:"is_default"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type PipelineStage struct {
+	ID         uuid.UUID `json:"id"`
+	PipelineID uuid.UUID `json:"pipeline_id"`
+	Key        string    `json:"key"`
+	Name       string    `json:"name"`
+	Position   int32     `json:"position"`
+	IsTerminal bool      `json:"is_terminal"`
+	Kind       string    `json:"kind"`
+}
+
+type PipelineTransition struct {
+	PipelineID  uuid.UUID `json:"pipeline_id"`
+	FromStageID uuid.UUID `json:"from_stage_id"`
+	ToStageID   uuid.UUID `json:"to_stage_id"`
+}
+
+type ReminderDelivery struct {
+	Kind      string       `json:"kind"`
+	SourceID  uuid.UUID    `json:"source_id"`
+	DueAt     time.Time    `json:"due_at"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Status    string       `json:"status"`
+	Attempts  int32        `json:"attempts"`
+	ClaimedAt sql.NullTime `json:"claimed_at"`
+	SentAt    sql.NullTime `json:"sent_at"`
+}
+
+type Session struct {
+	ID        uuid.UUID      `json:"id"`
+	FamilyID  uuid.UUID      `json:"family_id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	TokenHash string         `json:"token_hash"`
+	UserAgent sql.NullString `json:"user_agent"`
+	IpAddress sql.NullString `json:"ip_address"`
+	CreatedAt time.Time      `json:"created_at"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	RotatedAt sql.NullTime   `json:"rotated_at"`
+	RevokedAt sql.NullTime   `json:"revoked_at"`
+}
+
+type User struct {
+	ID                 uuid.UUID    `json:"id"`
+	Name               string       `json:"name"`
+	Email              string       `json:"email"`
+	PasswordHash       string       `json:"password_hash"`
+	Role               string       `json:"role"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	Locale             string       `json:"locale"`
+	MutedNotifications []string     `json:"muted_notifications"`
+	EmailVerifiedAt    sql.NullTime `json:"email_verified_at"`
+}
+
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type UserToken struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Purpose   string       `json:"purpose"`
+	TokenHash string       `json:"token_hash"`
+	Email     string       `json:"email"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+}
+
+type UserTotp struct {
+	UserID       uuid.UUID    `json:"user_id"`
+	Secret       string       `json:"secret"`
+	LastUsedStep int64        `json:"last_used_step"`
+	EnabledAt    sql.NullTime `json:"enabled_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID      `json:"id"`
+	EndpointID     uuid.UUID      `json:"endpoint_id"`
+	EventID        uuid.UUID      `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	Payload        string         `json:"payload"`
+	Status         string         `json:"status"`
+	Attempts       int32          `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime   `json:"last_attempt_at"`
+	ResponseStatus sql.NullInt32  `json:"response_status"`
+	LastError      sql.NullString `json:"last_error"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CompletedAt    sql.NullTime   `json:"completed_at"`
+}
+
+type WebhookEndpoint struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Url        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
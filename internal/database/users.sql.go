@@ -10,12 +10,13 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (name, email, password_hash, role)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, email, password_hash, role, created_at, updated_at
+RETURNING id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at
 `
 
 type CreateUserParams struct {
@@ -41,6 +42,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
+		pq.Array(&i.MutedNotifications),
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -55,7 +59,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, password_hash, role, created_at, updated_at 
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at 
 FROM users 
 WHERE email = $1
 `
@@ -71,12 +75,15 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
+		pq.Array(&i.MutedNotifications),
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, email, password_hash, role, created_at, updated_at 
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at 
 FROM users 
 WHERE id = $1
 `
@@ -92,26 +99,103 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
+		pq.Array(&i.MutedNotifications),
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at
+FROM users
+ORDER BY created_at ASC
+LIMIT $1 OFFSET $2
+`
+
+type ListUsersParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.PasswordHash,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Locale,
+			pq.Array(&i.MutedNotifications),
+			&i.EmailVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markEmailVerified = `-- name: MarkEmailVerified :execrows
+UPDATE users
+SET email_verified_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND email = $2
+`
+
+type MarkEmailVerifiedParams struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+}
+
+func (q *Queries) MarkEmailVerified(ctx context.Context, arg MarkEmailVerifiedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markEmailVerified, arg.ID, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users 
 SET 
   name = COALESCE($2, name),
   email = COALESCE($3, email),
   password_hash = COALESCE($4, password_hash),
+  locale = COALESCE($5, locale),
+  muted_notifications = COALESCE($6, muted_notifications),
+  email_verified_at = CASE
+    WHEN $3 IS NOT NULL AND $3 <> email THEN NULL
+    ELSE email_verified_at
+  END,
   updated_at = NOW()
 WHERE id = $1
-RETURNING id, name, email, password_hash, role, created_at, updated_at
+RETURNING id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at
 `
 
 type UpdateUserParams struct {
-	ID           uuid.UUID      `json:"id"`
-	Name         sql.NullString `json:"name"`
-	Email        sql.NullString `json:"email"`
-	PasswordHash sql.NullString `json:"password_hash"`
+	ID                 uuid.UUID      `json:"id"`
+	Name               sql.NullString `json:"name"`
+	Email              sql.NullString `json:"email"`
+	PasswordHash       sql.NullString `json:"password_hash"`
+	Locale             sql.NullString `json:"locale"`
+	MutedNotifications []string       `json:"muted_notifications"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -120,6 +204,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.Name,
 		arg.Email,
 		arg.PasswordHash,
+		arg.Locale,
+		pq.Array(arg.MutedNotifications),
 	)
 	var i User
 	err := row.Scan(
@@ -130,6 +216,29 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Locale,
+		pq.Array(&i.MutedNotifications),
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
+
+const updateUserRole = `-- name: UpdateUserRole :execrows
+UPDATE users
+SET role = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserRoleParams struct {
+	ID   uuid.UUID `json:"id"`
+	Role string    `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserRole, arg.ID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,241 @@
+package interview
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	ScheduleInterviewHandler(c *gin.Context)
+	GetApplicationInterviewsHandler(c *gin.Context)
+	UpdateInterviewHandler(c *gin.Context)
+	DeleteInterviewHandler(c *gin.Context)
+	GetUpcomingInterviewsHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/applications/:id/interviews - schedule an interview round
+func (h *GinHandler) ScheduleInterviewHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return
+	}
+
+	var req CreateInterviewInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	interview, err := h.service.ScheduleInterview(c.Request.Context(), userID, applicationID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "interview scheduled successfully",
+		"interview": interview,
+	})
+}
+
+// GET /api/applications/:id/interviews - list the interview rounds of an application
+func (h *GinHandler) GetApplicationInterviewsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return
+	}
+
+	interviews, err := h.service.GetApplicationInterviews(c.Request.Context(), userID, applicationID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"interviews": interviews,
+		"total":      len(interviews),
+	})
+}
+
+// PUT /api/applications/:id/interviews/:interviewID - update an interview round
+func (h *GinHandler) UpdateInterviewHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, interviewID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateInterviewInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	interview, err := h.service.UpdateInterview(c.Request.Context(), userID, applicationID, interviewID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "interview updated successfully",
+		"interview": interview,
+	})
+}
+
+// DELETE /api/applications/:id/interviews/:interviewID - remove an interview round
+func (h *GinHandler) DeleteInterviewHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, interviewID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteInterview(c.Request.Context(), userID, applicationID, interviewID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "interview deleted successfully",
+	})
+}
+
+// GET /api/interviews/upcoming?days=7 - pending interviews in the next days
+func (h *GinHandler) GetUpcomingInterviewsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "days must be a positive integer",
+		})
+		return
+	}
+
+	interviews, err := h.service.GetUpcomingInterviews(c.Request.Context(), userID, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"interviews": interviews,
+		"total":      len(interviews),
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrApplicationNotFound),
+		errors.Is(err, ErrInterviewNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrMissingRoundName),
+		errors.Is(err, ErrInvalidType),
+		errors.Is(err, ErrInvalidOutcome),
+		errors.Is(err, ErrInvalidDuration),
+		errors.Is(err, ErrMissingSchedule):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	applicationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	interviewID, err := uuid.Parse(c.Param("interviewID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid interview id format",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return applicationID, interviewID, true
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
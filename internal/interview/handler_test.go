@@ -0,0 +1,185 @@
+package interview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleInterviewHandler_Success(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID: {ID: appID, UserID: userID},
+	}}
+	handler := NewGinHandler(NewService(NewMockRepository(), apps))
+
+	reqBody := CreateInterviewInput{
+		RoundName:    "System design",
+		Type:         TypeTechnical,
+		ScheduledAt:  time.Now().Add(48 * time.Hour),
+		Interviewers: []string{"Ana", "Bruno"},
+		MeetingLink:  "https://meet.example.com/abc",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/applications/"+appID.String()+"/interviews", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.ScheduleInterviewHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Message   string    `json:"message"`
+		Interview Interview `json:"interview"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "interview scheduled successfully", response.Message)
+	assert.Equal(t, appID, response.Interview.ApplicationID)
+	assert.Equal(t, 60, response.Interview.DurationMinutes)
+	assert.Equal(t, OutcomePending, response.Interview.Outcome)
+	assert.Equal(t, []string{"Ana", "Bruno"}, response.Interview.Interviewers)
+}
+
+func TestScheduleInterviewHandler_OtherUser(t *testing.T) {
+	// Setup
+	appID := uuid.New()
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID: {ID: appID, UserID: uuid.New()},
+	}}
+	handler := NewGinHandler(NewService(NewMockRepository(), apps))
+
+	reqBody := CreateInterviewInput{
+		RoundName:   "Phone screen",
+		Type:        TypePhone,
+		ScheduledAt: time.Now().Add(time.Hour),
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/applications/"+appID.String()+"/interviews", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.ScheduleInterviewHandler(c)
+
+	// Assert - should return 403 Forbidden
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestUpdateInterviewHandler_InvalidOutcome(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID: {ID: appID, UserID: userID},
+	}}
+	svc := NewService(NewMockRepository(), apps)
+	interview, err := svc.ScheduleInterview(context.Background(), userID, appID, CreateInterviewInput{
+		RoundName:   "Onsite",
+		Type:        TypeOnsite,
+		ScheduledAt: time.Now().Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	handler := NewGinHandler(svc)
+
+	body := []byte(`{"outcome": "maybe"}`)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/applications/"+appID.String()+"/interviews/"+interview.ID.String(), bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{
+		{Key: "id", Value: appID.String()},
+		{Key: "interviewID", Value: interview.ID.String()},
+	}
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.UpdateInterviewHandler(c)
+
+	// Assert - should return 400 Bad Request
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetUpcomingInterviewsHandler(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	otherAppID := uuid.New()
+
+	repo := NewMockRepository()
+	mock := repo.(*mockRepository)
+	mock.setApplicationOwner(appID, userID)
+	mock.setApplicationOwner(otherAppID, uuid.New())
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, in := range []*Interview{
+		{ApplicationID: appID, RoundName: "Tomorrow", Type: TypePhone, ScheduledAt: now.Add(24 * time.Hour)},
+		{ApplicationID: appID, RoundName: "Next month", Type: TypeOnsite, ScheduledAt: now.Add(30 * 24 * time.Hour)},
+		{ApplicationID: appID, RoundName: "Yesterday", Type: TypePhone, ScheduledAt: now.Add(-24 * time.Hour)},
+		{ApplicationID: appID, RoundName: "Cancelled", Type: TypePhone, ScheduledAt: now.Add(2 * time.Hour), Outcome: OutcomeCancelled},
+		{ApplicationID: otherAppID, RoundName: "Not mine", Type: TypePhone, ScheduledAt: now.Add(time.Hour)},
+	} {
+		_, err := repo.Create(ctx, in)
+		require.NoError(t, err)
+	}
+
+	handler := NewGinHandler(NewService(repo, &stubApplicationService{}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/interviews/upcoming?days=7", nil)
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.GetUpcomingInterviewsHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Interviews []Interview `json:"interviews"`
+		Total      int         `json:"total"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, 1, response.Total)
+	assert.Equal(t, "Tomorrow", response.Interviews[0].RoundName)
+}
+
+// Stub application service for testing, only GetApplicationByID is used
+type stubApplicationService struct {
+	application.Service
+	apps map[uuid.UUID]*application.Application
+}
+
+func (s *stubApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*application.Application, error) {
+	app, exists := s.apps[id]
+	if !exists {
+		return nil, application.ErrApplicationNotFound
+	}
+	return app, nil
+}
@@ -0,0 +1,88 @@
+package interview
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewType string
+
+const (
+	TypePhone     InterviewType = "phone"
+	TypeTechnical InterviewType = "technical"
+	TypeOnsite    InterviewType = "onsite"
+	TypeOther     InterviewType = "other"
+)
+
+type Outcome string
+
+const (
+	OutcomePending   Outcome = "pending"
+	OutcomePassed    Outcome = "passed"
+	OutcomeFailed    Outcome = "failed"
+	OutcomeCancelled Outcome = "cancelled"
+)
+
+type Interview struct {
+	ID              uuid.UUID     `json:"id"`
+	ApplicationID   uuid.UUID     `json:"application_id"`
+	RoundName       string        `json:"round_name"`
+	Type            InterviewType `json:"type"`
+	ScheduledAt     time.Time     `json:"scheduled_at"`
+	DurationMinutes int           `json:"duration_minutes"`
+	Interviewers    []string      `json:"interviewers"`
+	Location        string        `json:"location,omitempty"`
+	MeetingLink     string        `json:"meeting_link,omitempty"`
+	Outcome         Outcome       `json:"outcome"`
+	Notes           string        `json:"notes,omitempty"`
+	ReminderSent    bool          `json:"reminder_sent"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+type CreateInterviewInput struct {
+	RoundName       string        `json:"round_name" binding:"required"`
+	Type            InterviewType `json:"type" binding:"required"`
+	ScheduledAt     time.Time     `json:"scheduled_at" binding:"required"`
+	DurationMinutes int           `json:"duration_minutes,omitempty"`
+	Interviewers    []string      `json:"interviewers,omitempty"`
+	Location        string        `json:"location,omitempty"`
+	MeetingLink     string        `json:"meeting_link,omitempty"`
+	Notes           string        `json:"notes,omitempty"`
+}
+
+type UpdateInterviewInput struct {
+	RoundName       string        `json:"round_name,omitempty"`
+	Type            InterviewType `json:"type,omitempty"`
+	ScheduledAt     *time.Time    `json:"scheduled_at,omitempty"`
+	DurationMinutes int           `json:"duration_minutes,omitempty"`
+	Interviewers    []string      `json:"interviewers,omitempty"`
+	Location        string        `json:"location,omitempty"`
+	MeetingLink     string        `json:"meeting_link,omitempty"`
+	Outcome         Outcome       `json:"outcome,omitempty"`
+	Notes           string        `json:"notes,omitempty"`
+}
+
+// IsValid validate the interview type
+func (t InterviewType) IsValid() bool {
+	switch t {
+	case TypePhone, TypeTechnical, TypeOnsite, TypeOther:
+		return true
+	}
+	return false
+}
+
+// IsValid validate the interview outcome
+func (o Outcome) IsValid() bool {
+	switch o {
+	case OutcomePending, OutcomePassed, OutcomeFailed, OutcomeCancelled:
+		return true
+	}
+	return false
+}
+
+// EndsAt returns when the interview is expected to finish
+func (i *Interview) EndsAt() time.Time {
+	return i.ScheduledAt.Add(time.Duration(i.DurationMinutes) * time.Minute)
+}
@@ -0,0 +1,20 @@
+package interview
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("interview not found")
+
+type Repository interface {
+	Create(ctx context.Context, interview *Interview) (*Interview, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Interview, error)
+	GetApplicationInterviews(ctx context.Context, applicationID uuid.UUID) ([]*Interview, error)
+	GetUpcoming(ctx context.Context, userID uuid.UUID, until time.Time) ([]*Interview, error)
+	Update(ctx context.Context, interview *Interview) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,170 @@
+package interview
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu         sync.RWMutex
+	interviews map[uuid.UUID]*Interview
+	// owners maps application IDs to user IDs, standing in for the
+	// applications join done by the postgres query
+	owners map[uuid.UUID]uuid.UUID
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		interviews: make(map[uuid.UUID]*Interview),
+		owners:     make(map[uuid.UUID]uuid.UUID),
+	}
+}
+
+// setApplicationOwner registers the owner of an application so that
+// GetUpcoming can filter by user
+func (m *mockRepository) setApplicationOwner(applicationID, userID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.owners[applicationID] = userID
+}
+
+func (m *mockRepository) Create(ctx context.Context, interview *Interview) (*Interview, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if interview.ID == uuid.Nil {
+		interview.ID = uuid.New()
+	}
+	if interview.DurationMinutes == 0 {
+		interview.DurationMinutes = 60
+	}
+	if interview.Interviewers == nil {
+		interview.Interviewers = []string{}
+	}
+	if interview.Outcome == "" {
+		interview.Outcome = OutcomePending
+	}
+
+	now := time.Now()
+	interview.CreatedAt = now
+	interview.UpdatedAt = now
+
+	m.interviews[interview.ID] = interview
+	return interview, nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Interview, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	interview, exists := m.interviews[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return interview, nil
+}
+
+func (m *mockRepository) GetApplicationInterviews(ctx context.Context, applicationID uuid.UUID) ([]*Interview, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	interviews := []*Interview{}
+	for _, interview := range m.interviews {
+		if interview.ApplicationID == applicationID {
+			interviews = append(interviews, interview)
+		}
+	}
+
+	sortBySchedule(interviews)
+	return interviews, nil
+}
+
+func (m *mockRepository) GetUpcoming(ctx context.Context, userID uuid.UUID, until time.Time) ([]*Interview, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	interviews := []*Interview{}
+	for _, interview := range m.interviews {
+		if m.owners[interview.ApplicationID] != userID {
+			continue
+		}
+		if interview.Outcome != OutcomePending {
+			continue
+		}
+		if interview.ScheduledAt.Before(now) || interview.ScheduledAt.After(until) {
+			continue
+		}
+		interviews = append(interviews, interview)
+	}
+
+	sortBySchedule(interviews)
+	return interviews, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, interview *Interview) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.interviews[interview.ID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	if interview.RoundName != "" {
+		existing.RoundName = interview.RoundName
+	}
+	if interview.Type != "" {
+		existing.Type = interview.Type
+	}
+	if !interview.ScheduledAt.IsZero() {
+		existing.ScheduledAt = interview.ScheduledAt
+		existing.ReminderSent = false
+	}
+	if interview.DurationMinutes > 0 {
+		existing.DurationMinutes = interview.DurationMinutes
+	}
+	if interview.Interviewers != nil {
+		existing.Interviewers = interview.Interviewers
+	}
+	if interview.Location != "" {
+		existing.Location = interview.Location
+	}
+	if interview.MeetingLink != "" {
+		existing.MeetingLink = interview.MeetingLink
+	}
+	if interview.Outcome != "" {
+		existing.Outcome = interview.Outcome
+	}
+	if interview.Notes != "" {
+		existing.Notes = interview.Notes
+	}
+	existing.UpdatedAt = time.Now()
+
+	*interview = *existing
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.interviews[id]; !exists {
+		return ErrNotFound
+	}
+
+	delete(m.interviews, id)
+	return nil
+}
+
+func sortBySchedule(interviews []*Interview) {
+	sort.Slice(interviews, func(i, j int) bool {
+		return interviews[i].ScheduledAt.Before(interviews[j].ScheduledAt)
+	})
+}
@@ -0,0 +1,157 @@
+package interview
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, interview *Interview) (*Interview, error) {
+	dbInterview, err := r.queries.CreateInterview(ctx, database.CreateInterviewParams{
+		ApplicationID:   interview.ApplicationID,
+		RoundName:       interview.RoundName,
+		Type:            string(interview.Type),
+		ScheduledAt:     interview.ScheduledAt,
+		DurationMinutes: int32(interview.DurationMinutes),
+		Interviewers:    interview.Interviewers,
+		Location:        toNullString(interview.Location),
+		MeetingLink:     toNullString(interview.MeetingLink),
+		Notes:           toNullString(interview.Notes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbInterviewToInterview(&dbInterview), nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*Interview, error) {
+	dbInterview, err := r.queries.GetInterviewByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbInterviewToInterview(&dbInterview), nil
+}
+
+func (r *PostgresRepository) GetApplicationInterviews(ctx context.Context, applicationID uuid.UUID) ([]*Interview, error) {
+	dbInterviews, err := r.queries.GetApplicationInterviews(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	interviews := make([]*Interview, len(dbInterviews))
+	for i, dbInterview := range dbInterviews {
+		interviews[i] = dbInterviewToInterview(&dbInterview)
+	}
+
+	return interviews, nil
+}
+
+func (r *PostgresRepository) GetUpcoming(ctx context.Context, userID uuid.UUID, until time.Time) ([]*Interview, error) {
+	dbInterviews, err := r.queries.GetUpcomingInterviews(ctx, database.GetUpcomingInterviewsParams{
+		UserID:      userID,
+		ScheduledAt: until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	interviews := make([]*Interview, len(dbInterviews))
+	for i, dbInterview := range dbInterviews {
+		interviews[i] = dbInterviewToInterview(&dbInterview)
+	}
+
+	return interviews, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, interview *Interview) error {
+	params := database.UpdateInterviewParams{
+		ID:           interview.ID,
+		RoundName:    toNullString(interview.RoundName),
+		Type:         toNullString(string(interview.Type)),
+		Interviewers: interview.Interviewers,
+		Location:     toNullString(interview.Location),
+		MeetingLink:  toNullString(interview.MeetingLink),
+		Outcome:      toNullString(string(interview.Outcome)),
+		Notes:        toNullString(interview.Notes),
+	}
+
+	if !interview.ScheduledAt.IsZero() {
+		params.ScheduledAt = sql.NullTime{Time: interview.ScheduledAt, Valid: true}
+	}
+	if interview.DurationMinutes > 0 {
+		params.DurationMinutes = sql.NullInt32{Int32: int32(interview.DurationMinutes), Valid: true}
+	}
+
+	dbInterview, err := r.queries.UpdateInterview(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	// Update the interview object with returned values
+	*interview = *dbInterviewToInterview(&dbInterview)
+
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteInterview(ctx, id)
+}
+
+// Helper functions to convert between domain and database models
+
+func dbInterviewToInterview(dbInterview *database.Interview) *Interview {
+	interviewers := dbInterview.Interviewers
+	if interviewers == nil {
+		interviewers = []string{}
+	}
+
+	return &Interview{
+		ID:              dbInterview.ID,
+		ApplicationID:   dbInterview.ApplicationID,
+		RoundName:       dbInterview.RoundName,
+		Type:            InterviewType(dbInterview.Type),
+		ScheduledAt:     dbInterview.ScheduledAt,
+		DurationMinutes: int(dbInterview.DurationMinutes),
+		Interviewers:    interviewers,
+		Location:        fromNullString(dbInterview.Location),
+		MeetingLink:     fromNullString(dbInterview.MeetingLink),
+		Outcome:         Outcome(dbInterview.Outcome),
+		Notes:           fromNullString(dbInterview.Notes),
+		ReminderSent:    dbInterview.ReminderSent,
+		CreatedAt:       dbInterview.CreatedAt,
+		UpdatedAt:       dbInterview.UpdatedAt,
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func fromNullString(ns sql.NullString) string {
+	if ns.Valid {
+		return ns.String
+	}
+	return ""
+}
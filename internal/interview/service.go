@@ -0,0 +1,215 @@
+package interview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+)
+
+var (
+	ErrInterviewNotFound   = errors.New("interview not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrForbidden           = errors.New("application belongs to another user")
+	ErrMissingRoundName    = errors.New("round name is required")
+	ErrInvalidType         = errors.New("invalid interview type")
+	ErrInvalidOutcome      = errors.New("invalid interview outcome")
+	ErrInvalidDuration     = errors.New("duration must be positive")
+	ErrMissingSchedule     = errors.New("scheduled_at is required")
+)
+
+type Service interface {
+	ScheduleInterview(ctx context.Context, userID, applicationID uuid.UUID, input CreateInterviewInput) (*Interview, error)
+	GetApplicationInterviews(ctx context.Context, userID, applicationID uuid.UUID) ([]*Interview, error)
+	UpdateInterview(ctx context.Context, userID, applicationID, id uuid.UUID, input UpdateInterviewInput) (*Interview, error)
+	DeleteInterview(ctx context.Context, userID, applicationID, id uuid.UUID) error
+	GetUpcomingInterviews(ctx context.Context, userID uuid.UUID, within time.Duration) ([]*Interview, error)
+}
+
+type service struct {
+	repo       Repository
+	appService application.Service
+}
+
+func NewService(repo Repository, appService application.Service) Service {
+	return &service{
+		repo:       repo,
+		appService: appService,
+	}
+}
+
+func (s *service) ScheduleInterview(ctx context.Context, userID, applicationID uuid.UUID, input CreateInterviewInput) (*Interview, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	roundName := strings.TrimSpace(input.RoundName)
+	if roundName == "" {
+		return nil, ErrMissingRoundName
+	}
+
+	if !input.Type.IsValid() {
+		return nil, ErrInvalidType
+	}
+
+	if input.ScheduledAt.IsZero() {
+		return nil, ErrMissingSchedule
+	}
+
+	duration := input.DurationMinutes
+	if duration < 0 {
+		return nil, ErrInvalidDuration
+	}
+	if duration == 0 {
+		duration = 60
+	}
+
+	interviewers := input.Interviewers
+	if interviewers == nil {
+		interviewers = []string{}
+	}
+
+	interview := &Interview{
+		ApplicationID:   applicationID,
+		RoundName:       roundName,
+		Type:            input.Type,
+		ScheduledAt:     input.ScheduledAt,
+		DurationMinutes: duration,
+		Interviewers:    interviewers,
+		Location:        input.Location,
+		MeetingLink:     input.MeetingLink,
+		Outcome:         OutcomePending,
+		Notes:           input.Notes,
+	}
+
+	created, err := s.repo.Create(ctx, interview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule interview: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *service) GetApplicationInterviews(ctx context.Context, userID, applicationID uuid.UUID) ([]*Interview, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	interviews, err := s.repo.GetApplicationInterviews(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interviews: %w", err)
+	}
+
+	return interviews, nil
+}
+
+func (s *service) UpdateInterview(ctx context.Context, userID, applicationID, id uuid.UUID, input UpdateInterviewInput) (*Interview, error) {
+	if _, err := s.getOwnedInterview(ctx, userID, applicationID, id); err != nil {
+		return nil, err
+	}
+
+	if input.Type != "" && !input.Type.IsValid() {
+		return nil, ErrInvalidType
+	}
+
+	if input.Outcome != "" && !input.Outcome.IsValid() {
+		return nil, ErrInvalidOutcome
+	}
+
+	if input.DurationMinutes < 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	interview := &Interview{
+		ID:              id,
+		RoundName:       strings.TrimSpace(input.RoundName),
+		Type:            input.Type,
+		DurationMinutes: input.DurationMinutes,
+		Interviewers:    input.Interviewers,
+		Location:        input.Location,
+		MeetingLink:     input.MeetingLink,
+		Outcome:         input.Outcome,
+		Notes:           input.Notes,
+	}
+	if input.ScheduledAt != nil {
+		interview.ScheduledAt = *input.ScheduledAt
+	}
+
+	if err := s.repo.Update(ctx, interview); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, fmt.Errorf("failed to update interview: %w", err)
+	}
+
+	return interview, nil
+}
+
+func (s *service) DeleteInterview(ctx context.Context, userID, applicationID, id uuid.UUID) error {
+	if _, err := s.getOwnedInterview(ctx, userID, applicationID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrInterviewNotFound
+		}
+		return fmt.Errorf("failed to delete interview: %w", err)
+	}
+
+	return nil
+}
+
+// GetUpcomingInterviews returns the user's pending interviews scheduled
+// between now and now + within
+func (s *service) GetUpcomingInterviews(ctx context.Context, userID uuid.UUID, within time.Duration) ([]*Interview, error) {
+	interviews, err := s.repo.GetUpcoming(ctx, userID, time.Now().Add(within))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming interviews: %w", err)
+	}
+
+	return interviews, nil
+}
+
+// authorize checks that the application exists and belongs to the user
+func (s *service) authorize(ctx context.Context, userID, applicationID uuid.UUID) error {
+	app, err := s.appService.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, application.ErrApplicationNotFound) {
+			return ErrApplicationNotFound
+		}
+		return err
+	}
+
+	if app.UserID != userID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// getOwnedInterview loads an interview making sure it belongs to the given
+// application and that the application belongs to the user
+func (s *service) getOwnedInterview(ctx context.Context, userID, applicationID, id uuid.UUID) (*Interview, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	interview, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get interview: %w", err)
+	}
+
+	if interview.ApplicationID != applicationID {
+		return nil, ErrInterviewNotFound
+	}
+
+	return interview, nil
+}
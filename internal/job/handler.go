@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
 )
 
 type Handler interface {
@@ -35,6 +36,19 @@ func (h *GinHandler) CreateJobHandler(c *gin.Context) {
 		return
 	}
 
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id format"})
+		return
+	}
+	req.CreatedBy = &userID
+
 	job, err := h.service.CreateJob(c.Request.Context(), req)
 	if err != nil {
 		status := http.StatusBadRequest
@@ -65,6 +79,7 @@ func (h *GinHandler) CreateJobHandler(c *gin.Context) {
 			"source":      job.Source,
 			"posted_date": job.PostedDate,
 			"link":        job.Link,
+			"created_by":  job.CreatedBy,
 			"created_at":  job.CreatedAt,
 		},
 	})
@@ -167,11 +182,28 @@ func (h *GinHandler) ToggleJobStatusHandler(c *gin.Context) {
 		return
 	}
 
-	err = h.service.MarkJobAsInactive(c.Request.Context(), parsedID)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id format"})
+		return
+	}
+
+	role := auth.Role(c.GetString("userRole"))
+
+	err = h.service.MarkJobAsInactive(c.Request.Context(), userID, role, parsedID)
 	if err != nil {
 		status := http.StatusInternalServerError
-		if errors.Is(err, ErrNotFound) {
+		switch {
+		case errors.Is(err, ErrNotFound):
 			status = http.StatusNotFound
+		case errors.Is(err, ErrNotJobOwner):
+			status = http.StatusForbidden
 		}
 		c.JSON(status, gin.H{
 			"error":   err.Error(),
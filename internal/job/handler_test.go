@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -80,7 +82,7 @@ func TestToggleJobStatusHandler_Success(t *testing.T) {
 	// Setup
 	jobID := uuid.New()
 	mockService := &mockJobService{
-		mockMarkJobAsInactive: func(ctx context.Context, id uuid.UUID) error {
+		mockMarkJobAsInactive: func(ctx context.Context, userID uuid.UUID, role auth.Role, id uuid.UUID) error {
 			if id == jobID {
 				return nil
 			}
@@ -94,6 +96,7 @@ func TestToggleJobStatusHandler_Success(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest("PATCH", "/jobs/"+jobID.String()+"/status", nil)
 	c.Params = gin.Params{gin.Param{Key: "jobID", Value: jobID.String()}}
+	c.Set("userID", uuid.NewString())
 
 	// Execute
 	handler.ToggleJobStatusHandler(c)
@@ -110,7 +113,7 @@ func TestToggleJobStatusHandler_NotFound(t *testing.T) {
 	// Setup
 	jobID := uuid.New()
 	mockService := &mockJobService{
-		mockMarkJobAsInactive: func(ctx context.Context, id uuid.UUID) error {
+		mockMarkJobAsInactive: func(ctx context.Context, userID uuid.UUID, role auth.Role, id uuid.UUID) error {
 			return ErrNotFound
 		},
 	}
@@ -121,6 +124,7 @@ func TestToggleJobStatusHandler_NotFound(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest("PATCH", "/jobs/"+jobID.String()+"/status", nil)
 	c.Params = gin.Params{gin.Param{Key: "jobID", Value: jobID.String()}}
+	c.Set("userID", uuid.NewString())
 
 	// Execute
 	handler.ToggleJobStatusHandler(c)
@@ -129,13 +133,68 @@ func TestToggleJobStatusHandler_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestCreateJobHandler_RecordsCreator(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler := NewGinHandler(NewService(NewMockRepository(), nil))
+
+	body := `{"title": "Platform Engineer", "company": "Initech", "source": "manual", "link": "https://jobs.example.com/platform"}`
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/jobs", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.CreateJobHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Job struct {
+			CreatedBy uuid.UUID `json:"created_by"`
+		} `json:"job"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, userID, response.Job.CreatedBy)
+}
+
+func TestToggleJobStatusHandler_OtherRecruiter(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository(), nil)
+	owner := uuid.New()
+	job, err := service.CreateJob(context.Background(), CreateJobInput{Title: "Platform Engineer", Company: "Initech", Source: "manual", Link: "https://jobs.example.com/platform", CreatedBy: &owner})
+	require.NoError(t, err)
+	handler := NewGinHandler(service)
+
+	toggle := func(userID uuid.UUID, role auth.Role) int {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PATCH", "/jobs/"+job.ID.String(), nil)
+		c.Params = gin.Params{gin.Param{Key: "jobID", Value: job.ID.String()}}
+		c.Set("userID", userID.String())
+		c.Set("userRole", string(role))
+		handler.ToggleJobStatusHandler(c)
+		return w.Code
+	}
+
+	// Execute
+	otherRecruiter := toggle(uuid.New(), auth.RoleRecruiter)
+	admin := toggle(uuid.New(), auth.RoleAdmin)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, otherRecruiter)
+	assert.Equal(t, http.StatusOK, admin)
+}
+
 // Mock service for testing
 type mockJobService struct {
 	mockCreateJob         func(context.Context, CreateJobInput) (*Job, error)
 	mockSearchJobs        func(context.Context, JobFilters) (*JobsResponse, error)
 	mockGetJob            func(context.Context, uuid.UUID) (*Job, error)
+	mockGetJobByLink      func(context.Context, string) (*Job, error)
 	mockUpdateJob         func(context.Context, uuid.UUID, UpdateJobInput) (*Job, error)
-	mockMarkJobAsInactive func(context.Context, uuid.UUID) error
+	mockMarkJobAsInactive func(context.Context, uuid.UUID, auth.Role, uuid.UUID) error
 }
 
 func (m *mockJobService) CreateJob(ctx context.Context, input CreateJobInput) (*Job, error) {
@@ -159,6 +218,13 @@ func (m *mockJobService) GetJob(ctx context.Context, id uuid.UUID) (*Job, error)
 	return nil, nil
 }
 
+func (m *mockJobService) GetJobByLink(ctx context.Context, link string) (*Job, error) {
+	if m.mockGetJobByLink != nil {
+		return m.mockGetJobByLink(ctx, link)
+	}
+	return nil, nil
+}
+
 func (m *mockJobService) UpdateJob(ctx context.Context, id uuid.UUID, updates UpdateJobInput) (*Job, error) {
 	if m.mockUpdateJob != nil {
 		return m.mockUpdateJob(ctx, id, updates)
@@ -166,9 +232,9 @@ func (m *mockJobService) UpdateJob(ctx context.Context, id uuid.UUID, updates Up
 	return nil, nil
 }
 
-func (m *mockJobService) MarkJobAsInactive(ctx context.Context, id uuid.UUID) error {
+func (m *mockJobService) MarkJobAsInactive(ctx context.Context, userID uuid.UUID, role auth.Role, id uuid.UUID) error {
 	if m.mockMarkJobAsInactive != nil {
-		return m.mockMarkJobAsInactive(ctx, id)
+		return m.mockMarkJobAsInactive(ctx, userID, role, id)
 	}
 	return nil
 }
@@ -21,6 +21,9 @@ type Job struct {
 	IsActive     bool      `json:"is_active"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// CreatedBy is the recruiter who posted the job, nil for scraped and
+	// imported jobs
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
 }
 
 type CreateJobInput struct {
@@ -33,6 +36,8 @@ type CreateJobInput struct {
 	Source       string    `json:"source"`
 	Link         string    `json:"link"`
 	PostedDate   time.Time `json:"posted_date"`
+	// CreatedBy is filled in from the authenticated user, not the body
+	CreatedBy *uuid.UUID `json:"-"`
 }
 
 type JobFilters struct {
@@ -65,3 +70,8 @@ type UpdateJobInput struct {
 	IsActive     *bool      `json:"is_active,omitempty"`
 	PostedDate   *time.Time `json:"posted_date,omitempty"`
 }
+
+// IsOwnedBy checks if the job was posted by the given user
+func (j *Job) IsOwnedBy(userID uuid.UUID) bool {
+	return j.CreatedBy != nil && *j.CreatedBy == userID
+}
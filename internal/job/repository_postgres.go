@@ -30,6 +30,7 @@ func (r *PostgresRepository) Create(ctx context.Context, job *Job) (*Job, error)
 		Source:       job.Source,
 		Link:         job.Link,
 		PostedDate:   job.PostedDate,
+		CreatedBy:    toNullUUID(job.CreatedBy),
 	})
 	if err != nil {
 		return nil, err
@@ -184,6 +185,7 @@ func dbJobToJob(dbJob *database.Job) *Job {
 		IsActive:     dbJob.IsActive,
 		CreatedAt:    dbJob.CreatedAt,
 		UpdatedAt:    dbJob.UpdatedAt,
+		CreatedBy:    fromNullUUID(dbJob.CreatedBy),
 	}
 }
 
@@ -204,3 +206,17 @@ func toNullBool(b *bool) sql.NullBool {
 	}
 	return sql.NullBool{Bool: *b, Valid: true}
 }
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/webhook"
 )
 
 var (
@@ -17,22 +19,28 @@ var (
 	ErrMissingTitle   = errors.New("job title is required")
 	ErrMissingCompany = errors.New("company name is required")
 	ErrFuturePostDate = errors.New("post date cannot be in the future")
+	ErrNotJobOwner    = errors.New("only the recruiter who posted the job can change it")
 )
 
 type Service interface {
 	CreateJob(ctx context.Context, input CreateJobInput) (*Job, error)
 	GetJob(ctx context.Context, id uuid.UUID) (*Job, error)
+	GetJobByLink(ctx context.Context, link string) (*Job, error)
 	SearchJobs(ctx context.Context, filters JobFilters) (*JobsResponse, error)
 	UpdateJob(ctx context.Context, id uuid.UUID, updates UpdateJobInput) (*Job, error)
-	MarkJobAsInactive(ctx context.Context, id uuid.UUID) error
+	// MarkJobAsInactive is left to the job's poster and admins
+	MarkJobAsInactive(ctx context.Context, userID uuid.UUID, role auth.Role, id uuid.UUID) error
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	events webhook.Publisher
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+// NewService builds the job service. events may be nil when no webhook
+// subscribers need to hear about new jobs
+func NewService(repo Repository, events webhook.Publisher) Service {
+	return &service{repo: repo, events: events}
 }
 
 func (s *service) CreateJob(ctx context.Context, input CreateJobInput) (*Job, error) {
@@ -93,6 +101,7 @@ func (s *service) CreateJob(ctx context.Context, input CreateJobInput) (*Job, er
 		IsActive:     true,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
+		CreatedBy:    input.CreatedBy,
 	}
 
 	createdJob, err := s.repo.Create(ctx, job)
@@ -100,6 +109,12 @@ func (s *service) CreateJob(ctx context.Context, input CreateJobInput) (*Job, er
 		return nil, fmt.Errorf("Error creating job: %w", err)
 	}
 
+	// only the poster hears about the job, scraped and imported jobs have
+	// no poster and other users' endpoints must not see them
+	if s.events != nil && createdJob.CreatedBy != nil {
+		s.events.Publish(ctx, webhook.NewEvent(webhook.EventJobCreated, *createdJob.CreatedBy, createdJob))
+	}
+
 	return createdJob, nil
 }
 
@@ -149,6 +164,18 @@ func (s *service) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
 	return job, nil
 }
 
+func (s *service) GetJobByLink(ctx context.Context, link string) (*Job, error) {
+	job, err := s.repo.GetByLink(ctx, link)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
 func (s *service) UpdateJob(ctx context.Context, id uuid.UUID, updates UpdateJobInput) (*Job, error) {
 	if updates.Link != "" && !strings.HasPrefix(updates.Link, "http") {
 		return nil, fmt.Errorf("link must be a valid url")
@@ -250,7 +277,7 @@ func (s *service) UpdateJob(ctx context.Context, id uuid.UUID, updates UpdateJob
 	return job, nil
 }
 
-func (s *service) MarkJobAsInactive(ctx context.Context, id uuid.UUID) error {
+func (s *service) MarkJobAsInactive(ctx context.Context, userID uuid.UUID, role auth.Role, id uuid.UUID) error {
 	job, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if !errors.Is(err, ErrNotFound) {
@@ -259,6 +286,11 @@ func (s *service) MarkJobAsInactive(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	// scraped jobs have no poster, only admins manage them
+	if role != auth.RoleAdmin && !job.IsOwnedBy(userID) {
+		return ErrNotJobOwner
+	}
+
 	job.UpdatedAt = time.Now()
 
 	err = s.repo.MarkJobAsInactive(ctx, id)
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJob_PublishesOnlyToPoster(t *testing.T) {
+	// Setup
+	events := &recordingPublisher{}
+	service := NewService(NewMockRepository(), events)
+	posterID := uuid.New()
+
+	// Execute
+	_, err := service.CreateJob(context.Background(), CreateJobInput{Title: "Platform Engineer", Company: "Initech", Source: "manual", Link: "https://jobs.example.com/platform", CreatedBy: &posterID})
+	require.NoError(t, err)
+	_, err = service.CreateJob(context.Background(), CreateJobInput{Title: "Data Engineer", Company: "Initech", Source: "manual", Link: "https://jobs.example.com/data"})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, events.events, 1)
+	assert.Equal(t, webhook.EventJobCreated, events.events[0].Type)
+	assert.Equal(t, posterID, events.events[0].UserID)
+}
+
+type recordingPublisher struct {
+	events []webhook.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event webhook.Event) {
+	p.events = append(p.events, event)
+}
@@ -0,0 +1,66 @@
+package loginguard
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	ListEventsHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// GET /api/admin/login-events?email=&user_id=&ip=&event=&page=1&limit=50 - login audit log, newest first
+func (h *GinHandler) ListEventsHandler(c *gin.Context) {
+	filters := EventFilters{
+		Email:     c.Query("email"),
+		IPAddress: c.Query("ip"),
+		Event:     EventType(c.Query("event")),
+	}
+
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid user id format",
+			})
+			return
+		}
+		filters.UserID = &userID
+	}
+
+	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
+		filters.Page = page
+	}
+	if limit, err := strconv.Atoi(c.DefaultQuery("limit", "0")); err == nil {
+		filters.Limit = limit
+	}
+
+	events, err := h.service.ListEvents(c.Request.Context(), filters)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidEvent) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"total":  len(events),
+	})
+}
@@ -0,0 +1,150 @@
+package loginguard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTooManyAttempts is wrapped by every ThrottledError
+var ErrTooManyAttempts = errors.New("too many failed login attempts")
+
+// ThrottledError is returned while an email or client address has to wait
+// before trying again
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, try again in %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// Policy says how failures on one key slow it down
+type Policy struct {
+	// FreeAttempts failures are allowed before any delay
+	FreeAttempts int
+	// LockAfter failures lock the key for LockFor
+	LockAfter int
+	LockFor   time.Duration
+	// MaxDelay caps the delay between attempts, which doubles with every
+	// failure past FreeAttempts starting at one second
+	MaxDelay time.Duration
+	// Window is how long failures are remembered after the last one
+	Window time.Duration
+}
+
+// EmailPolicy guards a single account against password guessing
+var EmailPolicy = Policy{
+	FreeAttempts: 3,
+	LockAfter:    10,
+	LockFor:      15 * time.Minute,
+	MaxDelay:     time.Minute,
+	Window:       15 * time.Minute,
+}
+
+// IPPolicy is looser, offices and NATs share one address
+var IPPolicy = Policy{
+	FreeAttempts: 10,
+	LockAfter:    100,
+	LockFor:      15 * time.Minute,
+	MaxDelay:     time.Minute,
+	Window:       15 * time.Minute,
+}
+
+// Delay is how long to wait after the given number of failures
+func (p Policy) Delay(failures int) time.Duration {
+	extra := failures - p.FreeAttempts
+	if extra <= 0 {
+		return 0
+	}
+	if extra > 30 {
+		return p.MaxDelay
+	}
+
+	delay := time.Duration(math.Pow(2, float64(extra-1))) * time.Second
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// Throttle is the failure count of one key
+type Throttle struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+type EventType string
+
+const (
+	EventSuccess EventType = "success"
+	EventFailure EventType = "failure"
+	EventLockout EventType = "lockout"
+)
+
+func (e EventType) IsValid() bool {
+	switch e {
+	case EventSuccess, EventFailure, EventLockout:
+		return true
+	}
+	return false
+}
+
+// Reasons recorded with failure and lockout events
+const (
+	ReasonUnknownEmail   = "unknown_email"
+	ReasonWrongPassword  = "wrong_password"
+	ReasonWrongCode      = "wrong_second_factor"
+	ReasonEmailThreshold = "email_threshold"
+	ReasonIPThreshold    = "ip_threshold"
+)
+
+// Event is an entry of the login audit log
+type Event struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    *uuid.UUID `json:"user_id"`
+	Email     string     `json:"email"`
+	IPAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	Event     EventType  `json:"event"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Attempt is a login as it came in
+type Attempt struct {
+	Email     string
+	IPAddress string
+	UserAgent string
+}
+
+type EventFilters struct {
+	Email     string
+	UserID    *uuid.UUID
+	IPAddress string
+	Event     EventType
+	Page      int
+	Limit     int
+}
+
+func emailKey(email string) string {
+	return "email:" + normalizeEmail(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,24 @@
+package loginguard
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// GetThrottles returns the keys that have failures, others are left out
+	GetThrottles(ctx context.Context, keys []string) ([]*Throttle, error)
+	// RecordFailure counts a failure at failedAt and returns the count,
+	// which starts over when the last failure is before windowStart
+	RecordFailure(ctx context.Context, key string, failedAt, windowStart time.Time) (int, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	CreateEvent(ctx context.Context, event *Event) error
+	// ListEvents returns matching events, newest first
+	ListEvents(ctx context.Context, filters EventFilters, limit, offset int) ([]*Event, error)
+	// DeleteHistory removes the events of a user and those recorded for
+	// their email
+	DeleteHistory(ctx context.Context, userID uuid.UUID, email string) error
+}
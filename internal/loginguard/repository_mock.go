@@ -0,0 +1,133 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu        sync.RWMutex
+	throttles map[string]*Throttle
+	events    []*Event
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		throttles: make(map[string]*Throttle),
+	}
+}
+
+func (m *mockRepository) GetThrottles(ctx context.Context, keys []string) ([]*Throttle, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	throttles := []*Throttle{}
+	for _, key := range keys {
+		if t, exists := m.throttles[key]; exists {
+			found := *t
+			throttles = append(throttles, &found)
+		}
+	}
+	return throttles, nil
+}
+
+func (m *mockRepository) RecordFailure(ctx context.Context, key string, failedAt, windowStart time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, exists := m.throttles[key]
+	if !exists {
+		t = &Throttle{Key: key}
+		m.throttles[key] = t
+	}
+	if t.LastFailureAt.Before(windowStart) {
+		t.Failures = 0
+	}
+	t.Failures++
+	t.LastFailureAt = failedAt
+	return t.Failures, nil
+}
+
+func (m *mockRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if t, exists := m.throttles[key]; exists {
+		t.LockedUntil = &until
+	}
+	return nil
+}
+
+func (m *mockRepository) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.throttles, key)
+	return nil
+}
+
+func (m *mockRepository) CreateEvent(ctx context.Context, event *Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := *event
+	stored.ID = uuid.New()
+	stored.CreatedAt = time.Now()
+	m.events = append(m.events, &stored)
+	return nil
+}
+
+func (m *mockRepository) ListEvents(ctx context.Context, filters EventFilters, limit, offset int) ([]*Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	events := []*Event{}
+	for _, e := range m.events {
+		if filters.Email != "" && e.Email != filters.Email {
+			continue
+		}
+		if filters.UserID != nil && (e.UserID == nil || *e.UserID != *filters.UserID) {
+			continue
+		}
+		if filters.IPAddress != "" && e.IPAddress != filters.IPAddress {
+			continue
+		}
+		if filters.Event != "" && e.Event != filters.Event {
+			continue
+		}
+		found := *e
+		events = append(events, &found)
+	}
+
+	// events are appended in order, newest last
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+
+	if offset >= len(events) {
+		return []*Event{}, nil
+	}
+	events = events[offset:]
+	if limit < len(events) {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (m *mockRepository) DeleteHistory(ctx context.Context, userID uuid.UUID, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.events[:0]
+	for _, e := range m.events {
+		if (e.UserID != nil && *e.UserID == userID) || e.Email == email {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	m.events = kept
+	return nil
+}
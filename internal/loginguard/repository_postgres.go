@@ -0,0 +1,125 @@
+package loginguard
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) GetThrottles(ctx context.Context, keys []string) ([]*Throttle, error) {
+	dbThrottles, err := r.queries.GetLoginThrottles(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	throttles := make([]*Throttle, 0, len(dbThrottles))
+	for _, t := range dbThrottles {
+		throttle := &Throttle{
+			Key:           t.Key,
+			Failures:      int(t.Failures),
+			LastFailureAt: t.LastFailureAt,
+		}
+		if t.LockedUntil.Valid {
+			throttle.LockedUntil = &t.LockedUntil.Time
+		}
+		throttles = append(throttles, throttle)
+	}
+	return throttles, nil
+}
+
+func (r *PostgresRepository) RecordFailure(ctx context.Context, key string, failedAt, windowStart time.Time) (int, error) {
+	failures, err := r.queries.RecordLoginFailure(ctx, database.RecordLoginFailureParams{
+		Key:         key,
+		FailedAt:    failedAt,
+		WindowStart: windowStart,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(failures), nil
+}
+
+func (r *PostgresRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return r.queries.LockLogin(ctx, database.LockLoginParams{
+		Key:         key,
+		LockedUntil: sql.NullTime{Time: until, Valid: true},
+	})
+}
+
+func (r *PostgresRepository) Reset(ctx context.Context, key string) error {
+	return r.queries.ResetLoginThrottle(ctx, key)
+}
+
+func (r *PostgresRepository) DeleteHistory(ctx context.Context, userID uuid.UUID, email string) error {
+	return r.queries.DeleteLoginHistory(ctx, database.DeleteLoginHistoryParams{
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+		Email:  email,
+	})
+}
+
+func (r *PostgresRepository) CreateEvent(ctx context.Context, event *Event) error {
+	return r.queries.CreateLoginEvent(ctx, database.CreateLoginEventParams{
+		UserID:    toNullUUID(event.UserID),
+		Email:     event.Email,
+		IpAddress: toNullString(event.IPAddress),
+		UserAgent: toNullString(event.UserAgent),
+		Event:     string(event.Event),
+		Reason:    toNullString(event.Reason),
+	})
+}
+
+func (r *PostgresRepository) ListEvents(ctx context.Context, filters EventFilters, limit, offset int) ([]*Event, error) {
+	dbEvents, err := r.queries.ListLoginEvents(ctx, database.ListLoginEventsParams{
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+		Email:     toNullString(filters.Email),
+		UserID:    toNullUUID(filters.UserID),
+		IpAddress: toNullString(filters.IPAddress),
+		Event:     toNullString(string(filters.Event)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*Event, 0, len(dbEvents))
+	for _, e := range dbEvents {
+		event := &Event{
+			ID:        e.ID,
+			Email:     e.Email,
+			IPAddress: e.IpAddress.String,
+			UserAgent: e.UserAgent.String,
+			Event:     EventType(e.Event),
+			Reason:    e.Reason.String,
+			CreatedAt: e.CreatedAt,
+		}
+		if e.UserID.Valid {
+			event.UserID = &e.UserID.UUID
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
@@ -0,0 +1,191 @@
+package loginguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidEvent = errors.New("invalid event, use success, failure or lockout")
+
+const (
+	defaultEventsLimit = 50
+	maxEventsLimit     = 200
+)
+
+// Service tracks failed logins per email and per client address. Each
+// failure past a policy's free attempts makes the key wait longer before
+// the next try, and enough of them lock it for a while
+type Service interface {
+	// Check returns a *ThrottledError while the email or the address has
+	// to wait. It runs before the password is hashed
+	Check(ctx context.Context, attempt Attempt) error
+	// Failure counts a failed attempt; userID is nil for unknown emails
+	Failure(ctx context.Context, attempt Attempt, userID *uuid.UUID, reason string) error
+	// Success clears the failures of the email. Those of the address are
+	// kept, one valid account must not unlock guessing on others
+	Success(ctx context.Context, attempt Attempt, userID uuid.UUID) error
+	ListEvents(ctx context.Context, filters EventFilters) ([]*Event, error)
+	// Forget removes the login history and failures of a deleted account
+	Forget(ctx context.Context, userID uuid.UUID, email string) error
+}
+
+type service struct {
+	repo     Repository
+	emailPol Policy
+	ipPol    Policy
+	now      func() time.Time
+}
+
+func NewService(repo Repository, emailPolicy, ipPolicy Policy) Service {
+	return &service{
+		repo:     repo,
+		emailPol: emailPolicy,
+		ipPol:    ipPolicy,
+		now:      time.Now,
+	}
+}
+
+func (s *service) Check(ctx context.Context, attempt Attempt) error {
+	policies := s.policies(attempt)
+	keys := make([]string, 0, len(policies))
+	for key := range policies {
+		keys = append(keys, key)
+	}
+
+	throttles, err := s.repo.GetThrottles(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("failed to get login throttles: %w", err)
+	}
+
+	now := s.now()
+	var wait time.Duration
+	for _, t := range throttles {
+		if w := s.wait(t, policies[t.Key], now); w > wait {
+			wait = w
+		}
+	}
+
+	if wait > 0 {
+		return &ThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+func (s *service) Failure(ctx context.Context, attempt Attempt, userID *uuid.UUID, reason string) error {
+	s.record(ctx, attempt, userID, EventFailure, reason)
+
+	now := s.now()
+	for key, policy := range s.policies(attempt) {
+		failures, err := s.repo.RecordFailure(ctx, key, now, now.Add(-policy.Window))
+		if err != nil {
+			return fmt.Errorf("failed to record login failure: %w", err)
+		}
+		if failures < policy.LockAfter {
+			continue
+		}
+
+		if err := s.repo.Lock(ctx, key, now.Add(policy.LockFor)); err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+		reason := ReasonEmailThreshold
+		if key != emailKey(attempt.Email) {
+			reason = ReasonIPThreshold
+		}
+		s.record(ctx, attempt, userID, EventLockout, reason)
+	}
+
+	return nil
+}
+
+func (s *service) Success(ctx context.Context, attempt Attempt, userID uuid.UUID) error {
+	s.record(ctx, attempt, &userID, EventSuccess, "")
+
+	if err := s.repo.Reset(ctx, emailKey(attempt.Email)); err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+	return nil
+}
+
+func (s *service) ListEvents(ctx context.Context, filters EventFilters) ([]*Event, error) {
+	if filters.Event != "" && !filters.Event.IsValid() {
+		return nil, ErrInvalidEvent
+	}
+	filters.Email = normalizeEmail(filters.Email)
+
+	page, limit := filters.Page, filters.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultEventsLimit
+	}
+	if limit > maxEventsLimit {
+		limit = maxEventsLimit
+	}
+
+	events, err := s.repo.ListEvents(ctx, filters, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+	return events, nil
+}
+
+// Forget keeps no trace of a deleted account: its events go, and so do
+// the failures counted against its email
+func (s *service) Forget(ctx context.Context, userID uuid.UUID, email string) error {
+	if err := s.repo.DeleteHistory(ctx, userID, normalizeEmail(email)); err != nil {
+		return fmt.Errorf("failed to delete login history: %w", err)
+	}
+	if err := s.repo.Reset(ctx, emailKey(email)); err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+	return nil
+}
+
+// policies maps the keys an attempt is counted under to their policy
+func (s *service) policies(attempt Attempt) map[string]Policy {
+	policies := map[string]Policy{
+		emailKey(attempt.Email): s.emailPol,
+	}
+	if attempt.IPAddress != "" {
+		policies[ipKey(attempt.IPAddress)] = s.ipPol
+	}
+	return policies
+}
+
+// wait is how long a key still has to wait at now
+func (s *service) wait(t *Throttle, policy Policy, now time.Time) time.Duration {
+	if t.LockedUntil != nil && now.Before(*t.LockedUntil) {
+		return t.LockedUntil.Sub(now)
+	}
+	if now.Sub(t.LastFailureAt) > policy.Window {
+		return 0
+	}
+
+	next := t.LastFailureAt.Add(policy.Delay(t.Failures))
+	if now.Before(next) {
+		return next.Sub(now)
+	}
+	return 0
+}
+
+// record adds an event to the audit log. A failed write is logged and
+// does not fail the login
+func (s *service) record(ctx context.Context, attempt Attempt, userID *uuid.UUID, event EventType, reason string) {
+	err := s.repo.CreateEvent(ctx, &Event{
+		UserID:    userID,
+		Email:     normalizeEmail(attempt.Email),
+		IPAddress: attempt.IPAddress,
+		UserAgent: attempt.UserAgent,
+		Event:     event,
+		Reason:    reason,
+	})
+	if err != nil {
+		log.Printf("failed to record %s login event for %s: %v", event, normalizeEmail(attempt.Email), err)
+	}
+}
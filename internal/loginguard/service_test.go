@@ -0,0 +1,188 @@
+package loginguard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		delay    time.Duration
+	}{
+		{0, 0},
+		{3, 0},
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{8, 16 * time.Second},
+		{10, time.Minute},
+		{1000, time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.delay, EmailPolicy.Delay(tt.failures), "failures=%d", tt.failures)
+	}
+}
+
+func TestCheck_ProgressiveDelayAndLockout(t *testing.T) {
+	// Setup
+	service, clock := newTestService()
+	attempt := Attempt{Email: "Ada@Example.com", IPAddress: "203.0.113.7"}
+	userID := uuid.New()
+
+	// Execute & Assert
+	for i := 1; i <= EmailPolicy.FreeAttempts; i++ {
+		require.NoError(t, service.Check(context.Background(), attempt))
+		require.NoError(t, service.Failure(context.Background(), attempt, &userID, ReasonWrongPassword))
+	}
+	require.NoError(t, service.Check(context.Background(), attempt), "free attempts have no delay")
+
+	require.NoError(t, service.Failure(context.Background(), attempt, &userID, ReasonWrongPassword))
+	err := service.Check(context.Background(), Attempt{Email: "ada@example.com"})
+	var throttled *ThrottledError
+	require.ErrorAs(t, err, &throttled)
+	assert.True(t, errors.Is(err, ErrTooManyAttempts))
+	assert.Equal(t, time.Second, throttled.RetryAfter)
+
+	for i := EmailPolicy.FreeAttempts + 2; i <= EmailPolicy.LockAfter; i++ {
+		clock.advance(EmailPolicy.MaxDelay)
+		require.NoError(t, service.Check(context.Background(), attempt))
+		require.NoError(t, service.Failure(context.Background(), attempt, &userID, ReasonWrongPassword))
+	}
+
+	clock.advance(EmailPolicy.MaxDelay)
+	err = service.Check(context.Background(), attempt)
+	require.ErrorAs(t, err, &throttled)
+	assert.Equal(t, EmailPolicy.LockFor-EmailPolicy.MaxDelay, throttled.RetryAfter)
+
+	lockouts, err := service.ListEvents(context.Background(), EventFilters{Event: EventLockout})
+	require.NoError(t, err)
+	require.Len(t, lockouts, 1)
+	assert.Equal(t, "ada@example.com", lockouts[0].Email)
+	assert.Equal(t, ReasonEmailThreshold, lockouts[0].Reason)
+
+	clock.advance(EmailPolicy.LockFor)
+	assert.NoError(t, service.Check(context.Background(), attempt))
+}
+
+func TestFailure_WindowStartsOver(t *testing.T) {
+	// Setup
+	service, clock := newTestService()
+	attempt := Attempt{Email: "ada@example.com"}
+	for i := 0; i <= EmailPolicy.FreeAttempts; i++ {
+		require.NoError(t, service.Failure(context.Background(), attempt, nil, ReasonUnknownEmail))
+	}
+	require.Error(t, service.Check(context.Background(), attempt))
+
+	// Execute
+	clock.advance(EmailPolicy.Window + time.Second)
+	require.NoError(t, service.Check(context.Background(), attempt))
+	require.NoError(t, service.Failure(context.Background(), attempt, nil, ReasonUnknownEmail))
+
+	// Assert
+	assert.NoError(t, service.Check(context.Background(), attempt), "old failures are forgotten")
+}
+
+func TestSuccess_ResetsEmailButNotAddress(t *testing.T) {
+	// Setup
+	service, _ := newTestService()
+	ip := "203.0.113.7"
+	for i := 0; i <= IPPolicy.FreeAttempts; i++ {
+		attempt := Attempt{Email: "guess" + string(rune('a'+i)) + "@example.com", IPAddress: ip}
+		require.NoError(t, service.Failure(context.Background(), attempt, nil, ReasonUnknownEmail))
+	}
+	victim := Attempt{Email: "ada@example.com", IPAddress: ip}
+	for i := 0; i <= EmailPolicy.FreeAttempts; i++ {
+		require.NoError(t, service.Failure(context.Background(), victim, nil, ReasonWrongPassword))
+	}
+
+	// Execute
+	require.NoError(t, service.Success(context.Background(), victim, uuid.New()))
+
+	// Assert
+	assert.NoError(t, service.Check(context.Background(), Attempt{Email: "ada@example.com"}))
+	assert.ErrorIs(t, service.Check(context.Background(), Attempt{Email: "other@example.com", IPAddress: ip}), ErrTooManyAttempts)
+}
+
+func TestListEvents_Filters(t *testing.T) {
+	// Setup
+	service, _ := newTestService()
+	userID := uuid.New()
+	require.NoError(t, service.Failure(context.Background(), Attempt{Email: "ada@example.com", IPAddress: "203.0.113.7"}, &userID, ReasonWrongPassword))
+	require.NoError(t, service.Success(context.Background(), Attempt{Email: "ada@example.com", IPAddress: "203.0.113.7", UserAgent: "curl"}, userID))
+	require.NoError(t, service.Failure(context.Background(), Attempt{Email: "bob@example.com"}, nil, ReasonUnknownEmail))
+
+	tests := []struct {
+		name    string
+		filters EventFilters
+		count   int
+		err     error
+	}{
+		{"all", EventFilters{}, 3, nil},
+		{"email is normalized", EventFilters{Email: " ADA@example.com"}, 2, nil},
+		{"user", EventFilters{UserID: &userID}, 2, nil},
+		{"address", EventFilters{IPAddress: "203.0.113.7"}, 2, nil},
+		{"event", EventFilters{Event: EventFailure}, 2, nil},
+		{"paged", EventFilters{Page: 2, Limit: 2}, 1, nil},
+		{"unknown event", EventFilters{Event: "logout"}, 0, ErrInvalidEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			events, err := service.ListEvents(context.Background(), tt.filters)
+
+			// Assert
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, events, tt.count)
+		})
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestService() (Service, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	s := NewService(NewMockRepository(), EmailPolicy, IPPolicy).(*service)
+	s.now = func() time.Time { return clock.now }
+	return s, clock
+}
+
+func TestForget(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository(), EmailPolicy, IPPolicy)
+	userID := uuid.New()
+	attempt := Attempt{Email: "Ada@Example.com", IPAddress: "203.0.113.7"}
+	other := Attempt{Email: "grace@example.com", IPAddress: "203.0.113.7"}
+	for i := 0; i < EmailPolicy.FreeAttempts+1; i++ {
+		require.NoError(t, service.Failure(context.Background(), attempt, &userID, ReasonWrongPassword))
+	}
+	require.NoError(t, service.Failure(context.Background(), other, nil, ReasonUnknownEmail))
+
+	// Execute
+	err := service.Forget(context.Background(), userID, attempt.Email)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, service.Check(context.Background(), Attempt{Email: attempt.Email}))
+	events, err := service.ListEvents(context.Background(), EventFilters{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "grace@example.com", events[0].Email)
+}
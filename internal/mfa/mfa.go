@@ -0,0 +1,45 @@
+package mfa
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Issuer is the account name authenticator apps show next to the code
+const Issuer = "Cintia"
+
+// RecoveryCodeCount is how many recovery codes each batch has
+const RecoveryCodeCount = 10
+
+// TOTP is a user's authenticator secret. It is pending until the first
+// code is confirmed, and only then asked for at login
+type TOTP struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	Secret       string     `json:"-"`
+	LastUsedStep int64      `json:"-"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Enrollment is what the user adds to their authenticator app
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type Status struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// CodeInput carries an authenticator code, or a recovery code where those
+// are accepted
+type CodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// IsEnabled reports whether the enrollment was confirmed
+func (t *TOTP) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
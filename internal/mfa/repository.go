@@ -0,0 +1,38 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound = errors.New("two-factor authentication not set up")
+	// ErrAlreadyEnabled is returned when enrolling again over a confirmed
+	// secret
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrStepUsed means a code of the same or a later time step was
+	// accepted before
+	ErrStepUsed = errors.New("code already used")
+	// ErrRecoveryCodeNotFound covers unknown and already used codes
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+)
+
+type Repository interface {
+	// StartEnrollment stores a new pending secret, replacing a pending one.
+	// It returns ErrAlreadyEnabled when 2FA is enabled
+	StartEnrollment(ctx context.Context, userID uuid.UUID, secret string) (*TOTP, error)
+	Get(ctx context.Context, userID uuid.UUID) (*TOTP, error)
+	// Enable returns ErrAlreadyEnabled when there is no pending secret
+	Enable(ctx context.Context, userID uuid.UUID) error
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// Delete removes the secret and the recovery codes
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	// ReplaceRecoveryCodes drops all recovery codes of the user and stores
+	// the given hashes
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
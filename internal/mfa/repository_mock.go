@@ -0,0 +1,125 @@
+package mfa
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu    sync.Mutex
+	totps map[uuid.UUID]*TOTP
+	// recovery codes by user, hash to whether it was used
+	codes map[uuid.UUID]map[string]bool
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		totps: make(map[uuid.UUID]*TOTP),
+		codes: make(map[uuid.UUID]map[string]bool),
+	}
+}
+
+func (m *mockRepository) StartEnrollment(ctx context.Context, userID uuid.UUID, secret string) (*TOTP, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, exists := m.totps[userID]; exists && existing.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	totp := &TOTP{UserID: userID, Secret: secret, CreatedAt: time.Now()}
+	m.totps[userID] = totp
+
+	stored := *totp
+	return &stored, nil
+}
+
+func (m *mockRepository) Get(ctx context.Context, userID uuid.UUID) (*TOTP, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totp, exists := m.totps[userID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	found := *totp
+	return &found, nil
+}
+
+func (m *mockRepository) Enable(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totp, exists := m.totps[userID]
+	if !exists || totp.IsEnabled() {
+		return ErrAlreadyEnabled
+	}
+
+	now := time.Now()
+	totp.EnabledAt = &now
+	return nil
+}
+
+func (m *mockRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totp, exists := m.totps[userID]
+	if !exists || totp.LastUsedStep >= step {
+		return ErrStepUsed
+	}
+
+	totp.LastUsedStep = step
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.totps, userID)
+	delete(m.codes, userID)
+	return nil
+}
+
+func (m *mockRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	codes := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		codes[hash] = false
+	}
+	m.codes[userID] = codes
+	return nil
+}
+
+func (m *mockRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	used, exists := m.codes[userID][hash]
+	if !exists || used {
+		return ErrRecoveryCodeNotFound
+	}
+
+	m.codes[userID][hash] = true
+	return nil
+}
+
+func (m *mockRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, used := range m.codes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
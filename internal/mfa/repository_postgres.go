@@ -0,0 +1,137 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) StartEnrollment(ctx context.Context, userID uuid.UUID, secret string) (*TOTP, error) {
+	dbTOTP, err := r.queries.UpsertUserTOTP(ctx, database.UpsertUserTOTPParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		// the upsert skips enabled secrets and returns no row
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAlreadyEnabled
+		}
+		return nil, err
+	}
+
+	return dbTOTPToTOTP(&dbTOTP), nil
+}
+
+func (r *PostgresRepository) Get(ctx context.Context, userID uuid.UUID) (*TOTP, error) {
+	dbTOTP, err := r.queries.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbTOTPToTOTP(&dbTOTP), nil
+}
+
+func (r *PostgresRepository) Enable(ctx context.Context, userID uuid.UUID) error {
+	rows, err := r.queries.EnableUserTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrAlreadyEnabled
+	}
+	return nil
+}
+
+func (r *PostgresRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	rows, err := r.queries.UseTOTPStep(ctx, database.UseTOTPStepParams{
+		UserID:       userID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrStepUsed
+	}
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	return r.queries.DeleteUserTOTP(ctx, userID)
+}
+
+func (r *PostgresRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error {
+	if err := r.queries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		err := r.queries.CreateRecoveryCode(ctx, database.CreateRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: hash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PostgresRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) error {
+	rows, err := r.queries.UseRecoveryCode(ctx, database.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: hash,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func dbTOTPToTOTP(dbTOTP *database.UserTotp) *TOTP {
+	return &TOTP{
+		UserID:       dbTOTP.UserID,
+		Secret:       dbTOTP.Secret,
+		LastUsedStep: dbTOTP.LastUsedStep,
+		EnabledAt:    fromNullTime(dbTOTP.EnabledAt),
+		CreatedAt:    dbTOTP.CreatedAt,
+	}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,234 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+)
+
+var (
+	ErrNotEnrolled = errors.New("start two-factor enrollment first")
+	ErrNotEnabled  = errors.New("two-factor authentication is not enabled")
+	ErrInvalidCode = errors.New("invalid two-factor code")
+)
+
+type Service interface {
+	// Enroll creates a pending secret for account, the label shown in
+	// authenticator apps. Enrolling again before confirming replaces it
+	Enroll(ctx context.Context, userID uuid.UUID, account string) (*Enrollment, error)
+	// Confirm enables 2FA with a first authenticator code and returns the
+	// recovery codes, which are not shown again
+	Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	// Verify checks an authenticator code or a recovery code. Each code is
+	// accepted once
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	Status(ctx context.Context, userID uuid.UUID) (*Status, error)
+}
+
+type service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo, now: time.Now}
+}
+
+func (s *service) Enroll(ctx context.Context, userID uuid.UUID, account string) (*Enrollment, error) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	if _, err := s.repo.StartEnrollment(ctx, userID, secret); err != nil {
+		if errors.Is(err, ErrAlreadyEnabled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to start enrollment: %w", err)
+	}
+
+	return &Enrollment{
+		Secret: secret,
+		URI:    auth.TOTPURI(Issuer, account, secret),
+	}, nil
+}
+
+func (s *service) Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	totp, err := s.get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrNotEnrolled
+		}
+		return nil, err
+	}
+	if totp.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	if err := s.checkTOTP(ctx, totp, code); err != nil {
+		return nil, err
+	}
+
+	// codes go in first, so an enabled account always has them
+	codes, err := s.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Enable(ctx, userID); err != nil {
+		if errors.Is(err, ErrAlreadyEnabled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	return codes, nil
+}
+
+func (s *service) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	totp, err := s.get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotEnabled
+		}
+		return err
+	}
+	if !totp.IsEnabled() {
+		return ErrNotEnabled
+	}
+
+	code = normalizeCode(code)
+	if len(code) == auth.TOTPDigits {
+		return s.checkTOTP(ctx, totp, code)
+	}
+
+	if err := s.repo.UseRecoveryCode(ctx, userID, auth.HashToken(code)); err != nil {
+		if errors.Is(err, ErrRecoveryCodeNotFound) {
+			return ErrInvalidCode
+		}
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return nil
+}
+
+func (s *service) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+	return nil
+}
+
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+func (s *service) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	totp, err := s.get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return totp.IsEnabled(), nil
+}
+
+func (s *service) Status(ctx context.Context, userID uuid.UUID) (*Status, error) {
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return &Status{}, nil
+	}
+
+	left, err := s.repo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return &Status{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+func (s *service) get(ctx context.Context, userID uuid.UUID) (*TOTP, error) {
+	totp, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get two-factor settings: %w", err)
+	}
+	return totp, nil
+}
+
+// checkTOTP accepts an authenticator code once. A code seen before, even
+// one still inside its time window, is refused so an observed code cannot
+// be replayed
+func (s *service) checkTOTP(ctx context.Context, totp *TOTP, code string) error {
+	step, valid, err := auth.ValidateTOTP(totp.Secret, code, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to check code: %w", err)
+	}
+	if !valid || step <= totp.LastUsedStep {
+		return ErrInvalidCode
+	}
+
+	if err := s.repo.UseStep(ctx, totp.UserID, step); err != nil {
+		if errors.Is(err, ErrStepUsed) {
+			return ErrInvalidCode
+		}
+		return fmt.Errorf("failed to record code: %w", err)
+	}
+	return nil
+}
+
+func (s *service) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, auth.HashToken(normalizeCode(code)))
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCode returns 50 random bits as "xxxxx-xxxxx"
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeCode lets users type codes with spaces, dashes or in upper case
+func normalizeCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
@@ -0,0 +1,109 @@
+package mfa
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm_EnablesAndRejectsReplayedCode(t *testing.T) {
+	// Setup
+	service, clock := newTestService()
+	userID := uuid.New()
+
+	enrollment, err := service.Enroll(context.Background(), userID, "ada@example.com")
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/Cintia:ada@example.com")
+
+	enabled, err := service.IsEnabled(context.Background(), userID)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	code := codeAt(t, enrollment.Secret, *clock)
+
+	// Execute
+	codes, err := service.Confirm(context.Background(), userID, code)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, codes, RecoveryCodeCount)
+
+	status, err := service.Status(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, &Status{Enabled: true, RecoveryCodesLeft: RecoveryCodeCount}, status)
+
+	// the confirming code cannot be used again, even in its time window
+	assert.ErrorIs(t, service.Verify(context.Background(), userID, code), ErrInvalidCode)
+
+	*clock = clock.Add(auth.TOTPPeriod)
+	assert.NoError(t, service.Verify(context.Background(), userID, codeAt(t, enrollment.Secret, *clock)))
+
+	_, err = service.Enroll(context.Background(), userID, "ada@example.com")
+	assert.ErrorIs(t, err, ErrAlreadyEnabled)
+}
+
+func TestConfirm_WrongCode(t *testing.T) {
+	// Setup
+	service, clock := newTestService()
+	userID := uuid.New()
+
+	enrollment, err := service.Enroll(context.Background(), userID, "ada@example.com")
+	require.NoError(t, err)
+
+	// Execute
+	_, err = service.Confirm(context.Background(), userID, codeAt(t, enrollment.Secret, clock.Add(-5*auth.TOTPPeriod)))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidCode)
+	_, err = service.Confirm(context.Background(), uuid.New(), "123456")
+	assert.ErrorIs(t, err, ErrNotEnrolled)
+}
+
+func TestVerify_RecoveryCodesAreSingleUse(t *testing.T) {
+	// Setup
+	service, clock := newTestService()
+	userID := uuid.New()
+
+	enrollment, err := service.Enroll(context.Background(), userID, "ada@example.com")
+	require.NoError(t, err)
+	codes, err := service.Confirm(context.Background(), userID, codeAt(t, enrollment.Secret, *clock))
+	require.NoError(t, err)
+
+	// Execute & Assert
+	assert.NoError(t, service.Verify(context.Background(), userID, " "+codes[0]+" "))
+	assert.ErrorIs(t, service.Verify(context.Background(), userID, codes[0]), ErrInvalidCode)
+	assert.NoError(t, service.Verify(context.Background(), userID, strings.ToUpper(strings.ReplaceAll(codes[1], "-", ""))))
+
+	status, err := service.Status(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, RecoveryCodeCount-2, status.RecoveryCodesLeft)
+
+	fresh, err := service.RegenerateRecoveryCodes(context.Background(), userID, codes[2])
+	require.NoError(t, err)
+	assert.ErrorIs(t, service.Verify(context.Background(), userID, codes[3]), ErrInvalidCode)
+
+	require.NoError(t, service.Disable(context.Background(), userID, fresh[0]))
+	enabled, err := service.IsEnabled(context.Background(), userID)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	assert.ErrorIs(t, service.Verify(context.Background(), userID, fresh[1]), ErrNotEnabled)
+}
+
+func newTestService() (Service, *time.Time) {
+	clock := time.Date(2026, time.March, 9, 10, 0, 0, 0, time.UTC)
+	return &service{repo: NewMockRepository(), now: func() time.Time { return clock }}, &clock
+}
+
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	code, err := auth.TOTPCode(secret, at)
+	require.NoError(t, err)
+	return code
+}
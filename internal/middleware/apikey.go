@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luis-octavius/cintia/internal/apikey"
+)
+
+// KeyAuthenticator resolves API keys, apikey.Service implements it
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*apikey.Identity, error)
+}
+
+// authenticateKey sets the same context keys as a token would, minus the
+// session, plus apiKeyID
+func authenticateKey(c *gin.Context, keys KeyAuthenticator, key string) {
+	if keys == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "api keys are not accepted here"})
+		c.Abort()
+		return
+	}
+
+	identity, err := keys.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidKey) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check api key"})
+		}
+		c.Abort()
+		return
+	}
+
+	if !identity.Allows(c.Request.Method) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "api key scopes do not allow this request"})
+		c.Abort()
+		return
+	}
+
+	c.Set("userID", identity.UserID.String())
+	c.Set("apiKeyID", identity.KeyID.String())
+	c.Set("userEmail", identity.Email)
+	c.Set("userRole", identity.Role)
+
+	c.Next()
+}
+
+// RequireSession refuses requests authenticated with an API key, for
+// account settings a leaked key must not be able to change. It must run
+// after AuthMiddleware
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, viaKey := c.Get("apiKeyID"); viaKey {
+			c.JSON(http.StatusForbidden, gin.H{"error": "log in to do this, api keys are not accepted"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
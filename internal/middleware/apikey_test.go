@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/apikey"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	// Setup
+	keys := apikey.NewService(apikey.NewMockRepository())
+	userID := uuid.New()
+	readOnly, err := keys.Create(context.Background(), userID, apikey.CreateInput{Name: "reports", Scopes: []string{"read"}})
+	require.NoError(t, err)
+	revoked, err := keys.Create(context.Background(), userID, apikey.CreateInput{Name: "old", Scopes: []string{"write"}})
+	require.NoError(t, err)
+	require.NoError(t, keys.Revoke(context.Background(), userID, revoked.APIKey.ID))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		value  string
+		status int
+	}{
+		{"bearer key", "GET", "/me", "Authorization", "Bearer " + readOnly.Key, http.StatusOK},
+		{"x-api-key header", "GET", "/me", "X-API-Key", readOnly.Key, http.StatusOK},
+		{"read key writing", "POST", "/me", "Authorization", "Bearer " + readOnly.Key, http.StatusForbidden},
+		{"revoked key", "GET", "/me", "Authorization", "Bearer " + revoked.Key, http.StatusUnauthorized},
+		{"unknown key", "GET", "/me", "X-API-Key", apikey.Prefix + "nope", http.StatusUnauthorized},
+		{"session only route", "GET", "/settings", "X-API-Key", readOnly.Key, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(AuthMiddleware(testKeys, session.NewService(session.NewMockRepository()), keys))
+			router.Handle(tt.method, "/me", func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetString("userID"))
+			})
+			router.GET("/settings", RequireSession(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(tt.header, tt.value)
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK && tt.path == "/me" {
+				assert.Equal(t, userID.String(), w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_KeysNotAccepted(t *testing.T) {
+	// Setup
+	keys := apikey.NewService(apikey.NewMockRepository())
+	created, err := keys.Create(context.Background(), uuid.New(), apikey.CreateInput{Name: "ops", Scopes: []string{"read", "write"}})
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/users", AuthMiddleware(testKeys, session.NewService(session.NewMockRepository()), nil), RequireRole(auth.RoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, header := range []string{"Authorization", "X-API-Key"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/admin/users", nil)
+		value := created.Key
+		if header == "Authorization" {
+			value = "Bearer " + value
+		}
+		req.Header.Set(header, value)
+
+		// Execute
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, w.Code, header)
+	}
+}
@@ -1,15 +1,36 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/apikey"
 	"github.com/luis-octavius/cintia/internal/auth"
 )
 
-func AuthMiddleware(secret string) gin.HandlerFunc {
+// SessionChecker tells whether the session an access token was issued for
+// is still active, session.Service implements it
+type SessionChecker interface {
+	IsActive(ctx context.Context, familyID uuid.UUID) (bool, error)
+}
+
+// TokenValidator checks access tokens, *auth.KeySet implements it
+type TokenValidator interface {
+	ValidateJWT(tokenString string) (*auth.UserClaims, error)
+}
+
+// AuthMiddleware accepts an access token, or an API key either as the
+// bearer token or in the X-API-Key header
+func AuthMiddleware(tokens TokenValidator, sessions SessionChecker, keys KeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := c.GetHeader("X-API-Key"); key != "" {
+			authenticateKey(c, keys, key)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header required"})
@@ -25,8 +46,12 @@ func AuthMiddleware(secret string) gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
+		if apikey.IsKey(tokenString) {
+			authenticateKey(c, keys, tokenString)
+			return
+		}
 
-		claims, err := auth.ValidateJWT(tokenString, secret)
+		claims, err := tokens.ValidateJWT(tokenString)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error":   "invalid token",
@@ -36,7 +61,29 @@ func AuthMiddleware(secret string) gin.HandlerFunc {
 			return
 		}
 
+		// tokens issued before sessions existed carry no session id and
+		// cannot be revoked, so they are refused
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "token has no session, log in again"})
+			c.Abort()
+			return
+		}
+
+		active, err := sessions.IsActive(c.Request.Context(), sessionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check session"})
+			c.Abort()
+			return
+		}
+		if !active {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "session has been revoked"})
+			c.Abort()
+			return
+		}
+
 		c.Set("userID", claims.UserID)
+		c.Set("sessionID", claims.SessionID)
 		c.Set("userEmail", claims.Email)
 		c.Set("userRole", claims.Role)
 
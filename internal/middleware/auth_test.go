@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKeys = newTestKeys()
+
+func TestAuthMiddleware_ActiveSession(t *testing.T) {
+	// Setup
+	sessions := session.NewService(session.NewMockRepository())
+	userID := uuid.New()
+	issued, err := sessions.Start(context.Background(), userID, session.Client{})
+	require.NoError(t, err)
+
+	token, err := testKeys.MakeJWT(userID, issued.Session.FamilyID, "test@example.com", "user")
+	require.NoError(t, err)
+
+	// Execute
+	w := serve(sessions, token)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, userID.String(), w.Body.String())
+}
+
+func TestAuthMiddleware_RevokedSession(t *testing.T) {
+	// Setup
+	sessions := session.NewService(session.NewMockRepository())
+	userID := uuid.New()
+	issued, err := sessions.Start(context.Background(), userID, session.Client{})
+	require.NoError(t, err)
+
+	token, err := testKeys.MakeJWT(userID, issued.Session.FamilyID, "test@example.com", "user")
+	require.NoError(t, err)
+	require.NoError(t, sessions.Revoke(context.Background(), userID, issued.Session.FamilyID))
+
+	// Execute
+	w := serve(sessions, token)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthMiddleware_UnknownSession(t *testing.T) {
+	// Setup
+	sessions := session.NewService(session.NewMockRepository())
+	token, err := testKeys.MakeJWT(uuid.New(), uuid.New(), "test@example.com", "user")
+	require.NoError(t, err)
+
+	// Execute
+	w := serve(sessions, token)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func serve(sessions SessionChecker, token string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", AuthMiddleware(testKeys, sessions, nil), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("userID"))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func newTestKeys() *auth.KeySet {
+	key, err := auth.GenerateSigningKey()
+	if err != nil {
+		panic(err)
+	}
+	keys, err := auth.NewKeySet(auth.DefaultIssuer, key)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luis-octavius/cintia/internal/auth"
+)
+
+// RequireRole lets through users with one of the roles. It must run after
+// AuthMiddleware, which sets the role
+func RequireRole(roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := roleFromContext(c)
+		if !ok {
+			return
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "your role does not allow this action"})
+		c.Abort()
+	}
+}
+
+// RequirePermission lets through users whose role grants the permission.
+// It must run after AuthMiddleware, which sets the role
+func RequirePermission(permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := roleFromContext(c)
+		if !ok {
+			return
+		}
+
+		if !role.Can(permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "missing permission " + string(permission)})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func roleFromContext(c *gin.Context) (auth.Role, bool) {
+	role, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		c.Abort()
+		return "", false
+	}
+
+	roleStr, _ := role.(string)
+	return auth.Role(roleStr), true
+}
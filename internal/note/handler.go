@@ -0,0 +1,236 @@
+package note
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	CreateNoteHandler(c *gin.Context)
+	GetApplicationNotesHandler(c *gin.Context)
+	UpdateNoteHandler(c *gin.Context)
+	DeleteNoteHandler(c *gin.Context)
+	SearchNotesHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/applications/:id/notes - add a note to the timeline of an application
+func (h *GinHandler) CreateNoteHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	var req NoteInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	note, err := h.service.CreateNote(c.Request.Context(), userID, applicationID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "note created successfully",
+		"note":    note,
+	})
+}
+
+// GET /api/applications/:id/notes - timeline of an application, newest first
+func (h *GinHandler) GetApplicationNotesHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	notes, err := h.service.GetApplicationNotes(c.Request.Context(), userID, applicationID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notes": notes,
+		"total": len(notes),
+	})
+}
+
+// PUT /api/applications/:id/notes/:noteID - edit a note
+func (h *GinHandler) UpdateNoteHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	noteID, ok := parseID(c, "noteID", "invalid note id format")
+	if !ok {
+		return
+	}
+
+	var req NoteInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	note, err := h.service.UpdateNote(c.Request.Context(), userID, applicationID, noteID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "note updated successfully",
+		"note":    note,
+	})
+}
+
+// DELETE /api/applications/:id/notes/:noteID - delete a note
+func (h *GinHandler) DeleteNoteHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	noteID, ok := parseID(c, "noteID", "invalid note id format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteNote(c.Request.Context(), userID, applicationID, noteID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "note deleted successfully",
+	})
+}
+
+// GET /api/notes/search?q=&limit=20 - full-text search across the user's notes
+func (h *GinHandler) SearchNotesHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit must be a positive number",
+			})
+			return
+		}
+	}
+
+	results, err := h.service.SearchNotes(c.Request.Context(), userID, c.Query("q"), limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"total":   len(results),
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrApplicationNotFound),
+		errors.Is(err, ErrNoteNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrEmptyBody),
+		errors.Is(err, ErrBodyTooLong),
+		errors.Is(err, ErrEmptyQuery):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
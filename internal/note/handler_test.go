@@ -0,0 +1,197 @@
+package note
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNoteHandler_Success(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, _ := newTestHandler(userID, appID)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "POST", "/applications/"+appID.String()+"/notes", userID, NoteInput{Body: "## Screening\n\n- asked about **Go** generics\n"})
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+	// Execute
+	handler.CreateNoteHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Note Note `json:"note"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "## Screening\n\n- asked about **Go** generics", response.Note.Body)
+	assert.Equal(t, appID, response.Note.ApplicationID)
+	assert.False(t, response.Note.Edited)
+}
+
+func TestCreateNoteHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"blank body", "  \n\t "},
+		{"too long", strings.Repeat("a", MaxBodyLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			userID := uuid.New()
+			appID := uuid.New()
+			handler, _ := newTestHandler(userID, appID)
+
+			w := httptest.NewRecorder()
+			c := jsonContext(w, "POST", "/applications/"+appID.String()+"/notes", userID, NoteInput{Body: tt.body})
+			c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+			// Execute
+			handler.CreateNoteHandler(c)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestUpdateNoteHandler_MarksEdited(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(userID, appID)
+
+	note, err := service.CreateNote(context.Background(), userID, appID, NoteInput{Body: "first draft"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "PUT", "/applications/"+appID.String()+"/notes/"+note.ID.String(), userID, NoteInput{Body: "final version"})
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}, {Key: "noteID", Value: note.ID.String()}}
+
+	// Execute
+	handler.UpdateNoteHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	notes, err := service.GetApplicationNotes(context.Background(), userID, appID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "final version", notes[0].Body)
+	assert.True(t, notes[0].Edited)
+}
+
+func TestDeleteNoteHandler_OtherUser(t *testing.T) {
+	// Setup
+	owner := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(owner, appID)
+
+	note, err := service.CreateNote(context.Background(), owner, appID, NoteInput{Body: "private"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "DELETE", "/applications/"+appID.String()+"/notes/"+note.ID.String(), uuid.New(), nil)
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}, {Key: "noteID", Value: note.ID.String()}}
+
+	// Execute
+	handler.DeleteNoteHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	notes, _ := service.GetApplicationNotes(context.Background(), owner, appID)
+	assert.Len(t, notes, 1)
+}
+
+func TestSearchNotesHandler(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, service := newTestHandler(userID, appID)
+
+	for _, body := range []string{"Talked about Kubernetes and Go", "Salary range discussed", "Go Go Go, recruiter loved Go"} {
+		_, err := service.CreateNote(context.Background(), userID, appID, NoteInput{Body: body})
+		require.NoError(t, err)
+	}
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/notes/search?q=go+-salary&limit=5", userID, nil)
+
+	// Execute
+	handler.SearchNotesHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Results []SearchResult `json:"results"`
+		Total   int            `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, 2, response.Total)
+	assert.Equal(t, "**Go** **Go** **Go**, recruiter loved **Go**", response.Results[0].Snippet)
+	assert.Equal(t, appID, response.Results[0].ApplicationID)
+}
+
+func TestSearchNotesHandler_EmptyQuery(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler, _ := newTestHandler(userID, uuid.New())
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/notes/search?q=+", userID, nil)
+
+	// Execute
+	handler.SearchNotesHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func newTestHandler(userID, appID uuid.UUID) (*GinHandler, Service) {
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		appID: {ID: appID, UserID: userID},
+	}}
+	service := NewService(NewMockRepository(), apps)
+	return NewGinHandler(service), service
+}
+
+func jsonContext(w *httptest.ResponseRecorder, method, path string, userID uuid.UUID, body any) *gin.Context {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+	return c
+}
+
+// Stub application service for testing, only GetApplicationByID is used
+type stubApplicationService struct {
+	application.Service
+	apps map[uuid.UUID]*application.Application
+}
+
+func (s *stubApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*application.Application, error) {
+	app, exists := s.apps[id]
+	if !exists {
+		return nil, application.ErrApplicationNotFound
+	}
+	return app, nil
+}
@@ -0,0 +1,39 @@
+package note
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBodyLength bounds a note body, in characters
+const MaxBodyLength = 20000
+
+// Note is an entry of an application's timeline. The body is markdown,
+// stored and returned as written
+type Note struct {
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Edited        bool      `json:"edited"`
+}
+
+// SearchResult is a note matching a search, with the matching fragments
+// highlighted in bold markdown
+type SearchResult struct {
+	Note
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+type NoteInput struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// IsOwnedBy checks whether the note belongs to the user
+func (n *Note) IsOwnedBy(userID uuid.UUID) bool {
+	return n.UserID == userID
+}
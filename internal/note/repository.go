@@ -0,0 +1,26 @@
+package note
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("note not found")
+
+type Repository interface {
+	Create(ctx context.Context, note *Note) (*Note, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Note, error)
+	// GetApplicationNotes returns the timeline of an application, newest
+	// first
+	GetApplicationNotes(ctx context.Context, applicationID uuid.UUID) ([]*Note, error)
+	// GetUserNotes returns the notes of all the user's applications, newest
+	// first
+	GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*Note, error)
+	Update(ctx context.Context, note *Note) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Search returns the user's notes matching a web search style query,
+	// best matches first
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*SearchResult, error)
+}
@@ -0,0 +1,211 @@
+package note
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu    sync.RWMutex
+	notes map[uuid.UUID]*Note
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		notes: make(map[uuid.UUID]*Note),
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, note *Note) (*Note, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if note.ID == uuid.Nil {
+		note.ID = uuid.New()
+	}
+
+	now := time.Now()
+	note.CreatedAt = now
+	note.UpdatedAt = now
+
+	stored := *note
+	m.notes[note.ID] = &stored
+	return note, nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	note, exists := m.notes[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	found := *note
+	return &found, nil
+}
+
+func (m *mockRepository) GetApplicationNotes(ctx context.Context, applicationID uuid.UUID) ([]*Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	notes := []*Note{}
+	for _, note := range m.notes {
+		if note.ApplicationID == applicationID {
+			found := *note
+			notes = append(notes, &found)
+		}
+	}
+
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.After(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+func (m *mockRepository) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	notes := []*Note{}
+	for _, note := range m.notes {
+		if note.UserID == userID {
+			found := *note
+			notes = append(notes, &found)
+		}
+	}
+
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.After(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, note *Note) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.notes[note.ID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	existing.Body = note.Body
+	existing.UpdatedAt = time.Now()
+	existing.Edited = true
+	*note = *existing
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.notes, id)
+	return nil
+}
+
+// Search approximates the full-text query: every plain word of the query
+// must appear in the note and words prefixed with - must not. Quotes and
+// OR are not supported
+func (m *mockRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*SearchResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var include, exclude []string
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if strings.HasPrefix(term, "-") {
+			exclude = append(exclude, strings.TrimPrefix(term, "-"))
+		} else {
+			include = append(include, term)
+		}
+	}
+
+	results := []*SearchResult{}
+	for _, note := range m.notes {
+		if note.UserID != userID || len(include) == 0 {
+			continue
+		}
+
+		words := map[string]int{}
+		for _, word := range splitWords(note.Body) {
+			words[strings.ToLower(word)]++
+		}
+
+		matches, ok := 0, true
+		for _, term := range include {
+			if words[term] == 0 {
+				ok = false
+				break
+			}
+			matches += words[term]
+		}
+		for _, term := range exclude {
+			if words[term] > 0 {
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		results = append(results, &SearchResult{
+			Note:    *note,
+			Snippet: highlight(note.Body, include),
+			Rank:    float64(matches),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// highlight wraps the words of body matching a term in **
+func highlight(body string, terms []string) string {
+	match := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		match[term] = true
+	}
+
+	var b strings.Builder
+	word := []rune{}
+	flush := func() {
+		if len(word) > 0 && match[strings.ToLower(string(word))] {
+			b.WriteString("**" + string(word) + "**")
+		} else {
+			b.WriteString(string(word))
+		}
+		word = word[:0]
+	}
+	for _, r := range body {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
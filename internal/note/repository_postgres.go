@@ -0,0 +1,131 @@
+package note
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, note *Note) (*Note, error) {
+	dbNote, err := r.queries.CreateNote(ctx, database.CreateNoteParams{
+		ApplicationID: note.ApplicationID,
+		UserID:        note.UserID,
+		Body:          note.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbNoteToNote(&dbNote), nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*Note, error) {
+	dbNote, err := r.queries.GetNoteByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbNoteToNote(&dbNote), nil
+}
+
+func (r *PostgresRepository) GetApplicationNotes(ctx context.Context, applicationID uuid.UUID) ([]*Note, error) {
+	dbNotes, err := r.queries.GetApplicationNotes(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]*Note, len(dbNotes))
+	for i := range dbNotes {
+		notes[i] = dbNoteToNote(&dbNotes[i])
+	}
+	return notes, nil
+}
+
+func (r *PostgresRepository) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*Note, error) {
+	dbNotes, err := r.queries.GetUserNotes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]*Note, len(dbNotes))
+	for i := range dbNotes {
+		notes[i] = dbNoteToNote(&dbNotes[i])
+	}
+	return notes, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, note *Note) error {
+	dbNote, err := r.queries.UpdateNote(ctx, database.UpdateNoteParams{
+		ID:   note.ID,
+		Body: note.Body,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	*note = *dbNoteToNote(&dbNote)
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteNote(ctx, id)
+}
+
+func (r *PostgresRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*SearchResult, error) {
+	rows, err := r.queries.SearchNotes(ctx, database.SearchNotesParams{
+		UserID: userID,
+		Query:  query,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*SearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = &SearchResult{
+			Note: *dbNoteToNote(&database.Note{
+				ID:            row.ID,
+				ApplicationID: row.ApplicationID,
+				UserID:        row.UserID,
+				Body:          row.Body,
+				CreatedAt:     row.CreatedAt,
+				UpdatedAt:     row.UpdatedAt,
+			}),
+			Snippet: row.Snippet,
+			Rank:    row.Rank,
+		}
+	}
+	return results, nil
+}
+
+func dbNoteToNote(dbNote *database.Note) *Note {
+	return &Note{
+		ID:            dbNote.ID,
+		ApplicationID: dbNote.ApplicationID,
+		UserID:        dbNote.UserID,
+		Body:          dbNote.Body,
+		CreatedAt:     dbNote.CreatedAt,
+		UpdatedAt:     dbNote.UpdatedAt,
+		Edited:        dbNote.UpdatedAt.After(dbNote.CreatedAt),
+	}
+}
@@ -0,0 +1,192 @@
+package note
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+)
+
+var (
+	ErrNoteNotFound        = errors.New("note not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrForbidden           = errors.New("application belongs to another user")
+	ErrEmptyBody           = errors.New("note body is required")
+	ErrBodyTooLong         = fmt.Errorf("note body exceeds %d characters", MaxBodyLength)
+	ErrEmptyQuery          = errors.New("search query is required")
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type Service interface {
+	CreateNote(ctx context.Context, userID, applicationID uuid.UUID, input NoteInput) (*Note, error)
+	GetApplicationNotes(ctx context.Context, userID, applicationID uuid.UUID) ([]*Note, error)
+	GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*Note, error)
+	UpdateNote(ctx context.Context, userID, applicationID, id uuid.UUID, input NoteInput) (*Note, error)
+	DeleteNote(ctx context.Context, userID, applicationID, id uuid.UUID) error
+	SearchNotes(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*SearchResult, error)
+}
+
+type service struct {
+	repo       Repository
+	appService application.Service
+}
+
+func NewService(repo Repository, appService application.Service) Service {
+	return &service{
+		repo:       repo,
+		appService: appService,
+	}
+}
+
+func (s *service) CreateNote(ctx context.Context, userID, applicationID uuid.UUID, input NoteInput) (*Note, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	body, err := validateBody(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := s.repo.Create(ctx, &Note{
+		ApplicationID: applicationID,
+		UserID:        userID,
+		Body:          body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+	return note, nil
+}
+
+func (s *service) GetApplicationNotes(ctx context.Context, userID, applicationID uuid.UUID) ([]*Note, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.repo.GetApplicationNotes(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	return notes, nil
+}
+
+func (s *service) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]*Note, error) {
+	notes, err := s.repo.GetUserNotes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	return notes, nil
+}
+
+func (s *service) UpdateNote(ctx context.Context, userID, applicationID, id uuid.UUID, input NoteInput) (*Note, error) {
+	note, err := s.getOwnedNote(ctx, userID, applicationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if note.Body, err = validateBody(input.Body); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, note); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to update note: %w", err)
+	}
+	return note, nil
+}
+
+func (s *service) DeleteNote(ctx context.Context, userID, applicationID, id uuid.UUID) error {
+	if _, err := s.getOwnedNote(ctx, userID, applicationID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	return nil
+}
+
+// SearchNotes runs a full-text search over all the user's notes. Zero
+// limit falls back to the default, larger limits are capped
+func (s *service) SearchNotes(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	results, err := s.repo.Search(ctx, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search notes: %w", err)
+	}
+	return results, nil
+}
+
+// authorize makes sure the application exists and belongs to the user
+func (s *service) authorize(ctx context.Context, userID, applicationID uuid.UUID) error {
+	app, err := s.appService.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, application.ErrApplicationNotFound) {
+			return ErrApplicationNotFound
+		}
+		return err
+	}
+
+	if app.UserID != userID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// getOwnedNote loads a note making sure it belongs to the given
+// application and that the application belongs to the user
+func (s *service) getOwnedNote(ctx context.Context, userID, applicationID, id uuid.UUID) (*Note, error) {
+	if err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	note, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, err
+	}
+
+	if note.ApplicationID != applicationID || !note.IsOwnedBy(userID) {
+		return nil, ErrNoteNotFound
+	}
+
+	return note, nil
+}
+
+// validateBody trims surrounding whitespace but otherwise keeps the markdown
+// as written
+func validateBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrEmptyBody
+	}
+	if utf8.RuneCountInString(body) > MaxBodyLength {
+		return "", ErrBodyTooLong
+	}
+	return body, nil
+}
@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AccountEmail is an email about the user's account. Unlike notification
+// kinds these are sent on request and cannot be turned off
+type AccountEmail string
+
+const (
+	EmailPasswordReset     AccountEmail = "password_reset"
+	EmailEmailVerification AccountEmail = "email_verification"
+	EmailAccountDeletion   AccountEmail = "account_deletion"
+)
+
+// Recipient is who an account email goes to
+type Recipient struct {
+	Email  string
+	Name   string
+	Locale string
+}
+
+// AccountEmailData is what account email templates render
+type AccountEmailData struct {
+	Name      string
+	Link      string
+	ExpiresAt time.Time
+}
+
+// RenderAccountEmail renders an account email in the recipient's locale
+func (r *Renderer) RenderAccountEmail(email AccountEmail, locale string, data *AccountEmailData) (*Message, error) {
+	return r.render(locale, string(email), data)
+}
+
+// AccountMailer sends password reset, email verification and account
+// deletion links. The
+// links point at paths under baseURL carrying the token as a query
+// parameter
+type AccountMailer struct {
+	mailer   Mailer
+	renderer *Renderer
+	baseURL  string
+}
+
+func NewAccountMailer(mailer Mailer, renderer *Renderer, baseURL string) *AccountMailer {
+	return &AccountMailer{
+		mailer:   mailer,
+		renderer: renderer,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// SendPasswordReset mails the link to the page where a new password is
+// chosen
+func (a *AccountMailer) SendPasswordReset(ctx context.Context, to Recipient, token string, expiresAt time.Time) error {
+	return a.send(ctx, EmailPasswordReset, to, "/reset-password", token, expiresAt)
+}
+
+// SendEmailVerification mails the link that confirms the address, which
+// hits the API directly
+func (a *AccountMailer) SendEmailVerification(ctx context.Context, to Recipient, token string, expiresAt time.Time) error {
+	return a.send(ctx, EmailEmailVerification, to, "/api/users/email/verify", token, expiresAt)
+}
+
+// SendDeletionConfirmation mails the link to the page that confirms the
+// deletion of the account, for users who sign in without a password
+func (a *AccountMailer) SendDeletionConfirmation(ctx context.Context, to Recipient, token string, expiresAt time.Time) error {
+	return a.send(ctx, EmailAccountDeletion, to, "/confirm-deletion", token, expiresAt)
+}
+
+func (a *AccountMailer) send(ctx context.Context, email AccountEmail, to Recipient, path, token string, expiresAt time.Time) error {
+	msg, err := a.renderer.RenderAccountEmail(email, to.Locale, &AccountEmailData{
+		Name:      to.Name,
+		Link:      a.baseURL + path + "?token=" + url.QueryEscape(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.mailer.Send(ctx, to.Email, msg)
+}
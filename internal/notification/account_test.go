@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingMailer struct {
+	to       []string
+	messages []*Message
+}
+
+func (r *recordingMailer) Send(ctx context.Context, to string, msg *Message) error {
+	r.to = append(r.to, to)
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func TestAccountMailer_AllEmailsAndLocales(t *testing.T) {
+	r := testRenderer(t)
+
+	for _, locale := range r.Locales() {
+		mailer := &recordingMailer{}
+		accounts := NewAccountMailer(mailer, r, "https://cintia.dev/")
+		to := Recipient{Email: "maria@example.com", Name: "Maria", Locale: locale}
+
+		if err := accounts.SendPasswordReset(context.Background(), to, "reset+token", testNow.Add(time.Hour)); err != nil {
+			t.Fatalf("%s: password reset: %v", locale, err)
+		}
+		if err := accounts.SendEmailVerification(context.Background(), to, "verify-token", testNow.Add(48*time.Hour)); err != nil {
+			t.Fatalf("%s: email verification: %v", locale, err)
+		}
+		if err := accounts.SendDeletionConfirmation(context.Background(), to, "delete-token", testNow.Add(time.Hour)); err != nil {
+			t.Fatalf("%s: deletion confirmation: %v", locale, err)
+		}
+
+		links := []string{
+			"https://cintia.dev/reset-password?token=reset%2Btoken",
+			"https://cintia.dev/api/users/email/verify?token=verify-token",
+			"https://cintia.dev/confirm-deletion?token=delete-token",
+		}
+		for i, msg := range mailer.messages {
+			if mailer.to[i] != to.Email {
+				t.Errorf("%s: expected mail to %s, got %s", locale, to.Email, mailer.to[i])
+			}
+			if msg.Subject == "" || strings.Contains(msg.Subject, "\n") {
+				t.Errorf("%s: expected single line subject, got %q", locale, msg.Subject)
+			}
+			if !strings.Contains(msg.Text, "Maria") || !strings.Contains(msg.Text, links[i]) {
+				t.Errorf("%s: expected text to greet the user and carry %s, got %q", locale, links[i], msg.Text)
+			}
+			if !strings.Contains(msg.HTML, "<html") || strings.Contains(msg.HTML, "notification") || strings.Contains(msg.HTML, "notificação") {
+				t.Errorf("%s: expected html with the account footer, got %q", locale, msg.HTML)
+			}
+		}
+	}
+}
+
+func TestFileMailer_WritesEmail(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer, err := NewFileMailer(dir, "Cintia <no-reply@cintia.dev>")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := &Message{Subject: "Hello", Text: "plain body", HTML: "<p>html body</p>"}
+	if err := mailer.Send(context.Background(), "maria@example.com", msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one email file, got %v (%v)", entries, err)
+	}
+	if name := entries[0].Name(); !strings.HasSuffix(name, "-maria_example.com.eml") {
+		t.Errorf("unexpected file name %q", name)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	for _, want := range []string{"To: maria@example.com", "Subject: Hello", "plain body"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected email to contain %q", want)
+		}
+	}
+}
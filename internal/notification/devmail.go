@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LogMailer writes emails to a logger instead of sending them, useful in
+// development
+type LogMailer struct {
+	logger *log.Logger
+}
+
+func NewLogMailer(logger *log.Logger) *LogMailer {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogMailer{logger: logger}
+}
+
+func (l *LogMailer) Send(ctx context.Context, to string, msg *Message) error {
+	if _, err := parseRecipient(to); err != nil {
+		return err
+	}
+
+	l.logger.Printf("email to %s: %s\n%s", to, msg.Subject, msg.Text)
+	return nil
+}
+
+// FileMailer writes each email as an .eml file into a directory, so it can
+// be opened with a mail client during development
+type FileMailer struct {
+	dir  string
+	from *mail.Address
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	return &FileMailer{dir: dir, from: address}, nil
+}
+
+func (f *FileMailer) Send(ctx context.Context, to string, msg *Message) error {
+	recipient, err := parseRecipient(to)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	data, err := buildEmail(f.from, recipient, msg, now)
+	if err != nil {
+		return err
+	}
+
+	// the recipient goes in the name to find emails quickly; anything
+	// but letters, digits, dots and dashes is replaced
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, recipient.Address)
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), safe)
+
+	if err := os.WriteFile(filepath.Join(f.dir, name), data, 0o640); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
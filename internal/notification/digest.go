@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+// digestRecipient is the user a weekly digest goes to
+type digestRecipient struct {
+	userID uuid.UUID
+	email  string
+	name   string
+	locale string
+	muted  []string
+}
+
+// digestApplication is an application sent or moved around the period
+type digestApplication struct {
+	recipient digestRecipient
+	appliedAt time.Time
+	updatedAt time.Time
+	stageKind pipeline.StageKind
+}
+
+// digestInterview is an interview round around the period
+type digestInterview struct {
+	recipient   digestRecipient
+	scheduledAt time.Time
+}
+
+// digestPeriod returns the week covered by the digest due at now. Digests
+// are due every Monday at midnight UTC and cover the week before, the same
+// weeks as the application stats
+func digestPeriod(now time.Time) (start, end time.Time) {
+	now = now.UTC()
+	offset := (int(now.Weekday()) + 6) % 7
+	end = time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, time.UTC)
+	return end.AddDate(0, 0, -7), end
+}
+
+// buildDigests sums up the activity of each user from start to end and the
+// interviews of the week after. Offers and rejections count applications
+// last moved during the period into a stage of that kind. Users with
+// nothing to tell get no digest
+func buildDigests(start, end time.Time, apps []digestApplication, interviews []digestInterview) []*Notification {
+	upcomingEnd := end.AddDate(0, 0, 7)
+	within := func(t, from, to time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	digests := make(map[uuid.UUID]*Notification)
+	digestOf := func(r digestRecipient) *DigestSummary {
+		n, exists := digests[r.userID]
+		if !exists {
+			n = &Notification{
+				Kind:     KindWeeklyDigest,
+				SourceID: r.userID,
+				DueAt:    end,
+				UserID:   r.userID,
+				Email:    r.email,
+				Name:     r.name,
+				Locale:   r.locale,
+				Muted:    IsMuted(r.muted, KindWeeklyDigest),
+				Digest:   &DigestSummary{PeriodStart: start, PeriodEnd: end},
+			}
+			digests[r.userID] = n
+		}
+		return n.Digest
+	}
+
+	for _, app := range apps {
+		digest := digestOf(app.recipient)
+		if within(app.appliedAt, start, end) {
+			digest.Applied++
+		}
+		if !within(app.updatedAt, start, end) {
+			continue
+		}
+		switch {
+		case app.stageKind.ReachedOffer():
+			digest.Offers++
+		case app.stageKind == pipeline.KindRejected:
+			digest.Rejections++
+		}
+	}
+
+	for _, interview := range interviews {
+		digest := digestOf(interview.recipient)
+		switch {
+		case within(interview.scheduledAt, start, end):
+			digest.Interviews++
+		case within(interview.scheduledAt, end, upcomingEnd):
+			digest.UpcomingInterviews++
+		}
+	}
+
+	built := make([]*Notification, 0, len(digests))
+	for _, n := range digests {
+		d := n.Digest
+		if d.Applied+d.Interviews+d.Offers+d.Rejections+d.UpcomingInterviews == 0 {
+			continue
+		}
+		built = append(built, n)
+	}
+
+	sort.Slice(built, func(i, j int) bool {
+		return built[i].UserID.String() < built[j].UserID.String()
+	})
+	return built
+}
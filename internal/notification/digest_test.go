@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+func TestDigestPeriod(t *testing.T) {
+	// Wednesday, the digest due is the one of Monday March 9
+	start, end := digestPeriod(time.Date(2026, time.March, 11, 15, 0, 0, 0, time.UTC))
+	if !end.Equal(time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC)) || !start.Equal(end.AddDate(0, 0, -7)) {
+		t.Fatalf("expected the week before Monday March 9, got %s to %s", start, end)
+	}
+
+	// Monday midnight starts the next period
+	if _, end := digestPeriod(time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)); end.Day() != 16 {
+		t.Fatalf("expected the period to end on March 16, got %s", end)
+	}
+}
+
+func TestBuildDigests(t *testing.T) {
+	start, end := digestPeriod(testNow)
+	maria := digestRecipient{userID: uuid.New(), email: "maria@example.com", name: "Maria", locale: "en"}
+	joao := digestRecipient{userID: uuid.New(), email: "joao@example.com", name: "João", locale: "pt-BR", muted: []string{"weekly_digest"}}
+	idle := digestRecipient{userID: uuid.New(), email: "idle@example.com", name: "Idle"}
+
+	during := start.Add(48 * time.Hour)
+	before := start.Add(-48 * time.Hour)
+	apps := []digestApplication{
+		{recipient: maria, appliedAt: during, updatedAt: during, stageKind: pipeline.KindActive},
+		{recipient: maria, appliedAt: during, updatedAt: during, stageKind: pipeline.KindInterview},
+		{recipient: maria, appliedAt: before, updatedAt: during, stageKind: pipeline.KindOffer},
+		{recipient: maria, appliedAt: before, updatedAt: during, stageKind: pipeline.KindRejected},
+		{recipient: joao, appliedAt: before, updatedAt: during, stageKind: pipeline.KindAccepted},
+		// Moved after the period, it belongs to next week's digest
+		{recipient: idle, appliedAt: before, updatedAt: end.Add(time.Hour), stageKind: pipeline.KindRejected},
+	}
+	interviews := []digestInterview{
+		{recipient: maria, scheduledAt: during},
+		{recipient: maria, scheduledAt: end.Add(72 * time.Hour)},
+		{recipient: maria, scheduledAt: end.AddDate(0, 0, 8)},
+		{recipient: joao, scheduledAt: end.Add(time.Hour)},
+	}
+
+	digests := buildDigests(start, end, apps, interviews)
+	if len(digests) != 2 {
+		t.Fatalf("expected digests for the 2 active users only, got %d", len(digests))
+	}
+
+	byUser := map[uuid.UUID]*Notification{}
+	for _, n := range digests {
+		if n.Kind != KindWeeklyDigest || n.SourceID != n.UserID || !n.DueAt.Equal(end) {
+			t.Fatalf("expected a digest keyed by user and period end, got %+v", n)
+		}
+		byUser[n.UserID] = n
+	}
+
+	want := DigestSummary{PeriodStart: start, PeriodEnd: end, Applied: 2, Interviews: 1, Offers: 1, Rejections: 1, UpcomingInterviews: 1}
+	if got := byUser[maria.userID]; got == nil || *got.Digest != want || got.Email != maria.email || got.Muted {
+		t.Fatalf("expected Maria's digest %+v, got %+v", want, got)
+	}
+
+	want = DigestSummary{PeriodStart: start, PeriodEnd: end, Offers: 1, UpcomingInterviews: 1}
+	if got := byUser[joao.userID]; got == nil || *got.Digest != want || !got.Muted {
+		t.Fatalf("expected João's muted digest %+v, got %+v", want, got)
+	}
+}
+
+func TestRunOnce_WeeklyDigestOnlyWhenEnabled(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	_, end := digestPeriod(time.Now())
+	repo.add(&Notification{Kind: KindWeeklyDigest, SourceID: uuid.New(), DueAt: end, Digest: &DigestSummary{Applied: 1}})
+
+	notifier := &recordingNotifier{}
+	stats, _ := NewDispatcher(repo, notifier, ClaimOptions{}, time.Minute, quietLogger()).RunOnce(context.Background())
+	if stats.Claimed != 0 {
+		t.Fatalf("expected no digest while disabled, got %+v", stats)
+	}
+
+	dispatcher := NewDispatcher(repo, notifier, ClaimOptions{WeeklyDigest: true}, time.Minute, quietLogger())
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Sent != 1 {
+		t.Fatalf("expected the digest to be sent, got %+v", stats)
+	}
+
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Claimed != 0 {
+		t.Fatalf("expected the digest to be sent once per period, got %+v", stats)
+	}
+}
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type DispatchStats struct {
+	Claimed int
+	Sent    int
+	Failed  int
+	Muted   int
+}
+
+// Dispatcher periodically claims due reminders and hands them to a
+// notifier. Several dispatchers may share a repository: claims guarantee a
+// reminder is delivered by only one of them
+type Dispatcher struct {
+	repo     Repository
+	notifier Notifier
+	opts     ClaimOptions
+	interval time.Duration
+	logger   *log.Logger
+}
+
+func NewDispatcher(repo Repository, notifier Notifier, opts ClaimOptions, interval time.Duration, logger *log.Logger) *Dispatcher {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	if opts.InterviewLead <= 0 {
+		opts.InterviewLead = 24 * time.Hour
+	}
+
+	if opts.Lease <= 0 {
+		opts.Lease = 5 * time.Minute
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = 100
+	}
+
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &Dispatcher{
+		repo:     repo,
+		notifier: notifier,
+		opts:     opts,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// RunOnce delivers every reminder currently due. Failed deliveries are
+// released and retried on a later run
+func (d *Dispatcher) RunOnce(ctx context.Context) (DispatchStats, error) {
+	stats := DispatchStats{}
+
+	claimed, err := d.repo.ClaimDue(ctx, d.opts)
+	stats.Claimed = len(claimed)
+
+	for _, n := range claimed {
+		// Opting out drops the reminder for good, opting back in only
+		// affects reminders due afterwards
+		if n.Muted {
+			stats.Muted++
+			if markErr := d.repo.MarkSent(ctx, n); markErr != nil {
+				d.logger.Printf("failed marking muted %s %s: %v", n.Kind, n.SourceID, markErr)
+			}
+			continue
+		}
+
+		if notifyErr := d.notifier.Notify(ctx, n); notifyErr != nil {
+			stats.Failed++
+			d.logger.Printf("failed delivering %s %s: %v", n.Kind, n.SourceID, notifyErr)
+			if relErr := d.repo.Release(ctx, n); relErr != nil {
+				d.logger.Printf("failed releasing %s %s: %v", n.Kind, n.SourceID, relErr)
+			}
+			continue
+		}
+
+		if markErr := d.repo.MarkSent(ctx, n); markErr != nil {
+			// The claim expires with the lease and the reminder may go out
+			// again, there is no way to make delivery and marking atomic
+			d.logger.Printf("failed marking %s %s as sent: %v", n.Kind, n.SourceID, markErr)
+		}
+		stats.Sent++
+	}
+
+	return stats, err
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.Printf("notification dispatcher started: interval=%s lead=%s lease=%s digest=%t", d.interval, d.opts.InterviewLead, d.opts.Lease, d.opts.WeeklyDigest)
+
+	for {
+		stats, err := d.RunOnce(ctx)
+		if err != nil {
+			d.logger.Printf("notification dispatcher run failed: %v", err)
+		}
+		if stats.Claimed > 0 {
+			d.logger.Printf("notification dispatcher run finished: claimed=%d sent=%d failed=%d muted=%d", stats.Claimed, stats.Sent, stats.Failed, stats.Muted)
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Println("notification dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRunOnce_SendsDueReminders(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	now := time.Now()
+	repo.add(&Notification{Kind: KindInterviewReminder, SourceID: uuid.New(), DueAt: now.Add(2 * time.Hour), RoundName: "Tech"})
+	repo.add(&Notification{Kind: KindInterviewReminder, SourceID: uuid.New(), DueAt: now.Add(72 * time.Hour), RoundName: "Onsite"})
+	repo.add(&Notification{Kind: KindFollowUp, SourceID: uuid.New(), DueAt: now.Add(-time.Hour)})
+	repo.add(&Notification{Kind: KindFollowUp, SourceID: uuid.New(), DueAt: now.Add(time.Hour)})
+
+	notifier := &recordingNotifier{}
+	dispatcher := NewDispatcher(repo, notifier, ClaimOptions{InterviewLead: 24 * time.Hour}, time.Minute, quietLogger())
+
+	stats, err := dispatcher.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Sent != 2 {
+		t.Fatalf("expected 2 sent reminders, got %d", stats.Sent)
+	}
+
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Claimed != 0 {
+		t.Fatalf("expected sent reminders not to be claimed again, got %d", stats.Claimed)
+	}
+
+	if len(notifier.sent) != 2 {
+		t.Fatalf("expected notifier to be called twice, got %d", len(notifier.sent))
+	}
+}
+
+func TestRunOnce_ReleasesFailedDeliveries(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	repo.add(&Notification{Kind: KindFollowUp, SourceID: uuid.New(), DueAt: time.Now().Add(-time.Hour)})
+
+	notifier := &recordingNotifier{err: errors.New("smtp down")}
+	dispatcher := NewDispatcher(repo, notifier, ClaimOptions{}, time.Minute, quietLogger())
+
+	stats, _ := dispatcher.RunOnce(context.Background())
+	if stats.Failed != 1 || stats.Sent != 0 {
+		t.Fatalf("expected 1 failed delivery, got %+v", stats)
+	}
+
+	notifier.err = nil
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Sent != 1 {
+		t.Fatalf("expected released reminder to be retried, got %+v", stats)
+	}
+}
+
+func TestRunOnce_ConcurrentDispatchersDeliverOnce(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	for i := 0; i < 50; i++ {
+		repo.add(&Notification{Kind: KindFollowUp, SourceID: uuid.New(), DueAt: time.Now().Add(-time.Minute)})
+	}
+
+	notifier := &recordingNotifier{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatcher := NewDispatcher(repo, notifier, ClaimOptions{Limit: 10}, time.Minute, quietLogger())
+			for j := 0; j < 10; j++ {
+				_, _ = dispatcher.RunOnce(context.Background())
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uuid.UUID]int)
+	for _, n := range notifier.sent {
+		seen[n.SourceID]++
+	}
+
+	if len(seen) != 50 {
+		t.Fatalf("expected 50 distinct reminders, got %d", len(seen))
+	}
+
+	for id, count := range seen {
+		if count != 1 {
+			t.Fatalf("expected reminder %s to be delivered once, got %d", id, count)
+		}
+	}
+}
+
+func TestRunOnce_ExpiredClaimIsTakenOver(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	repo.add(&Notification{Kind: KindFollowUp, SourceID: uuid.New(), DueAt: time.Now().Add(-time.Hour)})
+
+	// A worker claims the reminder and dies before delivering it
+	claimed, _ := repo.ClaimDue(context.Background(), ClaimOptions{Lease: time.Minute})
+	if len(claimed) != 1 {
+		t.Fatalf("expected 1 claimed reminder, got %d", len(claimed))
+	}
+
+	again, _ := repo.ClaimDue(context.Background(), ClaimOptions{Lease: time.Minute})
+	if len(again) != 0 {
+		t.Fatalf("expected claim to block other workers, got %d", len(again))
+	}
+
+	taken, _ := repo.ClaimDue(context.Background(), ClaimOptions{Lease: time.Nanosecond})
+	if len(taken) != 1 {
+		t.Fatalf("expected expired claim to be taken over, got %d", len(taken))
+	}
+}
+
+type recordingNotifier struct {
+	mu   sync.Mutex
+	err  error
+	sent []*Notification
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, n *Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+func quietLogger() *log.Logger {
+	return log.New(io.Discard, "", 0)
+}
+
+func TestRunOnce_SkipsMutedKinds(t *testing.T) {
+	repo := NewMockRepository().(*mockRepository)
+	repo.add(&Notification{Kind: KindFollowUp, SourceID: uuid.New(), DueAt: time.Now().Add(-time.Hour), Muted: true})
+
+	notifier := &recordingNotifier{}
+	dispatcher := NewDispatcher(repo, notifier, ClaimOptions{}, time.Minute, quietLogger())
+
+	stats, _ := dispatcher.RunOnce(context.Background())
+	if stats.Muted != 1 || len(notifier.sent) != 0 {
+		t.Fatalf("expected muted reminder not to be delivered, got %+v", stats)
+	}
+
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Claimed != 0 {
+		t.Fatalf("expected muted reminder to be settled, got %+v", stats)
+	}
+}
@@ -0,0 +1,230 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// SMTPConfig holds the settings of the outgoing mail server
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	// RequireTLS refuses to send when the server does not offer STARTTLS.
+	// Otherwise STARTTLS is used whenever it is offered
+	RequireTLS bool
+	Timeout    time.Duration
+}
+
+// ErrInvalidRecipient is returned for recipients that are not a single
+// email address
+var ErrInvalidRecipient = errors.New("invalid email recipient")
+
+// Mailer sends a rendered message to a single recipient
+type Mailer interface {
+	Send(ctx context.Context, to string, msg *Message) error
+}
+
+// SMTPMailer sends multipart text and HTML emails through an SMTP server
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, to string, msg *Message) error {
+	recipient, err := parseRecipient(to)
+	if err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(m.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	body, err := buildEmail(from, recipient, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	} else if m.config.RequireTLS {
+		return errors.New("smtp server does not support STARTTLS")
+	}
+
+	if m.config.Username != "" {
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// EmailNotifier renders notifications in the user's locale and mails them
+type EmailNotifier struct {
+	mailer   Mailer
+	renderer *Renderer
+}
+
+func NewEmailNotifier(mailer Mailer, renderer *Renderer) *EmailNotifier {
+	return &EmailNotifier{mailer: mailer, renderer: renderer}
+}
+
+func (e *EmailNotifier) Notify(ctx context.Context, n *Notification) error {
+	msg, err := e.renderer.Render(n)
+	if err != nil {
+		return err
+	}
+
+	return e.mailer.Send(ctx, n.Email, msg)
+}
+
+// parseRecipient accepts a single address, with or without a display name.
+// A line break would let the value start headers of its own
+func parseRecipient(to string) (*mail.Address, error) {
+	if to == "" {
+		return nil, errors.New("email recipient is required")
+	}
+	if strings.ContainsAny(to, "\r\n") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRecipient, to)
+	}
+
+	addr, err := mail.ParseAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRecipient, to)
+	}
+	return addr, nil
+}
+
+// buildEmail encodes msg as a multipart/alternative email with a text and
+// an HTML part. Header values are built from parsed addresses and a subject
+// kept on one line
+func buildEmail(from, to *mail.Address, msg *Message, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	subject := strings.Join(strings.Fields(msg.Subject), " ")
+	recipient := to.Address
+	if to.Name != "" {
+		recipient = to.String()
+	}
+	headers := []struct{ key, value string }{
+		{"From", from.String()},
+		{"To", recipient},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", messageID(from.Address)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h.key, h.value)
+	}
+	buf.WriteString("\r\n")
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	}
+	for _, p := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", p.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		pw, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(strings.ReplaceAll(p.body, "\n", "\r\n"))); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func messageID(from string) string {
+	domain := "localhost"
+	if _, d, ok := strings.Cut(from, "@"); ok {
+		domain = d
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
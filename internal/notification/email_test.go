@@ -0,0 +1,148 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luis-octavius/cintia/internal/notification/smtptest"
+)
+
+func TestEmailNotifier_SendsMultipartEmail(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start smtp server: %v", err)
+	}
+	defer server.Close()
+
+	mailer := NewSMTPMailer(SMTPConfig{
+		Host: server.Host(),
+		Port: server.Port(),
+		From: "Cintia <no-reply@cintia.dev>",
+	})
+	notifier := NewEmailNotifier(mailer, testRenderer(t))
+
+	n := sampleNotifications()[0]
+	n.Email = "maria@example.com"
+	n.Locale = "pt-BR"
+
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	if messages[0].From != "no-reply@cintia.dev" || messages[0].To[0] != "maria@example.com" {
+		t.Fatalf("unexpected envelope %+v", messages[0])
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(messages[0].Data))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if subject != "Entrevista amanhã: System design na Acme" {
+		t.Fatalf("unexpected subject %q", subject)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %q", mediaType)
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		types = append(types, part.Header.Get("Content-Type"))
+		if !strings.Contains(string(body), "System design") {
+			t.Errorf("expected part %s to mention the round", part.Header.Get("Content-Type"))
+		}
+	}
+
+	if len(types) != 2 || !strings.HasPrefix(types[0], "text/plain") || !strings.HasPrefix(types[1], "text/html") {
+		t.Fatalf("expected text and html parts, got %v", types)
+	}
+}
+
+func TestEmailNotifier_ReportsRejectedRecipient(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start smtp server: %v", err)
+	}
+	defer server.Close()
+	server.RejectRecipients(true)
+
+	mailer := NewSMTPMailer(SMTPConfig{Host: server.Host(), Port: server.Port(), From: "no-reply@cintia.dev"})
+	notifier := NewEmailNotifier(mailer, testRenderer(t))
+
+	n := sampleNotifications()[1]
+	n.Email = "maria@example.com"
+
+	if err := notifier.Notify(context.Background(), n); err == nil {
+		t.Fatal("expected error when recipient is rejected")
+	}
+}
+
+func TestSMTPMailer_RejectsHeaderInjection(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start smtp server: %v", err)
+	}
+	defer server.Close()
+
+	mailer := NewSMTPMailer(SMTPConfig{Host: server.Host(), Port: server.Port(), From: "no-reply@cintia.dev"})
+	msg := &Message{Subject: "Hello", Text: "Hi", HTML: "<p>Hi</p>"}
+
+	for _, to := range []string{
+		"maria@example.com\r\nBcc: victim@example.com",
+		"maria@example.com\nSubject: spoofed",
+		"maria@example.com, other@example.com",
+		"not an address",
+	} {
+		if err := mailer.Send(context.Background(), to, msg); !errors.Is(err, ErrInvalidRecipient) {
+			t.Errorf("%q: expected ErrInvalidRecipient, got %v", to, err)
+		}
+	}
+	if len(server.Messages()) != 0 {
+		t.Fatalf("expected nothing sent, got %d messages", len(server.Messages()))
+	}
+}
+
+func TestBuildEmail_KeepsSubjectOnOneLine(t *testing.T) {
+	from := &mail.Address{Address: "no-reply@cintia.dev"}
+	to := &mail.Address{Address: "maria@example.com"}
+	msg := &Message{Subject: "Interview at Acme\r\nBcc: victim@example.com", Text: "Hi", HTML: "<p>Hi</p>"}
+
+	data, err := buildEmail(from, to, msg, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	if bcc := parsed.Header.Get("Bcc"); bcc != "" {
+		t.Fatalf("expected no Bcc header, got %q", bcc)
+	}
+	if subject := parsed.Header.Get("Subject"); subject != "Interview at Acme Bcc: victim@example.com" {
+		t.Fatalf("unexpected subject %q", subject)
+	}
+}
@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindInterviewReminder Kind = "interview_reminder"
+	KindFollowUp          Kind = "follow_up"
+	KindWeeklyDigest      Kind = "weekly_digest"
+)
+
+// Kinds lists every notification kind users can opt out of
+var Kinds = []Kind{KindInterviewReminder, KindFollowUp, KindWeeklyDigest}
+
+// IsValid validate the notification kind
+func (k Kind) IsValid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification is a reminder ready to be delivered. Kind, SourceID and DueAt
+// identify it: the same triple is never delivered twice
+type Notification struct {
+	Kind          Kind      `json:"kind"`
+	SourceID      uuid.UUID `json:"source_id"`
+	DueAt         time.Time `json:"due_at"`
+	UserID        uuid.UUID `json:"user_id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	Email         string    `json:"email"`
+	Name          string    `json:"name"`
+	Locale        string    `json:"locale"`
+	JobTitle      string    `json:"job_title"`
+	Company       string    `json:"company"`
+
+	// Interview reminders only
+	RoundName       string `json:"round_name,omitempty"`
+	InterviewType   string `json:"interview_type,omitempty"`
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+	Location        string `json:"location,omitempty"`
+	MeetingLink     string `json:"meeting_link,omitempty"`
+
+	// Follow-ups only
+	Status string `json:"status,omitempty"`
+
+	// Weekly digests only
+	Digest *DigestSummary `json:"digest,omitempty"`
+
+	// Muted is set when the user opted out of this kind. Muted notifications
+	// are marked as sent without being delivered
+	Muted bool `json:"-"`
+}
+
+// DigestSummary is the activity of a user over the digest period
+type DigestSummary struct {
+	PeriodStart        time.Time `json:"period_start"`
+	PeriodEnd          time.Time `json:"period_end"`
+	Applied            int       `json:"applied"`
+	Interviews         int       `json:"interviews"`
+	Offers             int       `json:"offers"`
+	Rejections         int       `json:"rejections"`
+	UpcomingInterviews int       `json:"upcoming_interviews"`
+}
+
+// IsMuted reports whether kind is in the user's muted list
+func IsMuted(muted []string, kind Kind) bool {
+	for _, m := range muted {
+		if Kind(m) == kind {
+			return true
+		}
+	}
+	return false
+}
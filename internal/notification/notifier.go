@@ -0,0 +1,93 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Notifier delivers a notification through some channel. Implementations
+// must return an error when delivery did not happen so it can be retried
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// LogNotifier writes notifications to a logger, useful in development
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogNotifier{logger: logger}
+}
+
+func (l *LogNotifier) Notify(ctx context.Context, n *Notification) error {
+	l.logger.Printf("notification %s %s to %s <%s> due at %s", n.Kind, n.SourceID, n.Name, n.Email, n.DueAt.Format(time.RFC3339))
+	return nil
+}
+
+// WebhookNotifier posts notifications as JSON to a fixed URL, along with
+// the rendered subject and text body
+type WebhookNotifier struct {
+	url      string
+	renderer *Renderer
+	client   *http.Client
+}
+
+func NewWebhookNotifier(url string, renderer *Renderer, client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &WebhookNotifier{url: url, renderer: renderer, client: client}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n *Notification) error {
+	msg, err := w.renderer.Render(n)
+	if err != nil {
+		return err
+	}
+
+	payload := struct {
+		*Notification
+		Subject string `json:"subject"`
+		Text    string `json:"text"`
+	}{
+		Notification: n,
+		Subject:      msg.Subject,
+		Text:         msg.Text,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
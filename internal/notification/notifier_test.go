@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWebhookNotifier_PostsJSON(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected json content type, got %q", r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, testRenderer(t), server.Client())
+	n := &Notification{
+		Kind:      KindInterviewReminder,
+		SourceID:  uuid.New(),
+		DueAt:     testNow.Add(2 * time.Hour),
+		Company:   "Acme",
+		RoundName: "Tech",
+	}
+
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received["kind"] != string(KindInterviewReminder) {
+		t.Fatalf("expected kind in payload, got %v", received["kind"])
+	}
+
+	if received["subject"] != "Interview today: Tech at Acme" {
+		t.Fatalf("unexpected subject %v", received["subject"])
+	}
+}
+
+func TestWebhookNotifier_FailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, testRenderer(t), server.Client())
+	err := notifier.Notify(context.Background(), &Notification{Kind: KindFollowUp, SourceID: uuid.New()})
+	if err == nil {
+		t.Fatal("expected error on non 2xx response")
+	}
+}
@@ -0,0 +1,236 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// DefaultLocale is used when the user's locale has no templates
+const DefaultLocale = "en"
+
+// Message is a notification rendered for a given locale
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type localeTemplates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Renderer turns notifications into messages using the embedded templates.
+// Each locale directory holds a <kind>.txt defining "<kind>.subject" and
+// "<kind>.text", and a <kind>.html defining "<kind>.html"
+type Renderer struct {
+	locales map[string]*localeTemplates
+	now     func() time.Time
+}
+
+func NewRenderer() (*Renderer, error) {
+	entries, err := fs.ReadDir(templatesFS, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+
+	r := &Renderer{
+		locales: make(map[string]*localeTemplates),
+		now:     time.Now,
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		locale := entry.Name()
+		funcs := r.funcs(locale)
+
+		text, err := texttemplate.New(locale).Funcs(funcs).ParseFS(templatesFS, "templates/"+locale+"/*.txt")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s text templates: %w", locale, err)
+		}
+
+		html, err := htmltemplate.New(locale).Funcs(funcs).ParseFS(templatesFS, "templates/"+locale+"/*.html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s html templates: %w", locale, err)
+		}
+
+		r.locales[locale] = &localeTemplates{text: text, html: html}
+	}
+
+	if _, ok := r.locales[DefaultLocale]; !ok {
+		return nil, fmt.Errorf("missing templates for default locale %s", DefaultLocale)
+	}
+
+	return r, nil
+}
+
+// Locales returns the locales templates exist for
+func (r *Renderer) Locales() []string {
+	locales := make([]string, 0, len(r.locales))
+	for locale := range r.locales {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Render renders the subject, text and HTML bodies of a notification in
+// the notification's locale
+func (r *Renderer) Render(n *Notification) (*Message, error) {
+	return r.render(n.Locale, string(n.Kind), n)
+}
+
+// render executes the "<name>.subject", "<name>.text" and "<name>.html"
+// templates of the closest locale with data
+func (r *Renderer) render(locale, name string, data any) (*Message, error) {
+	tmpl := r.locales[r.match(locale)]
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, name+".text", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", name, err)
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimLeft(text.String(), "\n"),
+		HTML:    strings.TrimLeft(html.String(), "\n"),
+	}, nil
+}
+
+// match picks the closest locale with templates, or the default one
+func (r *Renderer) match(locale string) string {
+	if l, ok := matchLocale(r.Locales(), locale); ok {
+		return l
+	}
+	return DefaultLocale
+}
+
+// MatchLocale returns the supported locale closest to the given one: an
+// exact match, then a locale of the same language
+func MatchLocale(locale string) (string, bool) {
+	entries, err := fs.ReadDir(templatesFS, "templates")
+	if err != nil {
+		return "", false
+	}
+
+	locales := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			locales = append(locales, entry.Name())
+		}
+	}
+
+	return matchLocale(locales, locale)
+}
+
+func matchLocale(locales []string, locale string) (string, bool) {
+	for _, l := range locales {
+		if strings.EqualFold(l, locale) {
+			return l, true
+		}
+	}
+
+	lang, _, _ := strings.Cut(locale, "-")
+	if lang == "" {
+		return "", false
+	}
+	for _, l := range locales {
+		prefix, _, _ := strings.Cut(l, "-")
+		if strings.EqualFold(prefix, lang) {
+			return l, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *Renderer) funcs(locale string) map[string]any {
+	format := formats[DefaultLocale]
+	if f, ok := formats[locale]; ok {
+		format = f
+	}
+
+	return map[string]any{
+		"date": format.date,
+		"day":  format.day,
+		"when": func(t time.Time) string {
+			return format.when(t, r.now())
+		},
+	}
+}
+
+type dateFormat struct {
+	date func(time.Time) string
+	day  func(time.Time) string
+	when func(t, now time.Time) string
+}
+
+var ptMonths = [...]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+
+// Dates are shown in UTC since users have no time zone yet
+var formats = map[string]dateFormat{
+	"en": {
+		date: func(t time.Time) string {
+			return t.UTC().Format("Monday, January 2 at 15:04 MST")
+		},
+		day: func(t time.Time) string {
+			return t.UTC().Format("Jan 2")
+		},
+		when: func(t, now time.Time) string {
+			switch daysBetween(now, t) {
+			case 0:
+				return "today"
+			case 1:
+				return "tomorrow"
+			default:
+				return "on " + t.UTC().Format("Jan 2")
+			}
+		},
+	},
+	"pt-BR": {
+		date: func(t time.Time) string {
+			t = t.UTC()
+			return fmt.Sprintf("em %d de %s às %s", t.Day(), ptMonths[t.Month()-1], t.Format("15:04 MST"))
+		},
+		day: func(t time.Time) string {
+			t = t.UTC()
+			return fmt.Sprintf("%d de %s", t.Day(), ptMonths[t.Month()-1][:3])
+		},
+		when: func(t, now time.Time) string {
+			switch daysBetween(now, t) {
+			case 0:
+				return "hoje"
+			case 1:
+				return "amanhã"
+			default:
+				t = t.UTC()
+				return fmt.Sprintf("em %d de %s", t.Day(), ptMonths[t.Month()-1])
+			}
+		},
+	},
+}
+
+// daysBetween counts calendar days from a to b in UTC
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	start := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	end := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
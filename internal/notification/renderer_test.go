@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testNow = time.Date(2026, time.March, 9, 10, 0, 0, 0, time.UTC)
+
+func testRenderer(t *testing.T) *Renderer {
+	t.Helper()
+
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+	r.now = func() time.Time { return testNow }
+	return r
+}
+
+func sampleNotifications() []*Notification {
+	return []*Notification{
+		{
+			Kind: KindInterviewReminder, SourceID: uuid.New(), DueAt: testNow.Add(24 * time.Hour),
+			Name: "Maria", JobTitle: "Backend Engineer", Company: "Acme",
+			RoundName: "System design", InterviewType: "technical", DurationMinutes: 90,
+			MeetingLink: "https://meet.example.com/abc?x=1&y=2",
+		},
+		{
+			Kind: KindFollowUp, SourceID: uuid.New(), DueAt: testNow.Add(-time.Hour),
+			Name: "Maria", JobTitle: "Backend Engineer", Company: "Acme", Status: "applied",
+		},
+		{
+			Kind: KindWeeklyDigest, SourceID: uuid.New(), DueAt: testNow,
+			Name: "Maria",
+			Digest: &DigestSummary{
+				PeriodStart: testNow.Add(-7 * 24 * time.Hour), PeriodEnd: testNow,
+				Applied: 5, Interviews: 2, Offers: 1, Rejections: 1, UpcomingInterviews: 3,
+			},
+		},
+	}
+}
+
+func TestRender_AllKindsAndLocales(t *testing.T) {
+	r := testRenderer(t)
+
+	for _, locale := range r.Locales() {
+		for _, n := range sampleNotifications() {
+			n.Locale = locale
+			msg, err := r.Render(n)
+			if err != nil {
+				t.Fatalf("render %s in %s: %v", n.Kind, locale, err)
+			}
+
+			if msg.Subject == "" || strings.Contains(msg.Subject, "\n") {
+				t.Errorf("%s/%s: expected single line subject, got %q", locale, n.Kind, msg.Subject)
+			}
+			if !strings.Contains(msg.Text, "Maria") {
+				t.Errorf("%s/%s: expected text to greet the user, got %q", locale, n.Kind, msg.Text)
+			}
+			if !strings.Contains(msg.HTML, "<html") || !strings.Contains(msg.HTML, "Maria") {
+				t.Errorf("%s/%s: expected html document, got %q", locale, n.Kind, msg.HTML)
+			}
+		}
+	}
+}
+
+func TestRender_Localized(t *testing.T) {
+	r := testRenderer(t)
+	n := sampleNotifications()[0]
+
+	n.Locale = "en"
+	msg, _ := r.Render(n)
+	if msg.Subject != "Interview tomorrow: System design at Acme" {
+		t.Fatalf("unexpected english subject %q", msg.Subject)
+	}
+
+	n.Locale = "pt-br"
+	msg, _ = r.Render(n)
+	if msg.Subject != "Entrevista amanhã: System design na Acme" {
+		t.Fatalf("unexpected portuguese subject %q", msg.Subject)
+	}
+
+	// Same language falls back to the regional templates, unknown to english
+	n.Locale = "pt"
+	msg, _ = r.Render(n)
+	if !strings.HasPrefix(msg.Subject, "Entrevista") {
+		t.Fatalf("expected pt to use pt-BR templates, got %q", msg.Subject)
+	}
+
+	n.Locale = "de-DE"
+	msg, _ = r.Render(n)
+	if !strings.HasPrefix(msg.Subject, "Interview") {
+		t.Fatalf("expected unknown locale to fall back to english, got %q", msg.Subject)
+	}
+}
+
+func TestRender_EscapesHTML(t *testing.T) {
+	r := testRenderer(t)
+	n := sampleNotifications()[1]
+	n.Company = "<script>alert(1)</script>"
+
+	msg, err := r.Render(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(msg.HTML, "<script>") {
+		t.Fatalf("expected company to be escaped in html, got %q", msg.HTML)
+	}
+}
@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+// ClaimOptions controls which reminders are due and how long a claim holds
+type ClaimOptions struct {
+	// InterviewLead is how long before an interview its reminder is due
+	InterviewLead time.Duration
+	// Lease is how long a claim blocks other workers. A worker that dies
+	// mid-delivery loses its claim once the lease expires
+	Lease time.Duration
+	// Limit caps how many reminders of each kind are claimed per call
+	Limit int
+	// WeeklyDigest enables the digest of the past week, due every Monday
+	// at midnight UTC
+	WeeklyDigest bool
+}
+
+type Repository interface {
+	// ClaimDue returns due reminders claimed by the caller. A reminder is
+	// only ever returned to one caller at a time, even across processes
+	ClaimDue(ctx context.Context, opts ClaimOptions) ([]*Notification, error)
+	// MarkSent records the reminder as delivered so it is never claimed again
+	MarkSent(ctx context.Context, n *Notification) error
+	// Release drops the claim so the reminder is retried later
+	Release(ctx context.Context, n *Notification) error
+}
@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type deliveryKey struct {
+	kind     Kind
+	sourceID uuid.UUID
+	dueAt    int64
+}
+
+type delivery struct {
+	sent      bool
+	claimedAt time.Time
+	attempts  int
+}
+
+type mockRepository struct {
+	mu         sync.Mutex
+	pending    []*Notification
+	deliveries map[deliveryKey]*delivery
+}
+
+// NewMockRepository returns an in-memory repository. Reminders are fed to
+// it with add instead of being derived from interviews and applications
+func NewMockRepository() Repository {
+	return &mockRepository{
+		deliveries: make(map[deliveryKey]*delivery),
+	}
+}
+
+func (m *mockRepository) add(n *Notification) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pending = append(m.pending, n)
+}
+
+func (m *mockRepository) ClaimDue(ctx context.Context, opts ClaimOptions) ([]*Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	staleBefore := now.Add(-opts.Lease)
+
+	due := make([]*Notification, 0, len(m.pending))
+	for _, n := range m.pending {
+		switch n.Kind {
+		case KindInterviewReminder:
+			if n.DueAt.Before(now) || n.DueAt.After(now.Add(opts.InterviewLead)) {
+				continue
+			}
+		case KindFollowUp:
+			if n.DueAt.After(now) {
+				continue
+			}
+		case KindWeeklyDigest:
+			if !opts.WeeklyDigest || n.DueAt.After(now) {
+				continue
+			}
+		}
+		due = append(due, n)
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].DueAt.Before(due[j].DueAt)
+	})
+
+	claimed := []*Notification{}
+	perKind := make(map[Kind]int)
+	for _, n := range due {
+		if opts.Limit > 0 && perKind[n.Kind] >= opts.Limit {
+			continue
+		}
+
+		key := keyOf(n)
+		d, exists := m.deliveries[key]
+		if exists && (d.sent || (!d.claimedAt.IsZero() && !d.claimedAt.Before(staleBefore))) {
+			continue
+		}
+		if !exists {
+			d = &delivery{}
+			m.deliveries[key] = d
+		}
+
+		d.claimedAt = now
+		d.attempts++
+		perKind[n.Kind]++
+		copied := *n
+		claimed = append(claimed, &copied)
+	}
+
+	return claimed, nil
+}
+
+func (m *mockRepository) MarkSent(ctx context.Context, n *Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if d, exists := m.deliveries[keyOf(n)]; exists {
+		d.sent = true
+		d.claimedAt = time.Time{}
+	}
+
+	return nil
+}
+
+func (m *mockRepository) Release(ctx context.Context, n *Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if d, exists := m.deliveries[keyOf(n)]; exists && !d.sent {
+		d.claimedAt = time.Time{}
+	}
+
+	return nil
+}
+
+func keyOf(n *Notification) deliveryKey {
+	return deliveryKey{kind: n.Kind, sourceID: n.SourceID, dueAt: n.DueAt.UnixNano()}
+}
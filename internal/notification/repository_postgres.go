@@ -0,0 +1,193 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/luis-octavius/cintia/internal/database"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) ClaimDue(ctx context.Context, opts ClaimOptions) ([]*Notification, error) {
+	now := time.Now()
+	staleBefore := now.Add(-opts.Lease)
+
+	interviews, err := r.queries.ListDueInterviewReminders(ctx, database.ListDueInterviewRemindersParams{
+		DueBefore:   now.Add(opts.InterviewLead),
+		StaleBefore: staleBefore,
+		BatchSize:   int32(opts.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	followUps, err := r.queries.ListDueFollowUps(ctx, database.ListDueFollowUpsParams{
+		StaleBefore: staleBefore,
+		BatchSize:   int32(opts.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var digests []*Notification
+	if opts.WeeklyDigest {
+		digests, err = r.listDueDigests(ctx, now, staleBefore)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Limit > 0 && len(digests) > opts.Limit {
+			digests = digests[:opts.Limit]
+		}
+	}
+
+	candidates := make([]*Notification, 0, len(interviews)+len(followUps)+len(digests))
+	for _, row := range interviews {
+		candidates = append(candidates, &Notification{
+			Kind:            KindInterviewReminder,
+			SourceID:        row.ID,
+			DueAt:           row.ScheduledAt,
+			UserID:          row.UserID,
+			ApplicationID:   row.ApplicationID,
+			Email:           row.UserEmail,
+			Name:            row.UserName,
+			Locale:          row.UserLocale,
+			Muted:           IsMuted(row.UserMutedNotifications, KindInterviewReminder),
+			JobTitle:        row.JobTitle,
+			Company:         row.JobCompany,
+			RoundName:       row.RoundName,
+			InterviewType:   row.Type,
+			DurationMinutes: int(row.DurationMinutes),
+			Location:        fromNullString(row.Location),
+			MeetingLink:     fromNullString(row.MeetingLink),
+		})
+	}
+	for _, row := range followUps {
+		candidates = append(candidates, &Notification{
+			Kind:          KindFollowUp,
+			SourceID:      row.ID,
+			DueAt:         row.FollowUpDate.Time,
+			UserID:        row.UserID,
+			ApplicationID: row.ID,
+			Email:         row.UserEmail,
+			Name:          row.UserName,
+			Locale:        row.UserLocale,
+			Muted:         IsMuted(row.UserMutedNotifications, KindFollowUp),
+			JobTitle:      row.JobTitle,
+			Company:       row.JobCompany,
+			Status:        row.Status,
+		})
+	}
+	candidates = append(candidates, digests...)
+
+	// Listing is only a hint, another worker may claim the same rows in the
+	// meantime. The insert on the ledger primary key settles who wins
+	claimed := make([]*Notification, 0, len(candidates))
+	for _, n := range candidates {
+		rows, err := r.queries.ClaimReminder(ctx, database.ClaimReminderParams{
+			Kind:        string(n.Kind),
+			SourceID:    n.SourceID,
+			DueAt:       n.DueAt,
+			UserID:      n.UserID,
+			StaleBefore: staleBefore,
+		})
+		if err != nil {
+			return claimed, err
+		}
+		if rows == 1 {
+			claimed = append(claimed, n)
+		}
+	}
+
+	return claimed, nil
+}
+
+// listDueDigests builds the digests of the week before now for the users
+// that were not sent one yet
+func (r *PostgresRepository) listDueDigests(ctx context.Context, now, staleBefore time.Time) ([]*Notification, error) {
+	start, end := digestPeriod(now)
+
+	appRows, err := r.queries.ListDigestApplications(ctx, database.ListDigestApplicationsParams{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		StaleBefore: staleBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	interviewRows, err := r.queries.ListDigestInterviews(ctx, database.ListDigestInterviewsParams{
+		PeriodStart: start,
+		UpcomingEnd: end.AddDate(0, 0, 7),
+		PeriodEnd:   end,
+		StaleBefore: staleBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]digestApplication, len(appRows))
+	for i, row := range appRows {
+		apps[i] = digestApplication{
+			recipient: digestRecipient{userID: row.UserID, email: row.UserEmail, name: row.UserName, locale: row.UserLocale, muted: row.UserMutedNotifications},
+			appliedAt: row.AppliedAt,
+			updatedAt: row.UpdatedAt,
+			stageKind: pipeline.StageKind(row.StageKind),
+		}
+	}
+
+	interviews := make([]digestInterview, len(interviewRows))
+	for i, row := range interviewRows {
+		interviews[i] = digestInterview{
+			recipient:   digestRecipient{userID: row.UserID, email: row.UserEmail, name: row.UserName, locale: row.UserLocale, muted: row.UserMutedNotifications},
+			scheduledAt: row.ScheduledAt,
+		}
+	}
+
+	return buildDigests(start, end, apps, interviews), nil
+}
+
+func (r *PostgresRepository) MarkSent(ctx context.Context, n *Notification) error {
+	err := r.queries.MarkReminderDelivered(ctx, database.MarkReminderDeliveredParams{
+		Kind:     string(n.Kind),
+		SourceID: n.SourceID,
+		DueAt:    n.DueAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if n.Kind == KindInterviewReminder {
+		return r.queries.MarkInterviewReminderSent(ctx, database.MarkInterviewReminderSentParams{
+			ID:          n.SourceID,
+			ScheduledAt: n.DueAt,
+		})
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) Release(ctx context.Context, n *Notification) error {
+	return r.queries.ReleaseReminder(ctx, database.ReleaseReminderParams{
+		Kind:     string(n.Kind),
+		SourceID: n.SourceID,
+		DueAt:    n.DueAt,
+	})
+}
+
+func fromNullString(ns sql.NullString) string {
+	if ns.Valid {
+		return ns.String
+	}
+	return ""
+}
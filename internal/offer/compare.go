@@ -0,0 +1,182 @@
+package offer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HoursPerYear converts hourly pay to a yearly salary: 40 hours for 52
+// weeks
+const HoursPerYear = 2080
+
+var (
+	ErrMixedCurrencies = errors.New("offers use several currencies, set currency and rates to compare them")
+	ErrMissingRate     = errors.New("missing exchange rate")
+	ErrInvalidRates    = errors.New("rates must look like BRL:0.18,EUR:1.08")
+)
+
+// Compensation is the yearly value of an offer in the comparison currency,
+// rounded to whole units
+type Compensation struct {
+	Base     int64 `json:"base"`
+	Bonus    int64 `json:"bonus"`
+	Equity   int64 `json:"equity"`
+	Benefits int64 `json:"benefits"`
+	Total    int64 `json:"total"`
+	// FirstYearTotal adds the signing bonus to Total
+	FirstYearTotal int64 `json:"first_year_total"`
+}
+
+type ComparedOffer struct {
+	*ListedOffer
+	// ExchangeRate is the value of one unit of the offer currency in the
+	// comparison currency
+	ExchangeRate  float64      `json:"exchange_rate"`
+	Annual        Compensation `json:"annual"`
+	DaysToRespond *int         `json:"days_to_respond,omitempty"` // negative once the deadline passed
+	Rank          int          `json:"rank"`
+}
+
+// Comparison lists offers from the highest annual total down
+type Comparison struct {
+	Currency string           `json:"currency"`
+	Offers   []*ComparedOffer `json:"offers"`
+}
+
+// Compare normalizes the offers to annual totals in currency. An empty
+// currency is allowed when all offers share one. rates maps other
+// currencies to their value in currency
+func Compare(offers []*ListedOffer, currency string, rates map[string]float64, now time.Time) (*Comparison, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		for _, o := range offers {
+			if currency == "" {
+				currency = o.Currency
+			} else if o.Currency != currency {
+				return nil, ErrMixedCurrencies
+			}
+		}
+	}
+
+	comparison := &Comparison{Currency: currency, Offers: make([]*ComparedOffer, 0, len(offers))}
+	for _, o := range offers {
+		rate := 1.0
+		if o.Currency != currency {
+			var ok bool
+			if rate, ok = rates[o.Currency]; !ok {
+				return nil, fmt.Errorf("%w: %s to %s", ErrMissingRate, o.Currency, currency)
+			}
+		}
+
+		compared := &ComparedOffer{
+			ListedOffer:  o,
+			ExchangeRate: rate,
+			Annual:       annualize(&o.Offer, rate),
+		}
+		if o.RespondBy != nil {
+			days := int(math.Ceil(o.RespondBy.Sub(now).Hours() / 24))
+			compared.DaysToRespond = &days
+		}
+		comparison.Offers = append(comparison.Offers, compared)
+	}
+
+	sort.SliceStable(comparison.Offers, func(i, j int) bool {
+		return comparison.Offers[i].Annual.Total > comparison.Offers[j].Annual.Total
+	})
+	for i, compared := range comparison.Offers {
+		compared.Rank = i + 1
+	}
+
+	return comparison, nil
+}
+
+// annualize spreads equity over its vesting and converts every part with
+// rate before rounding, so the parts add up to the total
+func annualize(o *Offer, rate float64) Compensation {
+	base := float64(o.BaseSalary)
+	switch o.PayPeriod {
+	case PayPerMonth:
+		base *= 12
+	case PayPerHour:
+		base *= HoursPerYear
+	}
+
+	vesting := o.EquityVestingYears
+	if vesting <= 0 {
+		vesting = 1
+	}
+
+	c := Compensation{
+		Base:     convert(base, rate),
+		Bonus:    convert(float64(o.Bonus), rate),
+		Equity:   convert(float64(o.EquityValue)/float64(vesting), rate),
+		Benefits: convert(float64(o.BenefitsValue), rate),
+	}
+	c.Total = c.Base + c.Bonus + c.Equity + c.Benefits
+	c.FirstYearTotal = c.Total + convert(float64(o.SigningBonus), rate)
+	return c
+}
+
+func convert(amount, rate float64) int64 {
+	return int64(math.Round(amount * rate))
+}
+
+// parseRates reads exchange rates written as CUR:rate pairs separated by
+// commas
+func parseRates(value string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	if strings.TrimSpace(value) == "" {
+		return rates, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		code, raw, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, ErrInvalidRates
+		}
+
+		code = strings.ToUpper(strings.TrimSpace(code))
+		rate, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if !isCurrencyCode(code) || err != nil || rate <= 0 || math.IsInf(rate, 0) {
+			return nil, ErrInvalidRates
+		}
+		rates[code] = rate
+	}
+	return rates, nil
+}
+
+// parseIDs reads a comma separated list of application IDs
+func parseIDs(value string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, raw := range strings.Split(value, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// isCurrencyCode checks the shape of an ISO 4217 code
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
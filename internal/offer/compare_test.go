@@ -0,0 +1,85 @@
+package offer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCompare_NormalizesToAnnualTotals(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	deadline := now.Add(36 * time.Hour)
+
+	yearly := &ListedOffer{Offer: Offer{
+		ID: uuid.New(), Currency: "USD", BaseSalary: 120000, PayPeriod: PayPerYear,
+		Bonus: 10000, SigningBonus: 5000, EquityValue: 40000, EquityVestingYears: 4,
+		BenefitsValue: 2000, RespondBy: &deadline,
+	}}
+	monthly := &ListedOffer{Offer: Offer{
+		ID: uuid.New(), Currency: "BRL", BaseSalary: 30000, PayPeriod: PayPerMonth, EquityVestingYears: 4,
+	}}
+	hourly := &ListedOffer{Offer: Offer{
+		ID: uuid.New(), Currency: "USD", BaseSalary: 50, PayPeriod: PayPerHour, EquityVestingYears: 4,
+	}}
+
+	comparison, err := Compare([]*ListedOffer{monthly, hourly, yearly}, "usd", map[string]float64{"BRL": 0.2}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if comparison.Currency != "USD" || len(comparison.Offers) != 3 {
+		t.Fatalf("unexpected comparison: %+v", comparison)
+	}
+
+	best := comparison.Offers[0]
+	wantBest := Compensation{Base: 120000, Bonus: 10000, Equity: 10000, Benefits: 2000, Total: 142000, FirstYearTotal: 147000}
+	if best.ID != yearly.ID || best.Rank != 1 || best.Annual != wantBest {
+		t.Fatalf("expected yearly offer first with %+v, got %+v", wantBest, best.Annual)
+	}
+	if best.DaysToRespond == nil || *best.DaysToRespond != 2 {
+		t.Fatalf("expected 2 days to respond, got %v", best.DaysToRespond)
+	}
+
+	if comparison.Offers[1].ID != hourly.ID || comparison.Offers[1].Annual.Total != 104000 {
+		t.Fatalf("expected hourly offer second at 104000, got %+v", comparison.Offers[1].Annual)
+	}
+	if comparison.Offers[2].ID != monthly.ID || comparison.Offers[2].Annual.Total != 72000 || comparison.Offers[2].ExchangeRate != 0.2 {
+		t.Fatalf("expected converted monthly offer last at 72000, got %+v", comparison.Offers[2])
+	}
+}
+
+func TestCompare_Currencies(t *testing.T) {
+	usd := &ListedOffer{Offer: Offer{Currency: "USD", BaseSalary: 100, PayPeriod: PayPerYear, EquityVestingYears: 4}}
+	eur := &ListedOffer{Offer: Offer{Currency: "EUR", BaseSalary: 100, PayPeriod: PayPerYear, EquityVestingYears: 4}}
+
+	comparison, err := Compare([]*ListedOffer{eur, eur}, "", nil, time.Now())
+	if err != nil || comparison.Currency != "EUR" {
+		t.Fatalf("expected shared currency EUR, got %+v, %v", comparison, err)
+	}
+
+	if _, err := Compare([]*ListedOffer{usd, eur}, "", nil, time.Now()); !errors.Is(err, ErrMixedCurrencies) {
+		t.Fatalf("expected ErrMixedCurrencies, got %v", err)
+	}
+
+	if _, err := Compare([]*ListedOffer{usd, eur}, "USD", map[string]float64{"BRL": 0.2}, time.Now()); !errors.Is(err, ErrMissingRate) {
+		t.Fatalf("expected ErrMissingRate, got %v", err)
+	}
+}
+
+func TestParseRates(t *testing.T) {
+	rates, err := parseRates(" brl:0.18 , EUR:1.08")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 || rates["BRL"] != 0.18 || rates["EUR"] != 1.08 {
+		t.Fatalf("unexpected rates: %v", rates)
+	}
+
+	for _, value := range []string{"BRL", "BRL:abc", "BRL:-1", "REAL:0.2", "BRL:0"} {
+		if _, err := parseRates(value); !errors.Is(err, ErrInvalidRates) {
+			t.Errorf("expected ErrInvalidRates for %q, got %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package offer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	CreateOfferHandler(c *gin.Context)
+	GetOfferHandler(c *gin.Context)
+	UpdateOfferHandler(c *gin.Context)
+	DeleteOfferHandler(c *gin.Context)
+	GetUserOffersHandler(c *gin.Context)
+	CompareOffersHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/applications/:id/offer - record the offer of an application
+func (h *GinHandler) CreateOfferHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	var req OfferInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	offer, err := h.service.CreateOffer(c.Request.Context(), userID, applicationID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "offer created successfully",
+		"offer":   offer,
+	})
+}
+
+// GET /api/applications/:id/offer - offer of an application
+func (h *GinHandler) GetOfferHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	offer, err := h.service.GetOffer(c.Request.Context(), userID, applicationID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"offer": offer,
+	})
+}
+
+// PUT /api/applications/:id/offer - replace the offer of an application
+func (h *GinHandler) UpdateOfferHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	var req OfferInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	offer, err := h.service.UpdateOffer(c.Request.Context(), userID, applicationID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "offer updated successfully",
+		"offer":   offer,
+	})
+}
+
+// DELETE /api/applications/:id/offer - delete the offer of an application
+func (h *GinHandler) DeleteOfferHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationID, ok := parseID(c, "id", "invalid application id format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOffer(c.Request.Context(), userID, applicationID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "offer deleted successfully",
+	})
+}
+
+// GET /api/offers - user's offers, closest deadline first
+func (h *GinHandler) GetUserOffersHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	offers, err := h.service.GetUserOffers(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"offers": offers,
+		"total":  len(offers),
+	})
+}
+
+// GET /api/offers/compare?application_ids=&currency=USD&rates=BRL:0.18,EUR:1.08 - offers side by side in annual totals
+func (h *GinHandler) CompareOffersHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	applicationIDs, err := parseIDs(c.Query("application_ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid application id format",
+		})
+		return
+	}
+
+	rates, err := parseRates(c.Query("rates"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	comparison, err := h.service.CompareOffers(c.Request.Context(), userID, applicationIDs, c.Query("currency"), rates)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrApplicationNotFound),
+		errors.Is(err, ErrOfferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrOfferExists),
+		errors.Is(err, ErrNotInOfferStatus):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidCurrency),
+		errors.Is(err, ErrInvalidBaseSalary),
+		errors.Is(err, ErrNegativeAmount),
+		errors.Is(err, ErrInvalidPayPeriod),
+		errors.Is(err, ErrInvalidVesting),
+		errors.Is(err, ErrInvalidWorkMode),
+		errors.Is(err, ErrTooManyBenefits),
+		errors.Is(err, ErrMixedCurrencies),
+		errors.Is(err, ErrMissingRate),
+		errors.Is(err, ErrInvalidRates):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -0,0 +1,260 @@
+package offer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOfferHandler_Success(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	appID := uuid.New()
+	handler, _, _ := newTestHandler(userID, map[uuid.UUID]application.ApplicationStatus{appID: application.StatusOffer})
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "POST", "/applications/"+appID.String()+"/offer", userID, OfferInput{
+		Currency:   "eur",
+		BaseSalary: 6000,
+		PayPeriod:  PayPerMonth,
+		Benefits:   []string{" health insurance ", ""},
+		WorkMode:   WorkHybrid,
+	})
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+	// Execute
+	handler.CreateOfferHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Offer Offer `json:"offer"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "EUR", response.Offer.Currency)
+	assert.Equal(t, 4, response.Offer.EquityVestingYears)
+	assert.Equal(t, []string{"health insurance"}, response.Offer.Benefits)
+	assert.Equal(t, appID, response.Offer.ApplicationID)
+}
+
+func TestCreateOffer_CustomPipelineStage(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	pipelines := pipeline.NewService(pipeline.NewMockRepository())
+	custom, err := pipelines.CreatePipeline(context.Background(), userID, pipeline.CreatePipelineInput{
+		Name: "Agency",
+		Stages: []pipeline.StageInput{
+			{Key: "sent", Name: "Sent"},
+			{Key: "verbal_offer", Name: "Verbal offer", Kind: pipeline.KindOffer},
+		},
+	})
+	require.NoError(t, err)
+
+	offered, sent := uuid.New(), uuid.New()
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{
+		offered: {ID: offered, UserID: userID, Status: "verbal_offer", StageID: custom.Stages[1].ID},
+		sent:    {ID: sent, UserID: userID, Status: "sent", StageID: custom.Stages[0].ID},
+	}}
+	service := NewService(NewMockRepository(), apps, pipelines)
+
+	// Execute
+	_, offerErr := service.CreateOffer(context.Background(), userID, offered, OfferInput{Currency: "USD", BaseSalary: 100000})
+	_, sentErr := service.CreateOffer(context.Background(), userID, sent, OfferInput{Currency: "USD", BaseSalary: 100000})
+
+	// Assert
+	assert.NoError(t, offerErr)
+	assert.ErrorIs(t, sentErr, ErrNotInOfferStatus)
+}
+
+func TestCreateOfferHandler_Conflicts(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	offered := uuid.New()
+	applied := uuid.New()
+	handler, service, _ := newTestHandler(userID, map[uuid.UUID]application.ApplicationStatus{
+		offered: application.StatusOffer,
+		applied: application.StatusApplied,
+	})
+
+	_, err := service.CreateOffer(context.Background(), userID, offered, OfferInput{Currency: "USD", BaseSalary: 100000})
+	require.NoError(t, err)
+
+	for _, appID := range []uuid.UUID{offered, applied} {
+		w := httptest.NewRecorder()
+		c := jsonContext(w, "POST", "/applications/"+appID.String()+"/offer", userID, OfferInput{Currency: "USD", BaseSalary: 90000})
+		c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+		// Execute
+		handler.CreateOfferHandler(c)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+	}
+}
+
+func TestCreateOfferHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input OfferInput
+	}{
+		{"bad currency", OfferInput{Currency: "dollars", BaseSalary: 100}},
+		{"negative bonus", OfferInput{Currency: "USD", BaseSalary: 100, Bonus: -1}},
+		{"bad pay period", OfferInput{Currency: "USD", BaseSalary: 100, PayPeriod: "week"}},
+		{"bad vesting", OfferInput{Currency: "USD", BaseSalary: 100, EquityVestingYears: 20}},
+		{"bad work mode", OfferInput{Currency: "USD", BaseSalary: 100, WorkMode: "office"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			userID := uuid.New()
+			appID := uuid.New()
+			handler, _, _ := newTestHandler(userID, map[uuid.UUID]application.ApplicationStatus{appID: application.StatusOffer})
+
+			w := httptest.NewRecorder()
+			c := jsonContext(w, "POST", "/applications/"+appID.String()+"/offer", userID, tt.input)
+			c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+			// Execute
+			handler.CreateOfferHandler(c)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGetOfferHandler_OtherUser(t *testing.T) {
+	// Setup
+	owner := uuid.New()
+	appID := uuid.New()
+	handler, service, _ := newTestHandler(owner, map[uuid.UUID]application.ApplicationStatus{appID: application.StatusOffer})
+
+	_, err := service.CreateOffer(context.Background(), owner, appID, OfferInput{Currency: "USD", BaseSalary: 100000})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/applications/"+appID.String()+"/offer", uuid.New(), nil)
+	c.Params = gin.Params{{Key: "id", Value: appID.String()}}
+
+	// Execute
+	handler.GetOfferHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCompareOffersHandler(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	local := uuid.New()
+	abroad := uuid.New()
+	ignored := uuid.New()
+	handler, service, repo := newTestHandler(userID, map[uuid.UUID]application.ApplicationStatus{
+		local:   application.StatusOffer,
+		abroad:  application.StatusOffer,
+		ignored: application.StatusOffer,
+	})
+	repo.setApplicationJob(abroad, "offer", "Backend Engineer", "Globex")
+
+	for appID, input := range map[uuid.UUID]OfferInput{
+		local:   {Currency: "BRL", BaseSalary: 20000, PayPeriod: PayPerMonth},
+		abroad:  {Currency: "USD", BaseSalary: 90000, Bonus: 9000},
+		ignored: {Currency: "EUR", BaseSalary: 500000},
+	} {
+		_, err := service.CreateOffer(context.Background(), userID, appID, input)
+		require.NoError(t, err)
+	}
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/offers/compare?application_ids="+local.String()+","+abroad.String()+"&currency=USD&rates=BRL:0.2", userID, nil)
+
+	// Execute
+	handler.CompareOffersHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response Comparison
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Offers, 2)
+	assert.Equal(t, "USD", response.Currency)
+	assert.Equal(t, abroad, response.Offers[0].ApplicationID)
+	assert.Equal(t, "Globex", response.Offers[0].JobCompany)
+	assert.Equal(t, int64(99000), response.Offers[0].Annual.Total)
+	assert.Equal(t, int64(48000), response.Offers[1].Annual.Total)
+}
+
+func TestCompareOffersHandler_MissingRate(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	usd := uuid.New()
+	brl := uuid.New()
+	handler, service, _ := newTestHandler(userID, map[uuid.UUID]application.ApplicationStatus{
+		usd: application.StatusOffer,
+		brl: application.StatusAccepted,
+	})
+
+	_, err := service.CreateOffer(context.Background(), userID, usd, OfferInput{Currency: "USD", BaseSalary: 90000})
+	require.NoError(t, err)
+	_, err = service.CreateOffer(context.Background(), userID, brl, OfferInput{Currency: "BRL", BaseSalary: 240000})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonContext(w, "GET", "/offers/compare?currency=USD", userID, nil)
+
+	// Execute
+	handler.CompareOffersHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "BRL")
+}
+
+func newTestHandler(userID uuid.UUID, statuses map[uuid.UUID]application.ApplicationStatus) (*GinHandler, Service, *mockRepository) {
+	apps := &stubApplicationService{apps: map[uuid.UUID]*application.Application{}}
+	for appID, status := range statuses {
+		stage, _ := pipeline.DefaultPipeline().StageByKey(string(status))
+		apps.apps[appID] = &application.Application{ID: appID, UserID: userID, Status: status, StageID: stage.ID}
+	}
+
+	repo := NewMockRepository().(*mockRepository)
+	service := NewService(repo, apps, pipeline.NewService(pipeline.NewMockRepository()))
+	return NewGinHandler(service), service, repo
+}
+
+func jsonContext(w *httptest.ResponseRecorder, method, path string, userID uuid.UUID, body any) *gin.Context {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+	return c
+}
+
+// Stub application service for testing, only GetApplicationByID is used
+type stubApplicationService struct {
+	application.Service
+	apps map[uuid.UUID]*application.Application
+}
+
+func (s *stubApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*application.Application, error) {
+	app, exists := s.apps[id]
+	if !exists {
+		return nil, application.ErrApplicationNotFound
+	}
+	return app, nil
+}
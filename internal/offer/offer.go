@@ -0,0 +1,91 @@
+package offer
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PayPeriod string
+
+const (
+	PayPerYear  PayPeriod = "year"
+	PayPerMonth PayPeriod = "month"
+	PayPerHour  PayPeriod = "hour"
+)
+
+type WorkMode string
+
+const (
+	WorkRemote WorkMode = "remote"
+	WorkHybrid WorkMode = "hybrid"
+	WorkOnsite WorkMode = "onsite"
+)
+
+// Offer is the compensation offered for an application. Amounts are whole
+// units of Currency; Bonus and BenefitsValue are yearly, EquityValue is
+// the whole grant vesting over EquityVestingYears
+type Offer struct {
+	ID                 uuid.UUID  `json:"id"`
+	ApplicationID      uuid.UUID  `json:"application_id"`
+	UserID             uuid.UUID  `json:"user_id"`
+	Currency           string     `json:"currency"`
+	BaseSalary         int64      `json:"base_salary"`
+	PayPeriod          PayPeriod  `json:"pay_period"`
+	Bonus              int64      `json:"bonus"`
+	SigningBonus       int64      `json:"signing_bonus"`
+	EquityValue        int64      `json:"equity_value"`
+	EquityVestingYears int        `json:"equity_vesting_years"`
+	Benefits           []string   `json:"benefits"`
+	BenefitsValue      int64      `json:"benefits_value"`
+	RespondBy          *time.Time `json:"respond_by,omitempty"`
+	Location           string     `json:"location,omitempty"`
+	WorkMode           WorkMode   `json:"work_mode,omitempty"`
+	Notes              string     `json:"notes,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// ListedOffer is an offer with the job it is for
+type ListedOffer struct {
+	Offer
+	ApplicationStatus string `json:"application_status"`
+	JobTitle          string `json:"job_title"`
+	JobCompany        string `json:"job_company"`
+}
+
+// OfferInput holds the fields of an offer. PayPeriod defaults to year and
+// EquityVestingYears to 4
+type OfferInput struct {
+	Currency           string     `json:"currency" binding:"required"`
+	BaseSalary         int64      `json:"base_salary" binding:"required"`
+	PayPeriod          PayPeriod  `json:"pay_period,omitempty"`
+	Bonus              int64      `json:"bonus,omitempty"`
+	SigningBonus       int64      `json:"signing_bonus,omitempty"`
+	EquityValue        int64      `json:"equity_value,omitempty"`
+	EquityVestingYears int        `json:"equity_vesting_years,omitempty"`
+	Benefits           []string   `json:"benefits,omitempty"`
+	BenefitsValue      int64      `json:"benefits_value,omitempty"`
+	RespondBy          *time.Time `json:"respond_by,omitempty"`
+	Location           string     `json:"location,omitempty"`
+	WorkMode           WorkMode   `json:"work_mode,omitempty"`
+	Notes              string     `json:"notes,omitempty"`
+}
+
+// IsValid validate the pay period
+func (p PayPeriod) IsValid() bool {
+	switch p {
+	case PayPerYear, PayPerMonth, PayPerHour:
+		return true
+	}
+	return false
+}
+
+// IsValid validate the work mode
+func (m WorkMode) IsValid() bool {
+	switch m {
+	case WorkRemote, WorkHybrid, WorkOnsite:
+		return true
+	}
+	return false
+}
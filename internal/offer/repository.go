@@ -0,0 +1,24 @@
+package offer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound      = errors.New("offer not found")
+	ErrAlreadyExists = errors.New("offer already exists")
+)
+
+type Repository interface {
+	// Create returns ErrAlreadyExists when the application has an offer
+	Create(ctx context.Context, offer *Offer) (*Offer, error)
+	GetByApplication(ctx context.Context, applicationID uuid.UUID) (*Offer, error)
+	// GetUserOffers returns the user's offers with their job, closest
+	// deadline first
+	GetUserOffers(ctx context.Context, userID uuid.UUID) ([]*ListedOffer, error)
+	Update(ctx context.Context, offer *Offer) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
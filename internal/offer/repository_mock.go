@@ -0,0 +1,134 @@
+package offer
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu     sync.RWMutex
+	offers map[uuid.UUID]*Offer
+	// jobs stands in for the applications and jobs joined when listing
+	jobs map[uuid.UUID]offerJob
+}
+
+type offerJob struct {
+	status  string
+	title   string
+	company string
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		offers: make(map[uuid.UUID]*Offer),
+		jobs:   make(map[uuid.UUID]offerJob),
+	}
+}
+
+// setApplicationJob registers the status and job of an application so
+// that listed offers carry them
+func (m *mockRepository) setApplicationJob(applicationID uuid.UUID, status, title, company string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.jobs[applicationID] = offerJob{status: status, title: title, company: company}
+}
+
+func (m *mockRepository) Create(ctx context.Context, offer *Offer) (*Offer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.offers {
+		if existing.ApplicationID == offer.ApplicationID {
+			return nil, ErrAlreadyExists
+		}
+	}
+
+	if offer.ID == uuid.Nil {
+		offer.ID = uuid.New()
+	}
+
+	now := time.Now()
+	offer.CreatedAt = now
+	offer.UpdatedAt = now
+
+	stored := *offer
+	m.offers[offer.ID] = &stored
+	return offer, nil
+}
+
+func (m *mockRepository) GetByApplication(ctx context.Context, applicationID uuid.UUID) (*Offer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, offer := range m.offers {
+		if offer.ApplicationID == applicationID {
+			found := *offer
+			return &found, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) GetUserOffers(ctx context.Context, userID uuid.UUID) ([]*ListedOffer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	offers := []*ListedOffer{}
+	for _, offer := range m.offers {
+		if offer.UserID != userID {
+			continue
+		}
+
+		job := m.jobs[offer.ApplicationID]
+		offers = append(offers, &ListedOffer{
+			Offer:             *offer,
+			ApplicationStatus: job.status,
+			JobTitle:          job.title,
+			JobCompany:        job.company,
+		})
+	}
+
+	sort.Slice(offers, func(i, j int) bool {
+		a, b := offers[i].RespondBy, offers[j].RespondBy
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return offers[i].CreatedAt.After(offers[j].CreatedAt)
+	})
+	return offers, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, offer *Offer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.offers[offer.ID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	offer.ApplicationID = existing.ApplicationID
+	offer.UserID = existing.UserID
+	offer.CreatedAt = existing.CreatedAt
+	offer.UpdatedAt = time.Now()
+
+	stored := *offer
+	m.offers[offer.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.offers, id)
+	return nil
+}
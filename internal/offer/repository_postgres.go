@@ -0,0 +1,174 @@
+package offer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, offer *Offer) (*Offer, error) {
+	dbOffer, err := r.queries.CreateOffer(ctx, database.CreateOfferParams{
+		ApplicationID:      offer.ApplicationID,
+		UserID:             offer.UserID,
+		Currency:           offer.Currency,
+		BaseSalary:         offer.BaseSalary,
+		PayPeriod:          string(offer.PayPeriod),
+		Bonus:              offer.Bonus,
+		SigningBonus:       offer.SigningBonus,
+		EquityValue:        offer.EquityValue,
+		EquityVestingYears: int32(offer.EquityVestingYears),
+		Benefits:           offer.Benefits,
+		BenefitsValue:      offer.BenefitsValue,
+		RespondBy:          toNullTime(offer.RespondBy),
+		Location:           toNullString(offer.Location),
+		WorkMode:           toNullString(string(offer.WorkMode)),
+		Notes:              toNullString(offer.Notes),
+	})
+	if err != nil {
+		// One offer per application, enforced by the unique constraint
+		if strings.Contains(err.Error(), "offers_application_id_key") {
+			return nil, ErrAlreadyExists
+		}
+		return nil, err
+	}
+
+	return dbOfferToOffer(&dbOffer), nil
+}
+
+func (r *PostgresRepository) GetByApplication(ctx context.Context, applicationID uuid.UUID) (*Offer, error) {
+	dbOffer, err := r.queries.GetOfferByApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbOfferToOffer(&dbOffer), nil
+}
+
+func (r *PostgresRepository) GetUserOffers(ctx context.Context, userID uuid.UUID) ([]*ListedOffer, error) {
+	rows, err := r.queries.ListUserOffers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make([]*ListedOffer, len(rows))
+	for i, row := range rows {
+		offers[i] = &ListedOffer{
+			Offer: *dbOfferToOffer(&database.Offer{
+				ID:                 row.ID,
+				ApplicationID:      row.ApplicationID,
+				UserID:             row.UserID,
+				Currency:           row.Currency,
+				BaseSalary:         row.BaseSalary,
+				PayPeriod:          row.PayPeriod,
+				Bonus:              row.Bonus,
+				SigningBonus:       row.SigningBonus,
+				EquityValue:        row.EquityValue,
+				EquityVestingYears: row.EquityVestingYears,
+				Benefits:           row.Benefits,
+				BenefitsValue:      row.BenefitsValue,
+				RespondBy:          row.RespondBy,
+				Location:           row.Location,
+				WorkMode:           row.WorkMode,
+				Notes:              row.Notes,
+				CreatedAt:          row.CreatedAt,
+				UpdatedAt:          row.UpdatedAt,
+			}),
+			ApplicationStatus: row.ApplicationStatus,
+			JobTitle:          row.JobTitle,
+			JobCompany:        row.JobCompany,
+		}
+	}
+	return offers, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, offer *Offer) error {
+	dbOffer, err := r.queries.UpdateOffer(ctx, database.UpdateOfferParams{
+		ID:                 offer.ID,
+		Currency:           offer.Currency,
+		BaseSalary:         offer.BaseSalary,
+		PayPeriod:          string(offer.PayPeriod),
+		Bonus:              offer.Bonus,
+		SigningBonus:       offer.SigningBonus,
+		EquityValue:        offer.EquityValue,
+		EquityVestingYears: int32(offer.EquityVestingYears),
+		Benefits:           offer.Benefits,
+		BenefitsValue:      offer.BenefitsValue,
+		RespondBy:          toNullTime(offer.RespondBy),
+		Location:           toNullString(offer.Location),
+		WorkMode:           toNullString(string(offer.WorkMode)),
+		Notes:              toNullString(offer.Notes),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	*offer = *dbOfferToOffer(&dbOffer)
+	return nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteOffer(ctx, id)
+}
+
+// Helper functions to convert between domain and database models
+
+func dbOfferToOffer(dbOffer *database.Offer) *Offer {
+	offer := &Offer{
+		ID:                 dbOffer.ID,
+		ApplicationID:      dbOffer.ApplicationID,
+		UserID:             dbOffer.UserID,
+		Currency:           dbOffer.Currency,
+		BaseSalary:         dbOffer.BaseSalary,
+		PayPeriod:          PayPeriod(dbOffer.PayPeriod),
+		Bonus:              dbOffer.Bonus,
+		SigningBonus:       dbOffer.SigningBonus,
+		EquityValue:        dbOffer.EquityValue,
+		EquityVestingYears: int(dbOffer.EquityVestingYears),
+		Benefits:           dbOffer.Benefits,
+		BenefitsValue:      dbOffer.BenefitsValue,
+		Location:           dbOffer.Location.String,
+		WorkMode:           WorkMode(dbOffer.WorkMode.String),
+		Notes:              dbOffer.Notes.String,
+		CreatedAt:          dbOffer.CreatedAt,
+		UpdatedAt:          dbOffer.UpdatedAt,
+	}
+	if dbOffer.RespondBy.Valid {
+		offer.RespondBy = &dbOffer.RespondBy.Time
+	}
+	if offer.Benefits == nil {
+		offer.Benefits = []string{}
+	}
+	return offer
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
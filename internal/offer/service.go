@@ -0,0 +1,264 @@
+package offer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/application"
+	"github.com/luis-octavius/cintia/internal/pipeline"
+)
+
+var (
+	ErrOfferNotFound       = errors.New("offer not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrForbidden           = errors.New("application belongs to another user")
+	ErrOfferExists         = errors.New("application already has an offer")
+	ErrNotInOfferStatus    = errors.New("offers can only be recorded for applications in an offer or accepted stage")
+	ErrInvalidCurrency     = errors.New("currency must be a three letter ISO 4217 code")
+	ErrInvalidBaseSalary   = errors.New("base salary must be positive")
+	ErrNegativeAmount      = errors.New("amounts cannot be negative")
+	ErrInvalidPayPeriod    = errors.New("invalid pay period, use year, month or hour")
+	ErrInvalidVesting      = fmt.Errorf("equity vesting years must be between 1 and %d", maxVestingYears)
+	ErrInvalidWorkMode     = errors.New("invalid work mode, use remote, hybrid or onsite")
+	ErrTooManyBenefits     = fmt.Errorf("an offer can list at most %d benefits", maxBenefits)
+)
+
+const (
+	defaultVestingYears = 4
+	maxVestingYears     = 10
+	maxBenefits         = 50
+)
+
+type Service interface {
+	CreateOffer(ctx context.Context, userID, applicationID uuid.UUID, input OfferInput) (*Offer, error)
+	GetOffer(ctx context.Context, userID, applicationID uuid.UUID) (*Offer, error)
+	UpdateOffer(ctx context.Context, userID, applicationID uuid.UUID, input OfferInput) (*Offer, error)
+	DeleteOffer(ctx context.Context, userID, applicationID uuid.UUID) error
+	GetUserOffers(ctx context.Context, userID uuid.UUID) ([]*ListedOffer, error)
+	CompareOffers(ctx context.Context, userID uuid.UUID, applicationIDs []uuid.UUID, currency string, rates map[string]float64) (*Comparison, error)
+}
+
+type service struct {
+	repo            Repository
+	appService      application.Service
+	pipelineService pipeline.Service
+}
+
+func NewService(repo Repository, appService application.Service, pipelineService pipeline.Service) Service {
+	return &service{
+		repo:            repo,
+		appService:      appService,
+		pipelineService: pipelineService,
+	}
+}
+
+func (s *service) CreateOffer(ctx context.Context, userID, applicationID uuid.UUID, input OfferInput) (*Offer, error) {
+	app, err := s.authorize(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage kinds rather than keys, custom pipelines name their stages freely
+	p, err := s.pipelineService.GetPipelineForStage(ctx, app.StageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application pipeline: %w", err)
+	}
+	if stage, ok := p.StageByID(app.StageID); !ok || !stage.Kind.ReachedOffer() {
+		return nil, ErrNotInOfferStatus
+	}
+
+	offer := &Offer{
+		ApplicationID: applicationID,
+		UserID:        userID,
+	}
+	if err := applyInput(offer, input); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, offer)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return nil, ErrOfferExists
+		}
+		return nil, fmt.Errorf("failed to create offer: %w", err)
+	}
+	return created, nil
+}
+
+func (s *service) GetOffer(ctx context.Context, userID, applicationID uuid.UUID) (*Offer, error) {
+	return s.getOwnedOffer(ctx, userID, applicationID)
+}
+
+// UpdateOffer replaces every field of the offer with the input
+func (s *service) UpdateOffer(ctx context.Context, userID, applicationID uuid.UUID, input OfferInput) (*Offer, error) {
+	offer, err := s.getOwnedOffer(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyInput(offer, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, offer); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrOfferNotFound
+		}
+		return nil, fmt.Errorf("failed to update offer: %w", err)
+	}
+	return offer, nil
+}
+
+func (s *service) DeleteOffer(ctx context.Context, userID, applicationID uuid.UUID) error {
+	offer, err := s.getOwnedOffer(ctx, userID, applicationID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, offer.ID); err != nil {
+		return fmt.Errorf("failed to delete offer: %w", err)
+	}
+	return nil
+}
+
+func (s *service) GetUserOffers(ctx context.Context, userID uuid.UUID) ([]*ListedOffer, error) {
+	offers, err := s.repo.GetUserOffers(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offers: %w", err)
+	}
+	return offers, nil
+}
+
+// CompareOffers compares all the user's offers, or only those of the given
+// applications. An application without an offer is reported as not found
+func (s *service) CompareOffers(ctx context.Context, userID uuid.UUID, applicationIDs []uuid.UUID, currency string, rates map[string]float64) (*Comparison, error) {
+	offers, err := s.GetUserOffers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applicationIDs) > 0 {
+		byApplication := make(map[uuid.UUID]*ListedOffer, len(offers))
+		for _, o := range offers {
+			byApplication[o.ApplicationID] = o
+		}
+
+		selected := make([]*ListedOffer, 0, len(applicationIDs))
+		seen := make(map[uuid.UUID]bool, len(applicationIDs))
+		for _, id := range applicationIDs {
+			o, exists := byApplication[id]
+			if !exists {
+				return nil, fmt.Errorf("%w for application %s", ErrOfferNotFound, id)
+			}
+			if !seen[id] {
+				seen[id] = true
+				selected = append(selected, o)
+			}
+		}
+		offers = selected
+	}
+
+	if currency != "" && !isCurrencyCode(strings.ToUpper(currency)) {
+		return nil, ErrInvalidCurrency
+	}
+
+	return Compare(offers, currency, rates, time.Now())
+}
+
+// authorize makes sure the application exists and belongs to the user
+func (s *service) authorize(ctx context.Context, userID, applicationID uuid.UUID) (*application.Application, error) {
+	app, err := s.appService.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, application.ErrApplicationNotFound) {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	if app.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	return app, nil
+}
+
+// getOwnedOffer loads the offer of an application that belongs to the user
+func (s *service) getOwnedOffer(ctx context.Context, userID, applicationID uuid.UUID) (*Offer, error) {
+	if _, err := s.authorize(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	offer, err := s.repo.GetByApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrOfferNotFound
+		}
+		return nil, err
+	}
+	return offer, nil
+}
+
+// applyInput validates the input and copies it into the offer, filling
+// in the defaults
+func applyInput(offer *Offer, input OfferInput) error {
+	currency := strings.ToUpper(strings.TrimSpace(input.Currency))
+	if !isCurrencyCode(currency) {
+		return ErrInvalidCurrency
+	}
+
+	if input.BaseSalary <= 0 {
+		return ErrInvalidBaseSalary
+	}
+	if input.Bonus < 0 || input.SigningBonus < 0 || input.EquityValue < 0 || input.BenefitsValue < 0 {
+		return ErrNegativeAmount
+	}
+
+	payPeriod := input.PayPeriod
+	if payPeriod == "" {
+		payPeriod = PayPerYear
+	}
+	if !payPeriod.IsValid() {
+		return ErrInvalidPayPeriod
+	}
+
+	vesting := input.EquityVestingYears
+	if vesting == 0 {
+		vesting = defaultVestingYears
+	}
+	if vesting < 1 || vesting > maxVestingYears {
+		return ErrInvalidVesting
+	}
+
+	if input.WorkMode != "" && !input.WorkMode.IsValid() {
+		return ErrInvalidWorkMode
+	}
+
+	benefits := []string{}
+	for _, benefit := range input.Benefits {
+		if benefit = strings.TrimSpace(benefit); benefit != "" {
+			benefits = append(benefits, benefit)
+		}
+	}
+	if len(benefits) > maxBenefits {
+		return ErrTooManyBenefits
+	}
+
+	offer.Currency = currency
+	offer.BaseSalary = input.BaseSalary
+	offer.PayPeriod = payPeriod
+	offer.Bonus = input.Bonus
+	offer.SigningBonus = input.SigningBonus
+	offer.EquityValue = input.EquityValue
+	offer.EquityVestingYears = vesting
+	offer.Benefits = benefits
+	offer.BenefitsValue = input.BenefitsValue
+	offer.RespondBy = input.RespondBy
+	offer.Location = strings.TrimSpace(input.Location)
+	offer.WorkMode = input.WorkMode
+	offer.Notes = strings.TrimSpace(input.Notes)
+	return nil
+}
@@ -0,0 +1,55 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+)
+
+// StateTTL is how long a user has to come back from the provider
+const StateTTL = 10 * time.Minute
+
+// DefaultScopes ask for the claims needed to match a user by email
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// Config describes the client registered at the provider. ClientSecret
+// is empty for public clients, which rely on PKCE alone
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Identity is who signed in at the provider, read from a verified ID
+// token
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Authorization is a login waiting at the provider. State goes back with
+// the callback and is also kept by the browser to tie both ends together
+type Authorization struct {
+	URL   string
+	State string
+}
+
+// LoginState is what is kept of an Authorization until the callback
+type LoginState struct {
+	StateHash    string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+// CodeChallenge derives the S256 PKCE challenge sent with the
+// authorization request from the verifier kept for the token request
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
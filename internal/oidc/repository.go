@@ -0,0 +1,16 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrStateNotFound = errors.New("login state not found")
+
+type Repository interface {
+	CreateState(ctx context.Context, state *LoginState) error
+	// ConsumeState deletes and returns a state that has not expired,
+	// ErrStateNotFound otherwise
+	ConsumeState(ctx context.Context, stateHash string) (*LoginState, error)
+	DeleteExpiredStates(ctx context.Context) error
+}
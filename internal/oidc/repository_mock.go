@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type mockRepository struct {
+	mu     sync.Mutex
+	states map[string]*LoginState
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		states: make(map[string]*LoginState),
+	}
+}
+
+func (m *mockRepository) CreateState(ctx context.Context, state *LoginState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := *state
+	m.states[state.StateHash] = &stored
+	return nil
+}
+
+func (m *mockRepository) ConsumeState(ctx context.Context, stateHash string) (*LoginState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, exists := m.states[stateHash]
+	if !exists || !state.ExpiresAt.After(time.Now()) {
+		return nil, ErrStateNotFound
+	}
+
+	delete(m.states, stateHash)
+	return state, nil
+}
+
+func (m *mockRepository) DeleteExpiredStates(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for hash, state := range m.states {
+		if !state.ExpiresAt.After(now) {
+			delete(m.states, hash)
+		}
+	}
+	return nil
+}
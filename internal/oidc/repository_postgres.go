@@ -0,0 +1,49 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) CreateState(ctx context.Context, state *LoginState) error {
+	return r.queries.CreateOIDCLoginState(ctx, database.CreateOIDCLoginStateParams{
+		StateHash:    state.StateHash,
+		CodeVerifier: state.CodeVerifier,
+		Nonce:        state.Nonce,
+		ExpiresAt:    state.ExpiresAt,
+	})
+}
+
+func (r *PostgresRepository) ConsumeState(ctx context.Context, stateHash string) (*LoginState, error) {
+	dbState, err := r.queries.ConsumeOIDCLoginState(ctx, stateHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotFound
+		}
+		return nil, err
+	}
+
+	return &LoginState{
+		StateHash:    dbState.StateHash,
+		CodeVerifier: dbState.CodeVerifier,
+		Nonce:        dbState.Nonce,
+		ExpiresAt:    dbState.ExpiresAt,
+	}, nil
+}
+
+func (r *PostgresRepository) DeleteExpiredStates(ctx context.Context) error {
+	return r.queries.DeleteExpiredOIDCLoginStates(ctx)
+}
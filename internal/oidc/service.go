@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luis-octavius/cintia/internal/auth"
+)
+
+var ErrInvalidState = errors.New("invalid or expired login state, start the login again")
+
+type Service interface {
+	// Begin starts a login and returns where to send the user
+	Begin(ctx context.Context) (*Authorization, error)
+	// Finish checks the state of the callback, redeems the code and
+	// returns who signed in. A state works once
+	Finish(ctx context.Context, state, code string) (*Identity, error)
+}
+
+type service struct {
+	repo     Repository
+	provider *Provider
+}
+
+func NewService(repo Repository, provider *Provider) Service {
+	return &service{repo: repo, provider: provider}
+}
+
+func (s *service) Begin(ctx context.Context) (*Authorization, error) {
+	// abandoned logins are cleared here, there are few of them
+	if err := s.repo.DeleteExpiredStates(ctx); err != nil {
+		log.Printf("failed to delete expired oidc login states: %v", err)
+	}
+
+	state, err := auth.MakeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := auth.MakeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	// 43 characters of base64url, the shortest verifier RFC 7636 allows
+	verifier, err := auth.MakeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, CodeChallenge(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.CreateState(ctx, &LoginState{
+		StateHash:    auth.HashToken(state),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(StateTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save login state: %w", err)
+	}
+
+	return &Authorization{URL: authURL, State: state}, nil
+}
+
+func (s *service) Finish(ctx context.Context, state, code string) (*Identity, error) {
+	if state == "" || code == "" {
+		return nil, ErrInvalidState
+	}
+
+	loginState, err := s.repo.ConsumeState(ctx, auth.HashToken(state))
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return nil, ErrInvalidState
+		}
+		return nil, fmt.Errorf("failed to get login state: %w", err)
+	}
+
+	idToken, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.provider.VerifyIDToken(ctx, idToken, loginState.Nonce)
+}
@@ -0,0 +1,173 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luis-octavius/cintia/internal/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:8080/api/users/oidc/callback"
+
+func TestBeginFinish_AuthorizationCodeWithPKCE(t *testing.T) {
+	// Setup
+	server, service := newTestService(t)
+	server.SetUser(oidctest.User{Subject: "42", Email: "ada@example.com", EmailVerified: true, Name: "Ada"})
+
+	authorization, err := service.Begin(context.Background())
+	require.NoError(t, err)
+
+	target, err := url.Parse(authorization.URL)
+	require.NoError(t, err)
+	q := target.Query()
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, authorization.State, q.Get("state"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.NotEmpty(t, q.Get("nonce"))
+
+	code, state := authorize(t, authorization.URL)
+
+	// Execute
+	identity, err := service.Finish(context.Background(), state, code)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, server.Issuer, identity.Issuer)
+	assert.Equal(t, "42", identity.Subject)
+	assert.Equal(t, "ada@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "Ada", identity.Name)
+
+	_, err = service.Finish(context.Background(), state, code)
+	assert.ErrorIs(t, err, ErrInvalidState, "a state works once")
+}
+
+func TestFinish_RejectsUnknownStateAndForeignCode(t *testing.T) {
+	// Setup
+	_, service := newTestService(t)
+
+	first, err := service.Begin(context.Background())
+	require.NoError(t, err)
+	second, err := service.Begin(context.Background())
+	require.NoError(t, err)
+	code, _ := authorize(t, first.URL)
+
+	// Execute
+	_, unknownErr := service.Finish(context.Background(), "forged", code)
+	// the code was bound to the first login's PKCE challenge
+	_, swappedErr := service.Finish(context.Background(), second.State, code)
+
+	// Assert
+	assert.ErrorIs(t, unknownErr, ErrInvalidState)
+	assert.ErrorIs(t, swappedErr, ErrProvider)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	server, _ := newTestService(t)
+	provider := NewProvider(Config{Issuer: server.Issuer, ClientID: "cintia", RedirectURL: redirectURL}, nil)
+	user := oidctest.User{Subject: "42", Email: "ada@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		valid  bool
+	}{
+		{"valid", func(c jwt.MapClaims) {}, true},
+		{"email_verified as string", func(c jwt.MapClaims) { c["email_verified"] = "true" }, true},
+		{"wrong nonce", func(c jwt.MapClaims) { c["nonce"] = "other" }, false},
+		{"other audience", func(c jwt.MapClaims) { c["aud"] = "someone-else" }, false},
+		{"other issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, false},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, false},
+		{"no subject", func(c jwt.MapClaims) { delete(c, "sub") }, false},
+		{"several audiences without azp", func(c jwt.MapClaims) { c["aud"] = []string{"cintia", "other"} }, false},
+		{"several audiences with azp", func(c jwt.MapClaims) {
+			c["aud"] = []string{"cintia", "other"}
+			c["azp"] = "cintia"
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := server.IDTokenClaims(user, "nonce")
+			tt.mutate(claims)
+			token, err := server.SignIDToken(claims)
+			require.NoError(t, err)
+
+			// Execute
+			identity, err := provider.VerifyIDToken(context.Background(), token, "nonce")
+
+			// Assert
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidIDToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "42", identity.Subject)
+			assert.True(t, identity.EmailVerified)
+		})
+	}
+
+	t.Run("hmac", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, server.IDTokenClaims(user, "nonce")).SignedString([]byte("cintia"))
+		require.NoError(t, err)
+
+		_, err = provider.VerifyIDToken(context.Background(), token, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestBegin_ProviderUnreachable(t *testing.T) {
+	// Setup
+	provider := NewProvider(Config{Issuer: "http://127.0.0.1:1", ClientID: "cintia", RedirectURL: redirectURL}, nil)
+	service := NewService(NewMockRepository(), provider)
+
+	// Execute
+	_, err := service.Begin(context.Background())
+
+	// Assert
+	assert.True(t, errors.Is(err, ErrProvider))
+}
+
+func newTestService(t *testing.T) (*oidctest.Server, Service) {
+	t.Helper()
+
+	server, err := oidctest.NewServer("cintia", "cintia-secret")
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	provider := NewProvider(Config{
+		Issuer:       server.Issuer,
+		ClientID:     "cintia",
+		ClientSecret: "cintia-secret",
+		RedirectURL:  redirectURL,
+	}, nil)
+	return server, NewService(NewMockRepository(), provider)
+}
+
+// authorize plays the browser at the provider and returns what the
+// callback would receive
+func authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Empty(t, location.Query().Get("error"))
+	return location.Query().Get("code"), location.Query().Get("state")
+}
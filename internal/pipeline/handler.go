@@ -0,0 +1,217 @@
+package pipeline
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	CreatePipelineHandler(c *gin.Context)
+	GetUserPipelinesHandler(c *gin.Context)
+	GetPipelineHandler(c *gin.Context)
+	SetDefaultPipelineHandler(c *gin.Context)
+	DeletePipelineHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/pipelines - create a custom pipeline
+func (h *GinHandler) CreatePipelineHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req CreatePipelineInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pipeline, err := h.service.CreatePipeline(c.Request.Context(), userID, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrMissingName),
+			errors.Is(err, ErrMissingStageKey),
+			errors.Is(err, ErrInvalidStageKind),
+			errors.Is(err, ErrNoStages),
+			errors.Is(err, ErrDuplicateStage),
+			errors.Is(err, ErrUnknownStage),
+			errors.Is(err, ErrTerminalTransition):
+			status = http.StatusBadRequest
+		}
+
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "pipeline created successfully",
+		"pipeline": pipeline,
+	})
+}
+
+// GET /api/pipelines - list system pipelines plus the user's own
+func (h *GinHandler) GetUserPipelinesHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	pipelines, err := h.service.GetUserPipelines(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pipelines": pipelines,
+		"total":     len(pipelines),
+	})
+}
+
+// GET /api/pipelines/:id - details of a pipeline
+func (h *GinHandler) GetPipelineHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid pipeline id format",
+		})
+		return
+	}
+
+	pipeline, err := h.service.GetPipeline(c.Request.Context(), pipelineID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrPipelineNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if pipeline.UserID != nil && !pipeline.IsOwnedBy(userID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "you don't have permission to view this pipeline",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pipeline": pipeline,
+	})
+}
+
+// PUT /api/pipelines/:id/default - use a pipeline for new applications
+func (h *GinHandler) SetDefaultPipelineHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid pipeline id format",
+		})
+		return
+	}
+
+	err = h.service.SetDefaultPipeline(c.Request.Context(), userID, pipelineID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "default pipeline updated successfully",
+	})
+}
+
+// DELETE /api/pipelines/:id - delete an unused custom pipeline
+func (h *GinHandler) DeletePipelineHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid pipeline id format",
+		})
+		return
+	}
+
+	err = h.service.DeletePipeline(c.Request.Context(), userID, pipelineID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "pipeline deleted successfully",
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrPipelineNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrSystemPipeline),
+		errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
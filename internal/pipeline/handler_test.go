@@ -0,0 +1,191 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePipelineHandler_Success(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler := NewGinHandler(NewService(NewMockRepository()))
+
+	reqBody := CreatePipelineInput{
+		Name: "Tech loop",
+		Stages: []StageInput{
+			{Key: "applied", Name: "Applied"},
+			{Key: "phone_screen", Name: "Phone screen"},
+			{Key: "take_home", Name: "Take-home"},
+			{Key: "withdrawn", Name: "Withdrawn", IsTerminal: true},
+		},
+		Transitions: []Transition{
+			{From: "applied", To: "phone_screen"},
+			{From: "phone_screen", To: "take_home"},
+			{From: "phone_screen", To: "withdrawn"},
+		},
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/pipelines", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.CreatePipelineHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Message  string   `json:"message"`
+		Pipeline Pipeline `json:"pipeline"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "pipeline created successfully", response.Message)
+	assert.Len(t, response.Pipeline.Stages, 4)
+	assert.True(t, response.Pipeline.CanTransition("phone_screen", "take_home"))
+}
+
+func TestCreatePipelineHandler_TerminalTransition(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler := NewGinHandler(NewService(NewMockRepository()))
+
+	reqBody := CreatePipelineInput{
+		Name: "Broken",
+		Stages: []StageInput{
+			{Key: "applied", Name: "Applied"},
+			{Key: "rejected", Name: "Rejected", IsTerminal: true},
+		},
+		Transitions: []Transition{
+			{From: "rejected", To: "applied"},
+		},
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/pipelines", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.CreatePipelineHandler(c)
+
+	// Assert - should return 400 Bad Request
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetPipelineHandler_OtherUser(t *testing.T) {
+	// Setup
+	ownerID := uuid.New()
+	pipelineID := uuid.New()
+
+	mockService := &mockPipelineService{
+		mockGetPipeline: func(ctx context.Context, id uuid.UUID) (*Pipeline, error) {
+			return &Pipeline{ID: pipelineID, UserID: &ownerID, Name: "Private"}, nil
+		},
+	}
+
+	handler := NewGinHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/pipelines/"+pipelineID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: pipelineID.String()}}
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.GetPipelineHandler(c)
+
+	// Assert - should return 403 Forbidden
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDeletePipelineHandler_SystemPipeline(t *testing.T) {
+	// Setup
+	handler := NewGinHandler(NewService(NewMockRepository()))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/pipelines/"+DefaultPipelineID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: DefaultPipelineID.String()}}
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.DeletePipelineHandler(c)
+
+	// Assert - system pipeline cannot be deleted
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+// Mock service for testing
+type mockPipelineService struct {
+	mockCreatePipeline      func(context.Context, uuid.UUID, CreatePipelineInput) (*Pipeline, error)
+	mockGetPipeline         func(context.Context, uuid.UUID) (*Pipeline, error)
+	mockGetUserPipelines    func(context.Context, uuid.UUID) ([]*Pipeline, error)
+	mockGetDefaultPipeline  func(context.Context, uuid.UUID) (*Pipeline, error)
+	mockGetPipelineForStage func(context.Context, uuid.UUID) (*Pipeline, error)
+	mockSetDefaultPipeline  func(context.Context, uuid.UUID, uuid.UUID) error
+	mockDeletePipeline      func(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+func (m *mockPipelineService) CreatePipeline(ctx context.Context, userID uuid.UUID, input CreatePipelineInput) (*Pipeline, error) {
+	if m.mockCreatePipeline != nil {
+		return m.mockCreatePipeline(ctx, userID, input)
+	}
+	return nil, nil
+}
+
+func (m *mockPipelineService) GetPipeline(ctx context.Context, id uuid.UUID) (*Pipeline, error) {
+	if m.mockGetPipeline != nil {
+		return m.mockGetPipeline(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *mockPipelineService) GetUserPipelines(ctx context.Context, userID uuid.UUID) ([]*Pipeline, error) {
+	if m.mockGetUserPipelines != nil {
+		return m.mockGetUserPipelines(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockPipelineService) GetDefaultPipeline(ctx context.Context, userID uuid.UUID) (*Pipeline, error) {
+	if m.mockGetDefaultPipeline != nil {
+		return m.mockGetDefaultPipeline(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockPipelineService) GetPipelineForStage(ctx context.Context, stageID uuid.UUID) (*Pipeline, error) {
+	if m.mockGetPipelineForStage != nil {
+		return m.mockGetPipelineForStage(ctx, stageID)
+	}
+	return nil, nil
+}
+
+func (m *mockPipelineService) SetDefaultPipeline(ctx context.Context, userID, id uuid.UUID) error {
+	if m.mockSetDefaultPipeline != nil {
+		return m.mockSetDefaultPipeline(ctx, userID, id)
+	}
+	return nil
+}
+
+func (m *mockPipelineService) DeletePipeline(ctx context.Context, userID, id uuid.UUID) error {
+	if m.mockDeletePipeline != nil {
+		return m.mockDeletePipeline(ctx, userID, id)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPipelineID is the system pipeline seeded by the migrations, used
+// whenever a user has not configured a pipeline of their own
+var DefaultPipelineID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// StageKind tells what a stage means for the job search whatever its key,
+// so stats and offers work the same on custom pipelines
+type StageKind string
+
+const (
+	KindActive    StageKind = "active"
+	KindInterview StageKind = "interview"
+	KindOffer     StageKind = "offer"
+	KindAccepted  StageKind = "accepted"
+	KindRejected  StageKind = "rejected"
+	KindWithdrawn StageKind = "withdrawn"
+	KindGhosted   StageKind = "ghosted"
+)
+
+// IsValid validate the stage kind
+func (k StageKind) IsValid() bool {
+	switch k {
+	case KindActive, KindInterview, KindOffer, KindAccepted, KindRejected, KindWithdrawn, KindGhosted:
+		return true
+	}
+	return false
+}
+
+// ReachedInterview reports whether applications in stages of this kind got
+// at least to the interview step of the funnel
+func (k StageKind) ReachedInterview() bool {
+	return k == KindInterview || k.ReachedOffer()
+}
+
+// ReachedOffer reports whether applications in stages of this kind got an
+// offer, accepted or not yet
+func (k StageKind) ReachedOffer() bool {
+	return k == KindOffer || k == KindAccepted
+}
+
+type Stage struct {
+	ID         uuid.UUID `json:"id"`
+	PipelineID uuid.UUID `json:"pipeline_id"`
+	Key        string    `json:"key"`
+	Name       string    `json:"name"`
+	Position   int       `json:"position"`
+	IsTerminal bool      `json:"is_terminal"`
+	Kind       StageKind `json:"kind"`
+}
+
+type Transition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type Pipeline struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      *uuid.UUID   `json:"user_id,omitempty"` // nil for system pipelines
+	Name        string       `json:"name"`
+	IsDefault   bool         `json:"is_default"`
+	Stages      []Stage      `json:"stages"`
+	Transitions []Transition `json:"transitions"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type StageInput struct {
+	Key        string    `json:"key" binding:"required"`
+	Name       string    `json:"name" binding:"required"`
+	IsTerminal bool      `json:"is_terminal,omitempty"`
+	Kind       StageKind `json:"kind,omitempty"` // defaults to active
+}
+
+type CreatePipelineInput struct {
+	Name        string       `json:"name" binding:"required"`
+	IsDefault   bool         `json:"is_default,omitempty"`
+	Stages      []StageInput `json:"stages" binding:"required"`
+	Transitions []Transition `json:"transitions"`
+}
+
+// DefaultPipeline mirrors the system pipeline seeded in the database, so
+// in-memory repositories and tests share the same stages and transitions
+func DefaultPipeline() *Pipeline {
+	return &Pipeline{
+		ID:        DefaultPipelineID,
+		Name:      "Default",
+		IsDefault: true,
+		Stages: []Stage{
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000101"), PipelineID: DefaultPipelineID, Key: "applied", Name: "Applied", Position: 0, Kind: KindActive},
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000102"), PipelineID: DefaultPipelineID, Key: "interviewing", Name: "Interviewing", Position: 1, Kind: KindInterview},
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000103"), PipelineID: DefaultPipelineID, Key: "offer", Name: "Offer", Position: 2, Kind: KindOffer},
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000104"), PipelineID: DefaultPipelineID, Key: "accepted", Name: "Accepted", Position: 3, IsTerminal: true, Kind: KindAccepted},
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000105"), PipelineID: DefaultPipelineID, Key: "rejected", Name: "Rejected", Position: 4, IsTerminal: true, Kind: KindRejected},
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000106"), PipelineID: DefaultPipelineID, Key: "withdrawn", Name: "Withdrawn", Position: 5, IsTerminal: true, Kind: KindWithdrawn},
+			{ID: uuid.MustParse("00000000-0000-0000-0000-000000000107"), PipelineID: DefaultPipelineID, Key: "ghosted", Name: "Ghosted", Position: 6, Kind: KindGhosted},
+		},
+		Transitions: []Transition{
+			{From: "applied", To: "interviewing"},
+			{From: "applied", To: "rejected"},
+			{From: "interviewing", To: "offer"},
+			{From: "interviewing", To: "rejected"},
+			{From: "offer", To: "accepted"},
+			{From: "offer", To: "rejected"},
+			{From: "applied", To: "withdrawn"},
+			{From: "interviewing", To: "withdrawn"},
+			{From: "offer", To: "withdrawn"},
+			{From: "applied", To: "ghosted"},
+			{From: "interviewing", To: "ghosted"},
+			{From: "ghosted", To: "applied"},
+			{From: "ghosted", To: "interviewing"},
+			{From: "ghosted", To: "rejected"},
+			{From: "ghosted", To: "withdrawn"},
+		},
+	}
+}
+
+// InitialStage returns the stage new applications start in
+func (p *Pipeline) InitialStage() *Stage {
+	if len(p.Stages) == 0 {
+		return nil
+	}
+	return &p.Stages[0]
+}
+
+// StageByKey finds a stage of the pipeline by its key
+func (p *Pipeline) StageByKey(key string) (*Stage, bool) {
+	for i := range p.Stages {
+		if p.Stages[i].Key == key {
+			return &p.Stages[i], true
+		}
+	}
+	return nil, false
+}
+
+// StageByID finds a stage of the pipeline by its ID
+func (p *Pipeline) StageByID(id uuid.UUID) (*Stage, bool) {
+	for i := range p.Stages {
+		if p.Stages[i].ID == id {
+			return &p.Stages[i], true
+		}
+	}
+	return nil, false
+}
+
+// CanTransition checks if the pipeline allows moving from one stage to
+// another. Terminal stages never allow leaving them
+func (p *Pipeline) CanTransition(from, to string) bool {
+	stage, ok := p.StageByKey(from)
+	if !ok || stage.IsTerminal {
+		return false
+	}
+
+	if _, ok := p.StageByKey(to); !ok {
+		return false
+	}
+
+	for _, t := range p.Transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOwnedBy reports whether the pipeline belongs to the given user.
+// System pipelines are visible to everyone but owned by no one
+func (p *Pipeline) IsOwnedBy(userID uuid.UUID) bool {
+	return p.UserID != nil && *p.UserID == userID
+}
@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDefaultPipeline_Transitions(t *testing.T) {
+	p := DefaultPipeline()
+
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{"applied", "interviewing", true},
+		{"applied", "rejected", true},
+		{"interviewing", "offer", true},
+		{"offer", "accepted", true},
+		{"applied", "offer", false},
+		{"accepted", "rejected", false},
+		{"rejected", "applied", false},
+		{"applied", "unknown", false},
+		{"interviewing", "ghosted", true},
+		{"ghosted", "interviewing", true},
+		{"offer", "ghosted", false},
+		{"withdrawn", "applied", false},
+	}
+
+	for _, tc := range cases {
+		if got := p.CanTransition(tc.from, tc.to); got != tc.want {
+			t.Errorf("CanTransition(%s, %s) = %v, expected %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestDefaultPipeline_InitialStage(t *testing.T) {
+	stage := DefaultPipeline().InitialStage()
+	if stage == nil || stage.Key != "applied" {
+		t.Fatalf("expected initial stage to be applied, got %+v", stage)
+	}
+}
+
+func TestSetDefaultPipeline_SystemPipelineRestoresDefault(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	service := NewService(NewMockRepository())
+
+	custom, err := service.CreatePipeline(ctx, userID, CreatePipelineInput{
+		Name:   "Tech loop",
+		Stages: []StageInput{{Key: "applied", Name: "Applied"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+	if err := service.SetDefaultPipeline(ctx, userID, custom.ID); err != nil {
+		t.Fatalf("failed to set custom default: %v", err)
+	}
+
+	if err := service.SetDefaultPipeline(ctx, userID, DefaultPipeline().ID); err != nil {
+		t.Fatalf("expected system pipeline to be selectable, got %v", err)
+	}
+
+	def, err := service.GetDefaultPipeline(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get default pipeline: %v", err)
+	}
+	if def.UserID != nil {
+		t.Fatalf("expected the system pipeline as default, got %s", def.Name)
+	}
+}
+
+func TestCreatePipeline_StageKinds(t *testing.T) {
+	service := NewService(NewMockRepository())
+
+	created, err := service.CreatePipeline(context.Background(), uuid.New(), CreatePipelineInput{
+		Name: "Tech loop",
+		Stages: []StageInput{
+			{Key: "applied", Name: "Applied"},
+			{Key: "final_round", Name: "Final round", Kind: KindInterview},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+	if created.Stages[0].Kind != KindActive || created.Stages[1].Kind != KindInterview {
+		t.Fatalf("expected active and interview kinds, got %s and %s", created.Stages[0].Kind, created.Stages[1].Kind)
+	}
+
+	_, err = service.CreatePipeline(context.Background(), uuid.New(), CreatePipelineInput{
+		Name:   "Broken",
+		Stages: []StageInput{{Key: "applied", Name: "Applied", Kind: "hired"}},
+	})
+	if err != ErrInvalidStageKind {
+		t.Fatalf("expected ErrInvalidStageKind, got %v", err)
+	}
+}
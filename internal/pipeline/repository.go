@@ -0,0 +1,26 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound = errors.New("pipeline not found")
+	ErrInUse    = errors.New("pipeline has applications in its stages")
+)
+
+type Repository interface {
+	Create(ctx context.Context, pipeline *Pipeline) (*Pipeline, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Pipeline, error)
+	GetByStageID(ctx context.Context, stageID uuid.UUID) (*Pipeline, error)
+	GetUserPipelines(ctx context.Context, userID uuid.UUID) ([]*Pipeline, error)
+	GetDefault(ctx context.Context, userID uuid.UUID) (*Pipeline, error)
+	SetDefault(ctx context.Context, userID, id uuid.UUID) error
+	// ClearDefault drops the user's own default, the system default applies
+	// again
+	ClearDefault(ctx context.Context, userID uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,159 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu        sync.RWMutex
+	pipelines map[uuid.UUID]*Pipeline
+}
+
+// NewMockRepository returns an in-memory repository seeded with the
+// system default pipeline
+func NewMockRepository() Repository {
+	def := DefaultPipeline()
+	return &mockRepository{
+		pipelines: map[uuid.UUID]*Pipeline{def.ID: def},
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, pipeline *Pipeline) (*Pipeline, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if pipeline.ID == uuid.Nil {
+		pipeline.ID = uuid.New()
+	}
+
+	for i := range pipeline.Stages {
+		if pipeline.Stages[i].ID == uuid.Nil {
+			pipeline.Stages[i].ID = uuid.New()
+		}
+		pipeline.Stages[i].PipelineID = pipeline.ID
+		pipeline.Stages[i].Position = i
+	}
+
+	if pipeline.IsDefault && pipeline.UserID != nil {
+		m.clearDefault(*pipeline.UserID)
+	}
+
+	now := time.Now()
+	pipeline.CreatedAt = now
+	pipeline.UpdatedAt = now
+
+	m.pipelines[pipeline.ID] = pipeline
+	return pipeline, nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Pipeline, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pipeline, exists := m.pipelines[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return pipeline, nil
+}
+
+func (m *mockRepository) GetByStageID(ctx context.Context, stageID uuid.UUID) (*Pipeline, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, pipeline := range m.pipelines {
+		if _, ok := pipeline.StageByID(stageID); ok {
+			return pipeline, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) GetUserPipelines(ctx context.Context, userID uuid.UUID) ([]*Pipeline, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pipelines := []*Pipeline{}
+	for _, pipeline := range m.pipelines {
+		if pipeline.UserID == nil || *pipeline.UserID == userID {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+
+	return pipelines, nil
+}
+
+func (m *mockRepository) GetDefault(ctx context.Context, userID uuid.UUID) (*Pipeline, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var fallback *Pipeline
+	for _, pipeline := range m.pipelines {
+		if !pipeline.IsDefault {
+			continue
+		}
+		if pipeline.IsOwnedBy(userID) {
+			return pipeline, nil
+		}
+		if pipeline.UserID == nil {
+			fallback = pipeline
+		}
+	}
+
+	if fallback == nil {
+		return nil, ErrNotFound
+	}
+
+	return fallback, nil
+}
+
+func (m *mockRepository) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pipeline, exists := m.pipelines[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	m.clearDefault(userID)
+	pipeline.IsDefault = true
+	pipeline.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *mockRepository) ClearDefault(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.clearDefault(userID)
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.pipelines[id]; !exists {
+		return ErrNotFound
+	}
+
+	delete(m.pipelines, id)
+	return nil
+}
+
+// clearDefault unsets the default flag of every pipeline owned by the
+// user. Callers must hold the lock
+func (m *mockRepository) clearDefault(userID uuid.UUID) {
+	for _, pipeline := range m.pipelines {
+		if pipeline.IsOwnedBy(userID) {
+			pipeline.IsDefault = false
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		db:      db,
+		queries: database.New(db),
+	}
+}
+
+// Create inserts the pipeline with its stages and transitions in a single
+// transaction, so a pipeline is never visible half-built
+func (r *PostgresRepository) Create(ctx context.Context, pipeline *Pipeline) (*Pipeline, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if pipeline.IsDefault && pipeline.UserID != nil {
+		if err := qtx.ClearUserDefaultPipeline(ctx, toNullUUID(pipeline.UserID)); err != nil {
+			return nil, err
+		}
+	}
+
+	dbPipeline, err := qtx.CreatePipeline(ctx, database.CreatePipelineParams{
+		UserID:    toNullUUID(pipeline.UserID),
+		Name:      pipeline.Name,
+		IsDefault: pipeline.IsDefault,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stageIDs := make(map[string]uuid.UUID, len(pipeline.Stages))
+	dbStages := make([]database.PipelineStage, 0, len(pipeline.Stages))
+	for i, stage := range pipeline.Stages {
+		dbStage, err := qtx.CreatePipelineStage(ctx, database.CreatePipelineStageParams{
+			PipelineID: dbPipeline.ID,
+			Key:        stage.Key,
+			Name:       stage.Name,
+			Position:   int32(i),
+			IsTerminal: stage.IsTerminal,
+			Kind:       string(stage.Kind),
+		})
+		if err != nil {
+			return nil, err
+		}
+		stageIDs[dbStage.Key] = dbStage.ID
+		dbStages = append(dbStages, dbStage)
+	}
+
+	dbTransitions := make([]database.PipelineTransition, 0, len(pipeline.Transitions))
+	for _, t := range pipeline.Transitions {
+		transition := database.PipelineTransition{
+			PipelineID:  dbPipeline.ID,
+			FromStageID: stageIDs[t.From],
+			ToStageID:   stageIDs[t.To],
+		}
+		if err := qtx.CreatePipelineTransition(ctx, database.CreatePipelineTransitionParams(transition)); err != nil {
+			return nil, err
+		}
+		dbTransitions = append(dbTransitions, transition)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return dbPipelineToPipeline(&dbPipeline, dbStages, dbTransitions), nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*Pipeline, error) {
+	dbPipeline, err := r.queries.GetPipelineByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.load(ctx, &dbPipeline)
+}
+
+func (r *PostgresRepository) GetByStageID(ctx context.Context, stageID uuid.UUID) (*Pipeline, error) {
+	dbPipeline, err := r.queries.GetPipelineByStageID(ctx, stageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.load(ctx, &dbPipeline)
+}
+
+func (r *PostgresRepository) GetUserPipelines(ctx context.Context, userID uuid.UUID) ([]*Pipeline, error) {
+	dbPipelines, err := r.queries.GetUserPipelines(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make([]*Pipeline, len(dbPipelines))
+	for i := range dbPipelines {
+		pipelines[i], err = r.load(ctx, &dbPipelines[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pipelines, nil
+}
+
+func (r *PostgresRepository) GetDefault(ctx context.Context, userID uuid.UUID) (*Pipeline, error) {
+	dbPipeline, err := r.queries.GetDefaultPipeline(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.load(ctx, &dbPipeline)
+}
+
+func (r *PostgresRepository) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := qtx.ClearUserDefaultPipeline(ctx, uuid.NullUUID{UUID: userID, Valid: true}); err != nil {
+		return err
+	}
+
+	if err := qtx.SetPipelineDefault(ctx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresRepository) ClearDefault(ctx context.Context, userID uuid.UUID) error {
+	return r.queries.ClearUserDefaultPipeline(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.queries.DeletePipeline(ctx, id)
+	if err != nil {
+		// applications still pointing to one of the stages
+		if strings.Contains(err.Error(), "fk_applications_stage") {
+			return ErrInUse
+		}
+		return err
+	}
+	return nil
+}
+
+// load fetches the stages and transitions of a pipeline row
+func (r *PostgresRepository) load(ctx context.Context, dbPipeline *database.Pipeline) (*Pipeline, error) {
+	dbStages, err := r.queries.GetPipelineStages(ctx, dbPipeline.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline stages: %w", err)
+	}
+
+	dbTransitions, err := r.queries.GetPipelineTransitions(ctx, dbPipeline.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline transitions: %w", err)
+	}
+
+	return dbPipelineToPipeline(dbPipeline, dbStages, dbTransitions), nil
+}
+
+// Helper functions to convert between domain and database models
+
+func dbPipelineToPipeline(dbPipeline *database.Pipeline, dbStages []database.PipelineStage, dbTransitions []database.PipelineTransition) *Pipeline {
+	pipeline := &Pipeline{
+		ID:          dbPipeline.ID,
+		Name:        dbPipeline.Name,
+		IsDefault:   dbPipeline.IsDefault,
+		Stages:      make([]Stage, len(dbStages)),
+		Transitions: make([]Transition, 0, len(dbTransitions)),
+		CreatedAt:   dbPipeline.CreatedAt,
+		UpdatedAt:   dbPipeline.UpdatedAt,
+	}
+
+	if dbPipeline.UserID.Valid {
+		pipeline.UserID = &dbPipeline.UserID.UUID
+	}
+
+	keys := make(map[uuid.UUID]string, len(dbStages))
+	for i, dbStage := range dbStages {
+		pipeline.Stages[i] = Stage{
+			ID:         dbStage.ID,
+			PipelineID: dbStage.PipelineID,
+			Key:        dbStage.Key,
+			Name:       dbStage.Name,
+			Position:   int(dbStage.Position),
+			IsTerminal: dbStage.IsTerminal,
+			Kind:       StageKind(dbStage.Kind),
+		}
+		keys[dbStage.ID] = dbStage.Key
+	}
+
+	for _, t := range dbTransitions {
+		pipeline.Transitions = append(pipeline.Transitions, Transition{
+			From: keys[t.FromStageID],
+			To:   keys[t.ToStageID],
+		})
+	}
+
+	return pipeline
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{Valid: false}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
@@ -0,0 +1,219 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPipelineNotFound   = errors.New("pipeline not found")
+	ErrMissingName        = errors.New("pipeline name is required")
+	ErrNoStages           = errors.New("pipeline must have at least one stage")
+	ErrMissingStageKey    = errors.New("stage key is required")
+	ErrInvalidStageKind   = errors.New("invalid stage kind")
+	ErrDuplicateStage     = errors.New("stage keys must be unique within a pipeline")
+	ErrUnknownStage       = errors.New("transition references an unknown stage")
+	ErrTerminalTransition = errors.New("terminal stages cannot have outgoing transitions")
+	ErrSystemPipeline     = errors.New("system pipelines cannot be modified")
+	ErrForbidden          = errors.New("pipeline belongs to another user")
+)
+
+type Service interface {
+	CreatePipeline(ctx context.Context, userID uuid.UUID, input CreatePipelineInput) (*Pipeline, error)
+	GetPipeline(ctx context.Context, id uuid.UUID) (*Pipeline, error)
+	GetUserPipelines(ctx context.Context, userID uuid.UUID) ([]*Pipeline, error)
+	GetDefaultPipeline(ctx context.Context, userID uuid.UUID) (*Pipeline, error)
+	GetPipelineForStage(ctx context.Context, stageID uuid.UUID) (*Pipeline, error)
+	SetDefaultPipeline(ctx context.Context, userID, id uuid.UUID) error
+	DeletePipeline(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) CreatePipeline(ctx context.Context, userID uuid.UUID, input CreatePipelineInput) (*Pipeline, error) {
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, ErrMissingName
+	}
+
+	if len(input.Stages) == 0 {
+		return nil, ErrNoStages
+	}
+
+	stages := make([]Stage, 0, len(input.Stages))
+	terminal := make(map[string]bool, len(input.Stages))
+	for i, in := range input.Stages {
+		key := strings.ToLower(strings.TrimSpace(in.Key))
+		if key == "" {
+			return nil, ErrMissingStageKey
+		}
+		if _, exists := terminal[key]; exists {
+			return nil, ErrDuplicateStage
+		}
+		terminal[key] = in.IsTerminal
+
+		name := in.Name
+		if name == "" {
+			name = key
+		}
+
+		kind := in.Kind
+		if kind == "" {
+			kind = KindActive
+		}
+		if !kind.IsValid() {
+			return nil, ErrInvalidStageKind
+		}
+
+		stages = append(stages, Stage{
+			Key:        key,
+			Name:       name,
+			Position:   i,
+			IsTerminal: in.IsTerminal,
+			Kind:       kind,
+		})
+	}
+
+	transitions := make([]Transition, 0, len(input.Transitions))
+	seen := make(map[Transition]struct{}, len(input.Transitions))
+	for _, t := range input.Transitions {
+		t.From = strings.ToLower(strings.TrimSpace(t.From))
+		t.To = strings.ToLower(strings.TrimSpace(t.To))
+
+		isTerminal, fromExists := terminal[t.From]
+		_, toExists := terminal[t.To]
+		if !fromExists || !toExists {
+			return nil, ErrUnknownStage
+		}
+		if isTerminal {
+			return nil, ErrTerminalTransition
+		}
+
+		if _, dup := seen[t]; dup {
+			continue
+		}
+		seen[t] = struct{}{}
+		transitions = append(transitions, t)
+	}
+
+	pipeline := &Pipeline{
+		UserID:      &userID,
+		Name:        input.Name,
+		IsDefault:   input.IsDefault,
+		Stages:      stages,
+		Transitions: transitions,
+	}
+
+	created, err := s.repo.Create(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipeline: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *service) GetPipeline(ctx context.Context, id uuid.UUID) (*Pipeline, error) {
+	pipeline, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrPipelineNotFound
+		}
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+func (s *service) GetUserPipelines(ctx context.Context, userID uuid.UUID) ([]*Pipeline, error) {
+	pipelines, err := s.repo.GetUserPipelines(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+
+	return pipelines, nil
+}
+
+// GetDefaultPipeline returns the user's default pipeline, falling back to
+// the system pipeline when the user has none
+func (s *service) GetDefaultPipeline(ctx context.Context, userID uuid.UUID) (*Pipeline, error) {
+	pipeline, err := s.repo.GetDefault(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrPipelineNotFound
+		}
+		return nil, fmt.Errorf("failed to get default pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+func (s *service) GetPipelineForStage(ctx context.Context, stageID uuid.UUID) (*Pipeline, error) {
+	pipeline, err := s.repo.GetByStageID(ctx, stageID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrPipelineNotFound
+		}
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+func (s *service) SetDefaultPipeline(ctx context.Context, userID, id uuid.UUID) error {
+	pipeline, err := s.GetPipeline(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// the system pipeline is shared and stays marked as default, picking it
+	// again only drops the user's own default
+	if pipeline.UserID == nil {
+		if err := s.repo.ClearDefault(ctx, userID); err != nil {
+			return fmt.Errorf("failed to set default pipeline: %w", err)
+		}
+		return nil
+	}
+
+	if !pipeline.IsOwnedBy(userID) {
+		return ErrForbidden
+	}
+
+	if err := s.repo.SetDefault(ctx, userID, id); err != nil {
+		return fmt.Errorf("failed to set default pipeline: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) DeletePipeline(ctx context.Context, userID, id uuid.UUID) error {
+	pipeline, err := s.GetPipeline(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if pipeline.UserID == nil {
+		return ErrSystemPipeline
+	}
+
+	if !pipeline.IsOwnedBy(userID) {
+		return ErrForbidden
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, ErrInUse) {
+			return ErrInUse
+		}
+		return fmt.Errorf("failed to delete pipeline: %w", err)
+	}
+
+	return nil
+}
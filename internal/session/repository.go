@@ -0,0 +1,27 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound = errors.New("session not found")
+	// ErrAlreadyRotated means another request exchanged the refresh token
+	// first
+	ErrAlreadyRotated = errors.New("session already rotated")
+)
+
+type Repository interface {
+	Create(ctx context.Context, session *Session) (*Session, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+	// Rotate marks the session as rotated and creates next in its place,
+	// both or neither. It returns ErrAlreadyRotated when the session was
+	// rotated or revoked before
+	Rotate(ctx context.Context, id uuid.UUID, next *Session) (*Session, error)
+	RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) error
+	IsFamilyActive(ctx context.Context, familyID uuid.UUID) (bool, error)
+}
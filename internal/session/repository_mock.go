@@ -0,0 +1,105 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]*Session
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		sessions: make(map[uuid.UUID]*Session),
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, session *Session) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session.ID == uuid.Nil {
+		session.ID = uuid.New()
+	}
+	session.CreatedAt = time.Now()
+
+	stored := *session
+	m.sessions[session.ID] = &stored
+	return session, nil
+}
+
+func (m *mockRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, session := range m.sessions {
+		if session.TokenHash == tokenHash {
+			found := *session
+			return &found, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) Rotate(ctx context.Context, id uuid.UUID, next *Session) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, exists := m.sessions[id]
+	if !exists || session.RotatedAt != nil || session.RevokedAt != nil {
+		return nil, ErrAlreadyRotated
+	}
+
+	now := time.Now()
+	session.RotatedAt = &now
+
+	next.ID = uuid.New()
+	next.CreatedAt = now
+	stored := *next
+	m.sessions[next.ID] = &stored
+	return next, nil
+}
+
+func (m *mockRepository) RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, session := range m.sessions {
+		if session.FamilyID == familyID && session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, session := range m.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) IsFamilyActive(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	for _, session := range m.sessions {
+		if session.FamilyID == familyID && session.IsActive(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	db      *sql.DB
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		db:      db,
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, session *Session) (*Session, error) {
+	return create(ctx, r.queries, session)
+}
+
+func create(ctx context.Context, queries *database.Queries, session *Session) (*Session, error) {
+	dbSession, err := queries.CreateSession(ctx, database.CreateSessionParams{
+		FamilyID:  session.FamilyID,
+		UserID:    session.UserID,
+		TokenHash: session.TokenHash,
+		UserAgent: toNullString(session.UserAgent),
+		IpAddress: toNullString(session.IPAddress),
+		ExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbSessionToSession(&dbSession), nil
+}
+
+func (r *PostgresRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*Session, error) {
+	dbSession, err := r.queries.GetSessionByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbSessionToSession(&dbSession), nil
+}
+
+// Rotate runs in a transaction: until it commits, other requests still see
+// the old token as the live one and the family stays active
+func (r *PostgresRepository) Rotate(ctx context.Context, id uuid.UUID, next *Session) (*Session, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	rows, err := qtx.MarkSessionRotated(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrAlreadyRotated
+	}
+
+	session, err := create(ctx, qtx, next)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *PostgresRepository) RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	return r.queries.RevokeSessionFamily(ctx, database.RevokeSessionFamilyParams{
+		FamilyID: familyID,
+		UserID:   userID,
+	})
+}
+
+func (r *PostgresRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return r.queries.RevokeUserSessions(ctx, userID)
+}
+
+func (r *PostgresRepository) IsFamilyActive(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	return r.queries.IsSessionFamilyActive(ctx, familyID)
+}
+
+func dbSessionToSession(dbSession *database.Session) *Session {
+	return &Session{
+		ID:        dbSession.ID,
+		FamilyID:  dbSession.FamilyID,
+		UserID:    dbSession.UserID,
+		TokenHash: dbSession.TokenHash,
+		UserAgent: dbSession.UserAgent.String,
+		IPAddress: dbSession.IpAddress.String,
+		CreatedAt: dbSession.CreatedAt,
+		ExpiresAt: dbSession.ExpiresAt,
+		RotatedAt: fromNullTime(dbSession.RotatedAt),
+		RevokedAt: fromNullTime(dbSession.RevokedAt),
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
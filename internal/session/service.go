@@ -0,0 +1,143 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+)
+
+// RefreshTokenTTL is how long a refresh token can go unused. Each rotation
+// starts a new period
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, the session has been revoked")
+)
+
+type Service interface {
+	// Start opens a new session family at login
+	Start(ctx context.Context, userID uuid.UUID, client Client) (*Issued, error)
+	// Rotate exchanges a refresh token for a new one in the same family.
+	// Presenting a token that was already exchanged revokes the family
+	Rotate(ctx context.Context, refreshToken string, client Client) (*Issued, error)
+	Revoke(ctx context.Context, userID, familyID uuid.UUID) error
+	RevokeAll(ctx context.Context, userID uuid.UUID) error
+	// IsActive reports whether access tokens of the family are still valid
+	IsActive(ctx context.Context, familyID uuid.UUID) (bool, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) Start(ctx context.Context, userID uuid.UUID, client Client) (*Issued, error) {
+	token, next, err := newSession(userID, uuid.New(), client)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.Create(ctx, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return &Issued{Session: session, RefreshToken: token}, nil
+}
+
+func (s *service) Rotate(ctx context.Context, refreshToken string, client Client) (*Issued, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	current, err := s.repo.GetByTokenHash(ctx, auth.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if current.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if current.RotatedAt != nil {
+		return nil, s.reused(ctx, current)
+	}
+	if !time.Now().Before(current.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	token, next, err := newSession(current.UserID, current.FamilyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.Rotate(ctx, current.ID, next)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyRotated) {
+			return nil, s.reused(ctx, current)
+		}
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	return &Issued{Session: session, RefreshToken: token}, nil
+}
+
+func (s *service) Revoke(ctx context.Context, userID, familyID uuid.UUID) error {
+	if err := s.repo.RevokeFamily(ctx, userID, familyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+func (s *service) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.RevokeUserSessions(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (s *service) IsActive(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	active, err := s.repo.IsFamilyActive(ctx, familyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	return active, nil
+}
+
+// newSession generates a refresh token and the session row storing its
+// hash
+func newSession(userID, familyID uuid.UUID, client Client) (string, *Session, error) {
+	token, err := auth.MakeToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return token, &Session{
+		FamilyID:  familyID,
+		UserID:    userID,
+		TokenHash: auth.HashToken(token),
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+		ExpiresAt: time.Now().Add(RefreshTokenTTL),
+	}, nil
+}
+
+// reused revokes the family of a refresh token presented a second time:
+// either the client or an attacker holds a stolen copy, and there is no
+// telling which
+func (s *service) reused(ctx context.Context, session *Session) error {
+	if err := s.repo.RevokeFamily(ctx, session.UserID, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke reused session: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
@@ -0,0 +1,48 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate_ReplacesTokenInSameFamily(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository())
+	started, err := service.Start(context.Background(), uuid.New(), Client{UserAgent: "curl", IPAddress: "203.0.113.7"})
+	require.NoError(t, err)
+
+	// Execute
+	rotated, err := service.Rotate(context.Background(), started.RefreshToken, Client{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, started.RefreshToken, rotated.RefreshToken)
+	assert.Equal(t, started.Session.FamilyID, rotated.Session.FamilyID)
+	active, err := service.IsActive(context.Background(), started.Session.FamilyID)
+	require.NoError(t, err)
+	assert.True(t, active)
+}
+
+func TestRotate_ReuseRevokesFamily(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository())
+	started, err := service.Start(context.Background(), uuid.New(), Client{})
+	require.NoError(t, err)
+	rotated, err := service.Rotate(context.Background(), started.RefreshToken, Client{})
+	require.NoError(t, err)
+
+	// Execute
+	_, err = service.Rotate(context.Background(), started.RefreshToken, Client{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	_, err = service.Rotate(context.Background(), rotated.RefreshToken, Client{})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	active, err := service.IsActive(context.Background(), started.Session.FamilyID)
+	require.NoError(t, err)
+	assert.False(t, active)
+}
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one refresh token. Every rotation adds a session to the
+// family started at login; access tokens carry the family id. The token
+// itself is never stored, only its hash
+type Session struct {
+	ID        uuid.UUID  `json:"id"`
+	FamilyID  uuid.UUID  `json:"family_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	TokenHash string     `json:"-"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	IPAddress string     `json:"ip_address,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Client describes where a session is used from
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Issued is a new session with its refresh token, which is only known at
+// this point
+type Issued struct {
+	Session      *Session
+	RefreshToken string
+}
+
+// IsActive reports whether the refresh token can still be exchanged
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RotatedAt == nil && s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -2,17 +2,35 @@ package user
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/mfa"
+	"github.com/luis-octavius/cintia/internal/session"
 )
 
 type Handler interface {
 	RegisterHandler(c *gin.Context)
 	LoginHandler(c *gin.Context)
+	LoginSecondFactorHandler(c *gin.Context)
 	GetProfileHandler(c *gin.Context)
 	UpdateProfileHandler(c *gin.Context)
+	RefreshHandler(c *gin.Context)
+	LogoutHandler(c *gin.Context)
+	ListUsersHandler(c *gin.Context)
+	ChangeRoleHandler(c *gin.Context)
+	ForgotPasswordHandler(c *gin.Context)
+	ResetPasswordHandler(c *gin.Context)
+	VerifyEmailHandler(c *gin.Context)
+	ResendVerificationHandler(c *gin.Context)
+}
+
+type ChangeRoleInput struct {
+	Role string `json:"role" binding:"required"`
 }
 
 type GinHandler struct {
@@ -70,8 +88,13 @@ func (h *GinHandler) LoginHandler(c *gin.Context) {
 		return
 	}
 
+	req.Client = clientFromRequest(c)
+
 	response, err := h.service.Login(c.Request.Context(), req)
 	if err != nil {
+		if throttled(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": err.Error(),
 		})
@@ -79,14 +102,103 @@ func (h *GinHandler) LoginHandler(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, loginResponseBody(response))
+}
+
+// POST /api/users/login/2fa - finish a login with a two-factor code
+func (h *GinHandler) LoginSecondFactorHandler(c *gin.Context) {
+	var req SecondFactorInput
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	req.Client = clientFromRequest(c)
+
+	response, err := h.service.LoginSecondFactor(c.Request.Context(), req)
+	if err != nil {
+		if throttled(c, err) {
+			return
+		}
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidChallenge) || errors.Is(err, mfa.ErrInvalidCode) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, loginResponseBody(response))
+}
+
+// POST /api/users/refresh - exchange a refresh token for new tokens
+func (h *GinHandler) RefreshHandler(c *gin.Context) {
+	var req RefreshInput
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	req.Client = clientFromRequest(c)
+
+	response, err := h.service.Refresh(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, session.ErrInvalidRefreshToken) || errors.Is(err, session.ErrRefreshTokenReused) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, loginResponseBody(response))
+}
+
+// POST /api/users/logout?all=true - revoke the current session, or all of them
+func (h *GinHandler) LogoutHandler(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if c.Query("all") == "true" {
+		err = h.service.LogoutAll(c.Request.Context(), userID)
+	} else {
+		sessionID, parseErr := uuid.Parse(c.GetString("sessionID"))
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+			return
+		}
+		err = h.service.Logout(c.Request.Context(), userID, sessionID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"user": gin.H{
-			"id":    response.User.ID,
-			"name":  response.User.Name,
-			"email": response.User.Email,
-			"role":  response.User.Role,
-		},
-		"token": response.Token,
+		"message": "logged out successfully",
 	})
 }
 
@@ -114,12 +226,15 @@ func (h *GinHandler) GetProfileHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"user": gin.H{
-			"id":         user.ID,
-			"name":       user.Name,
-			"email":      user.Email,
-			"role":       user.Role,
-			"created_at": user.CreatedAt,
-			"updated_at": user.UpdatedAt,
+			"id":             user.ID,
+			"name":           user.Name,
+			"email":          user.Email,
+			"email_verified": user.EmailVerifiedAt != nil,
+			"role":           user.Role,
+			"locale":         user.Locale,
+			"notifications":  user.NotificationPreferences(),
+			"created_at":     user.CreatedAt,
+			"updated_at":     user.UpdatedAt,
 		},
 	})
 }
@@ -147,9 +262,9 @@ func (h *GinHandler) UpdateProfileHandler(c *gin.Context) {
 	}
 
 	// validate if there is at least one field with a value
-	if req.Name == "" && req.Email == "" && req.Password == "" {
+	if req.Name == "" && req.Email == "" && req.Password == "" && req.Locale == "" && len(req.Notifications) == 0 {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "at least one field (name, email, or password) must be provided",
+			"error": "at least one field (name, email, password, locale or notifications) must be provided",
 		})
 		return
 	}
@@ -160,7 +275,9 @@ func (h *GinHandler) UpdateProfileHandler(c *gin.Context) {
 		switch {
 		case errors.Is(err, ErrEmailExists):
 			status = http.StatusConflict
-		case errors.Is(err, ErrWeakPassword):
+		case errors.Is(err, ErrWeakPassword),
+			errors.Is(err, ErrInvalidLocale),
+			errors.Is(err, ErrUnknownKind):
 			status = http.StatusBadRequest
 		default:
 			status = http.StatusInternalServerError
@@ -172,12 +289,253 @@ func (h *GinHandler) UpdateProfileHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"user": gin.H{
-			"id":         user.ID,
-			"name":       user.Name,
-			"email":      user.Email,
-			"role":       user.Role,
-			"updated_at": user.UpdatedAt,
+			"id":             user.ID,
+			"name":           user.Name,
+			"email":          user.Email,
+			"email_verified": user.EmailVerifiedAt != nil,
+			"role":           user.Role,
+			"locale":         user.Locale,
+			"notifications":  user.NotificationPreferences(),
+			"updated_at":     user.UpdatedAt,
 		},
 		"message": "profile updated successfully",
 	})
 }
+
+// GET /api/admin/users?page=1&limit=50 - list every account
+func (h *GinHandler) ListUsersHandler(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		limit = 0
+	}
+
+	users, err := h.service.ListUsers(c.Request.Context(), page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := make([]gin.H, 0, len(users))
+	for _, user := range users {
+		response = append(response, adminUserBody(user))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": response,
+		"total": len(response),
+	})
+}
+
+// PUT /api/admin/users/:id/role - change the role of an account
+func (h *GinHandler) ChangeRoleHandler(c *gin.Context) {
+	actorIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		return
+	}
+
+	actorID, err := uuid.Parse(actorIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id format"})
+		return
+	}
+
+	var req ChangeRoleInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.service.ChangeRole(c.Request.Context(), actorID, userID, req.Role)
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrInvalidRole),
+			errors.Is(err, ErrOwnRole):
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":    adminUserBody(user),
+		"message": "role updated successfully",
+	})
+}
+
+// POST /api/users/password/forgot - mail a password reset link
+func (h *GinHandler) ForgotPasswordHandler(c *gin.Context) {
+	var req ForgotPasswordInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.ForgotPassword(c.Request.Context(), req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// the same answer whether or not the email has an account
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "if the email belongs to an account, a reset link is on its way",
+	})
+}
+
+// POST /api/users/password/reset - set a new password with a reset token
+func (h *GinHandler) ResetPasswordHandler(c *gin.Context) {
+	var req ResetPasswordInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), req); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrWeakPassword) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "password reset successfully, please log in again",
+	})
+}
+
+// GET /api/users/email/verify?token= - confirm an email with the mailed link
+func (h *GinHandler) VerifyEmailHandler(c *gin.Context) {
+	user, err := h.service.VerifyEmail(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidToken) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"email":   user.Email,
+		"message": "email verified successfully",
+	})
+}
+
+// POST /api/users/email/verify/resend - mail a new verification link
+func (h *GinHandler) ResendVerificationHandler(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := h.service.ResendVerification(c.Request.Context(), userID); err != nil {
+		var status int
+		switch {
+		case errors.Is(err, ErrAlreadyVerified):
+			status = http.StatusConflict
+		case errors.Is(err, ErrUserNotFound):
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "verification email sent",
+	})
+}
+
+func adminUserBody(user *User) gin.H {
+	return gin.H{
+		"id":         user.ID,
+		"name":       user.Name,
+		"email":      user.Email,
+		"role":       user.Role,
+		"created_at": user.CreatedAt,
+	}
+}
+
+func loginResponseBody(response *LoginResponse) gin.H {
+	if response.MFARequired {
+		return gin.H{
+			"mfa_required":    true,
+			"challenge_token": response.ChallengeToken,
+			"expires_at":      response.ExpiresAt,
+		}
+	}
+
+	return gin.H{
+		"user": gin.H{
+			"id":    response.User.ID,
+			"name":  response.User.Name,
+			"email": response.User.Email,
+			"role":  response.User.Role,
+		},
+		"token":         response.Token,
+		"expires_at":    response.ExpiresAt,
+		"refresh_token": response.RefreshToken,
+	}
+}
+
+func clientFromRequest(c *gin.Context) session.Client {
+	return session.Client{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// throttled answers 429 with a Retry-After header when err is a
+// *loginguard.ThrottledError and reports whether it did
+func throttled(c *gin.Context, err error) bool {
+	var throttledErr *loginguard.ThrottledError
+	if !errors.As(err, &throttledErr) {
+		return false
+	}
+
+	seconds := int(math.Ceil(throttledErr.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":       err.Error(),
+		"retry_after": seconds,
+	})
+	return true
+}
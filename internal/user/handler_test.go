@@ -4,12 +4,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/mfa"
+	"github.com/luis-octavius/cintia/internal/notification"
+	"github.com/luis-octavius/cintia/internal/oidc"
+	"github.com/luis-octavius/cintia/internal/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -114,6 +122,66 @@ func TestUpdateProfileHandler_ValidUpdate(t *testing.T) {
 	assert.Equal(t, "profile updated successfully", response["message"])
 }
 
+func TestUpdateProfileHandler_NotificationPreferences(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	existing, _ := repo.Create(context.Background(), &User{
+		Name:               "Maria",
+		Email:              "maria@example.com",
+		Locale:             "en",
+		MutedNotifications: []string{"weekly_digest"},
+	})
+	handler := NewGinHandler(NewService(repo, session.NewService(session.NewMockRepository()), mfa.NewService(mfa.NewMockRepository()), newGuard(), &recordingMailer{}, testKeys, nil))
+
+	body := []byte(`{"locale": "pt", "notifications": {"follow_up": false, "weekly_digest": true}}`)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/profile", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", existing.ID.String())
+
+	// Execute
+	handler.UpdateProfileHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		User struct {
+			Locale        string          `json:"locale"`
+			Notifications map[string]bool `json:"notifications"`
+		} `json:"user"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "pt-BR", response.User.Locale)
+	assert.False(t, response.User.Notifications["follow_up"])
+	assert.True(t, response.User.Notifications["weekly_digest"])
+	assert.True(t, response.User.Notifications["interview_reminder"])
+	assert.Equal(t, []string{"follow_up"}, existing.MutedNotifications)
+}
+
+func TestUpdateProfileHandler_UnknownNotificationKind(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	existing, _ := repo.Create(context.Background(), &User{Name: "Maria", Email: "maria@example.com"})
+	handler := NewGinHandler(NewService(repo, session.NewService(session.NewMockRepository()), mfa.NewService(mfa.NewMockRepository()), newGuard(), &recordingMailer{}, testKeys, nil))
+
+	body := []byte(`{"notifications": {"carrier_pigeon": true}}`)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/profile", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", existing.ID.String())
+
+	// Execute
+	handler.UpdateProfileHandler(c)
+
+	// Assert - should return 400 Bad Request
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestUpdateProfileHandler_NoFields(t *testing.T) {
 	// Setup
 	userID := uuid.New()
@@ -137,6 +205,512 @@ func TestUpdateProfileHandler_NoFields(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestRefreshHandler_RotatesAndDetectsReuse(t *testing.T) {
+	// Setup
+	service, sessions := newTestService()
+	login := registerAndLogin(t, service)
+	handler := NewGinHandler(service)
+
+	refresh := func(token string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(RefreshInput{RefreshToken: token})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/refresh", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.RefreshHandler(c)
+		return w
+	}
+
+	// Execute
+	w := refresh(login.RefreshToken)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var rotated struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+	assert.NotEmpty(t, rotated.Token)
+	assert.NotEqual(t, login.RefreshToken, rotated.RefreshToken)
+
+	// presenting the first token again revokes the whole family
+	assert.Equal(t, http.StatusUnauthorized, refresh(login.RefreshToken).Code)
+	assert.Equal(t, http.StatusUnauthorized, refresh(rotated.RefreshToken).Code)
+
+	claims, err := testKeys.ValidateJWT(rotated.Token)
+	require.NoError(t, err)
+	active, err := sessions.IsActive(context.Background(), uuid.MustParse(claims.SessionID))
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestLogoutHandler_RevokesSession(t *testing.T) {
+	// Setup
+	service, sessions := newTestService()
+	login := registerAndLogin(t, service)
+	handler := NewGinHandler(service)
+
+	claims, err := testKeys.ValidateJWT(login.Token)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/logout", nil)
+	c.Set("userID", claims.UserID)
+	c.Set("sessionID", claims.SessionID)
+
+	// Execute
+	handler.LogoutHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	active, err := sessions.IsActive(context.Background(), uuid.MustParse(claims.SessionID))
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestUpdateProfile_PasswordChangeRevokesSessions(t *testing.T) {
+	// Setup
+	service, sessions := newTestService()
+	first := registerAndLogin(t, service)
+	second, err := service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	// Execute
+	_, err = service.UpdateProfile(context.Background(), first.User.ID, UpdatesInput{Password: "battery staple"})
+	require.NoError(t, err)
+
+	// Assert
+	for _, login := range []*LoginResponse{first, second} {
+		claims, err := testKeys.ValidateJWT(login.Token)
+		require.NoError(t, err)
+		active, err := sessions.IsActive(context.Background(), uuid.MustParse(claims.SessionID))
+		require.NoError(t, err)
+		assert.False(t, active)
+	}
+}
+
+func TestChangeRoleHandler_PromotesAndSignsOut(t *testing.T) {
+	// Setup
+	service, sessions := newTestService()
+	login := registerAndLogin(t, service)
+	handler := NewGinHandler(service)
+
+	claims, err := testKeys.ValidateJWT(login.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "candidate", claims.Role)
+
+	body, _ := json.Marshal(ChangeRoleInput{Role: "recruiter"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/admin/users/"+login.User.ID.String()+"/role", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: login.User.ID.String()}}
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.ChangeRoleHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "recruiter", login.User.Role)
+
+	active, err := sessions.IsActive(context.Background(), uuid.MustParse(claims.SessionID))
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestChangeRoleHandler_Validation(t *testing.T) {
+	service, _ := newTestService()
+	login := registerAndLogin(t, service)
+
+	tests := []struct {
+		name    string
+		actorID uuid.UUID
+		role    string
+		status  int
+	}{
+		{"unknown role", uuid.New(), "superuser", http.StatusBadRequest},
+		{"own role", login.User.ID, "admin", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler := NewGinHandler(service)
+
+			body, _ := json.Marshal(ChangeRoleInput{Role: tt.role})
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("PUT", "/admin/users/"+login.User.ID.String()+"/role", bytes.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: login.User.ID.String()}}
+			c.Set("userID", tt.actorID.String())
+
+			// Execute
+			handler.ChangeRoleHandler(c)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, "candidate", login.User.Role)
+		})
+	}
+}
+
+func TestResetPasswordHandler_SingleUse(t *testing.T) {
+	// Setup
+	service, mailer, sessions := newMailingTestService()
+	login := registerAndLogin(t, service)
+	handler := NewGinHandler(service)
+
+	w := httptest.NewRecorder()
+	c := jsonRequest(w, "POST", "/users/password/forgot", ForgotPasswordInput{Email: "ada@example.com"})
+	handler.ForgotPasswordHandler(c)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, 1, mailer.resets)
+
+	// Execute
+	w = httptest.NewRecorder()
+	c = jsonRequest(w, "POST", "/users/password/reset", ResetPasswordInput{Token: mailer.resetToken, Password: "battery staple"})
+	handler.ResetPasswordHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	_, err := service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "correct horse"})
+	assert.Error(t, err)
+	_, err = service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "battery staple"})
+	assert.NoError(t, err)
+
+	claims, err := testKeys.ValidateJWT(login.Token)
+	require.NoError(t, err)
+	active, err := sessions.IsActive(context.Background(), uuid.MustParse(claims.SessionID))
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	w = httptest.NewRecorder()
+	c = jsonRequest(w, "POST", "/users/password/reset", ResetPasswordInput{Token: mailer.resetToken, Password: "another password"})
+	handler.ResetPasswordHandler(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestForgotPasswordHandler_UnknownEmail(t *testing.T) {
+	// Setup
+	service, mailer, _ := newMailingTestService()
+	handler := NewGinHandler(service)
+
+	w := httptest.NewRecorder()
+	c := jsonRequest(w, "POST", "/users/password/forgot", ForgotPasswordInput{Email: "nobody@example.com"})
+
+	// Execute
+	handler.ForgotPasswordHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, 0, mailer.resets)
+}
+
+func TestResetPassword_RejectsExpiredAndReplacedTokens(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	mailer := &recordingMailer{}
+	service := NewService(repo, session.NewService(session.NewMockRepository()), mfa.NewService(mfa.NewMockRepository()), newGuard(), mailer, testKeys, nil)
+	user, err := service.Register(context.Background(), RegisterInput{Name: "Ada", Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	_, err = repo.CreateToken(context.Background(), &Token{
+		UserID: user.ID, Purpose: PurposePasswordReset, TokenHash: auth.HashToken("expired"),
+		Email: user.Email, ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, service.ForgotPassword(context.Background(), ForgotPasswordInput{Email: "ada@example.com"}))
+	replaced := mailer.resetToken
+	require.NoError(t, service.ForgotPassword(context.Background(), ForgotPasswordInput{Email: "ada@example.com"}))
+
+	// Execute & Assert
+	for _, token := range []string{"expired", replaced, mailer.verifyToken} {
+		err := service.ResetPassword(context.Background(), ResetPasswordInput{Token: token, Password: "battery staple"})
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	}
+	assert.NoError(t, service.ResetPassword(context.Background(), ResetPasswordInput{Token: mailer.resetToken, Password: "battery staple"}))
+}
+
+func TestConfirmDeletionToken(t *testing.T) {
+	// Setup
+	service, mailer, _ := newMailingTestService()
+	ada, err := service.Register(context.Background(), RegisterInput{Name: "Ada", Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	grace, err := service.Register(context.Background(), RegisterInput{Name: "Grace", Email: "grace@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	require.NoError(t, service.SendDeletionConfirmation(context.Background(), ada.ID))
+	replaced := mailer.deleteToken
+	require.NoError(t, service.SendDeletionConfirmation(context.Background(), ada.ID))
+
+	// Execute & Assert
+	for _, token := range []string{"", replaced, mailer.verifyToken} {
+		assert.ErrorIs(t, service.ConfirmDeletionToken(context.Background(), ada.ID, token), ErrInvalidToken)
+	}
+	assert.ErrorIs(t, service.ConfirmDeletionToken(context.Background(), grace.ID, mailer.deleteToken), ErrInvalidToken)
+
+	require.NoError(t, service.SendDeletionConfirmation(context.Background(), ada.ID))
+	assert.NoError(t, service.ConfirmDeletionToken(context.Background(), ada.ID, mailer.deleteToken))
+	assert.ErrorIs(t, service.ConfirmDeletionToken(context.Background(), ada.ID, mailer.deleteToken), ErrInvalidToken, "tokens are single use")
+}
+
+func TestVerifyEmailHandler_RegisterAndEmailChange(t *testing.T) {
+	// Setup
+	service, mailer, _ := newMailingTestService()
+	login := registerAndLogin(t, service)
+	handler := NewGinHandler(service)
+	require.Equal(t, 1, mailer.verifications)
+	assert.Nil(t, login.User.EmailVerifiedAt)
+
+	registerToken := mailer.verifyToken
+	_, err := service.UpdateProfile(context.Background(), login.User.ID, UpdatesInput{Email: "ada@lovelace.dev"})
+	require.NoError(t, err)
+	require.Equal(t, 2, mailer.verifications)
+	assert.Equal(t, "ada@lovelace.dev", mailer.verifyEmail)
+
+	// Execute
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/users/email/verify?token="+registerToken, nil)
+	handler.VerifyEmailHandler(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/users/email/verify?token="+mailer.verifyToken, nil)
+	handler.VerifyEmailHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	profile, err := service.GetProfile(context.Background(), login.User.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, profile.EmailVerifiedAt)
+
+	w = httptest.NewRecorder()
+	c = jsonRequest(w, "POST", "/users/email/verify/resend", nil)
+	c.Set("userID", login.User.ID.String())
+	handler.ResendVerificationHandler(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestLoginSecondFactorHandler(t *testing.T) {
+	// Setup
+	sessions := session.NewService(session.NewMockRepository())
+	mfaService := mfa.NewService(mfa.NewMockRepository())
+	service := NewService(NewMockRepository(), sessions, mfaService, newGuard(), &recordingMailer{}, testKeys, nil)
+	user := registerAndLogin(t, service).User
+	handler := NewGinHandler(service)
+
+	enrollment, err := mfaService.Enroll(context.Background(), user.ID, user.Email)
+	require.NoError(t, err)
+	confirmCode, err := auth.TOTPCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	recoveryCodes, err := mfaService.Confirm(context.Background(), user.ID, confirmCode)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c := jsonRequest(w, "POST", "/users/login", LoginInput{Email: "ada@example.com", Password: "correct horse"})
+	handler.LoginHandler(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var challenge map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+	assert.Equal(t, true, challenge["mfa_required"])
+	assert.NotContains(t, challenge, "token")
+	assert.NotContains(t, challenge, "refresh_token")
+	challengeToken := challenge["challenge_token"].(string)
+
+	nextCode, err := auth.TOTPCode(enrollment.Secret, time.Now().Add(auth.TOTPPeriod))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		challenge string
+		code      string
+		status    int
+	}{
+		{"replayed code", challengeToken, confirmCode, http.StatusUnauthorized},
+		{"bad challenge", "not-a-token", nextCode, http.StatusUnauthorized},
+		{"authenticator code", challengeToken, nextCode, http.StatusOK},
+		{"recovery code", challengeToken, recoveryCodes[0], http.StatusOK},
+		{"used recovery code", challengeToken, recoveryCodes[0], http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c := jsonRequest(w, "POST", "/users/login/2fa", SecondFactorInput{ChallengeToken: tt.challenge, Code: tt.code})
+
+			// Execute
+			handler.LoginSecondFactorHandler(c)
+
+			// Assert
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				var response struct {
+					Token        string `json:"token"`
+					RefreshToken string `json:"refresh_token"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				claims, err := testKeys.ValidateJWT(response.Token)
+				require.NoError(t, err)
+				assert.Equal(t, user.ID.String(), claims.UserID)
+				assert.NotEmpty(t, response.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestLoginHandler_ThrottlesFailedAttempts(t *testing.T) {
+	// Setup
+	guard := newGuard()
+	service := NewService(NewMockRepository(), session.NewService(session.NewMockRepository()), mfa.NewService(mfa.NewMockRepository()), guard, &recordingMailer{}, testKeys, nil)
+	user := registerAndLogin(t, service).User
+	handler := NewGinHandler(service)
+
+	login := func(password string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c := jsonRequest(w, "POST", "/users/login", LoginInput{Email: "ada@example.com", Password: password})
+		handler.LoginHandler(c)
+		return w
+	}
+
+	// Execute
+	for i := 0; i <= loginguard.EmailPolicy.FreeAttempts; i++ {
+		require.Equal(t, http.StatusUnauthorized, login("wrong password").Code)
+	}
+	w := login("correct horse")
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	events, err := guard.ListEvents(context.Background(), loginguard.EventFilters{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, events, loginguard.EmailPolicy.FreeAttempts+2)
+	assert.Equal(t, loginguard.EventFailure, events[0].Event)
+	assert.Equal(t, loginguard.ReasonWrongPassword, events[0].Reason)
+	assert.Equal(t, loginguard.EventSuccess, events[len(events)-1].Event)
+}
+
+func jsonRequest(w *httptest.ResponseRecorder, method, path string, body any) *gin.Context {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewReader(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c
+}
+
+func newTestService() (Service, session.Service) {
+	service, _, sessions := newMailingTestService()
+	return service, sessions
+}
+
+func newMailingTestService() (Service, *recordingMailer, session.Service) {
+	sessions := session.NewService(session.NewMockRepository())
+	mailer := &recordingMailer{}
+	return NewService(NewMockRepository(), sessions, mfa.NewService(mfa.NewMockRepository()), newGuard(), mailer, testKeys, nil), mailer, sessions
+}
+
+var testKeys = newTestKeys()
+
+func newTestKeys() *auth.KeySet {
+	key, err := auth.GenerateSigningKey()
+	if err != nil {
+		panic(err)
+	}
+	keys, err := auth.NewKeySet(auth.DefaultIssuer, key)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+func newGuard() loginguard.Service {
+	return loginguard.NewService(loginguard.NewMockRepository(), loginguard.EmailPolicy, loginguard.IPPolicy)
+}
+
+func TestLogin_CancelsPendingDeletion(t *testing.T) {
+	// Setup
+	deletions := &recordingDeletions{fail: true}
+	service := NewService(NewMockRepository(), session.NewService(session.NewMockRepository()), mfa.NewService(mfa.NewMockRepository()), newGuard(), &recordingMailer{}, testKeys, deletions)
+	_, err := service.Register(context.Background(), RegisterInput{Name: "Ada", Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	// Execute
+	_, failedErr := service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "correct horse"})
+	deletions.fail = false
+	login, err := service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "correct horse"})
+
+	// Assert
+	assert.Error(t, failedErr, "the login must fail while the deletion stays scheduled")
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{login.User.ID, login.User.ID}, deletions.cancelled)
+}
+
+// recordingDeletions records the users whose deletion was cancelled
+type recordingDeletions struct {
+	cancelled []uuid.UUID
+	fail      bool
+}
+
+func (d *recordingDeletions) CancelPendingDeletion(ctx context.Context, userID uuid.UUID) error {
+	d.cancelled = append(d.cancelled, userID)
+	if d.fail {
+		return errors.New("connection reset")
+	}
+	return nil
+}
+
+// recordingMailer keeps the last token mailed for each purpose
+type recordingMailer struct {
+	resets        int
+	verifications int
+	resetToken    string
+	verifyToken   string
+	verifyEmail   string
+	deleteToken   string
+}
+
+func (r *recordingMailer) SendPasswordReset(ctx context.Context, to notification.Recipient, token string, expiresAt time.Time) error {
+	r.resets++
+	r.resetToken = token
+	return nil
+}
+
+func (r *recordingMailer) SendEmailVerification(ctx context.Context, to notification.Recipient, token string, expiresAt time.Time) error {
+	r.verifications++
+	r.verifyToken = token
+	r.verifyEmail = to.Email
+	return nil
+}
+
+func (r *recordingMailer) SendDeletionConfirmation(ctx context.Context, to notification.Recipient, token string, expiresAt time.Time) error {
+	r.deleteToken = token
+	return nil
+}
+
+func registerAndLogin(t *testing.T, service Service) *LoginResponse {
+	t.Helper()
+
+	_, err := service.Register(context.Background(), RegisterInput{Name: "Ada", Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	login, err := service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	return login
+}
+
 // Mock service for testing
 type mockService struct {
 	mockRegister      func(context.Context, RegisterInput) (*User, error)
@@ -172,3 +746,63 @@ func (m *mockService) UpdateProfile(ctx context.Context, userID uuid.UUID, updat
 	}
 	return nil, nil
 }
+
+func (m *mockService) LoginSecondFactor(ctx context.Context, input SecondFactorInput) (*LoginResponse, error) {
+	return nil, nil
+}
+
+func (m *mockService) Refresh(ctx context.Context, input RefreshInput) (*LoginResponse, error) {
+	return nil, nil
+}
+
+func (m *mockService) Logout(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockService) ListUsers(ctx context.Context, page, limit int) ([]*User, error) {
+	return nil, nil
+}
+
+func (m *mockService) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) (*User, error) {
+	return nil, nil
+}
+
+func (m *mockService) ForgotPassword(ctx context.Context, input ForgotPasswordInput) error {
+	return nil
+}
+
+func (m *mockService) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	return nil
+}
+
+func (m *mockService) VerifyEmail(ctx context.Context, token string) (*User, error) {
+	return nil, nil
+}
+
+func (m *mockService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockService) ConfirmPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	return nil
+}
+
+func (m *mockService) SendDeletionConfirmation(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockService) ConfirmDeletionToken(ctx context.Context, userID uuid.UUID, token string) error {
+	return nil
+}
+
+func (m *mockService) Delete(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockService) LoginWithIdentity(ctx context.Context, identity *oidc.Identity, client session.Client) (*LoginResponse, error) {
+	return nil, nil
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luis-octavius/cintia/internal/oidc"
+)
+
+// stateCookie keeps the state of a login in the browser that started it,
+// so a callback URL made for someone else's login is refused
+const stateCookie = "cintia_oidc_state"
+
+const oidcCookiePath = "/api/users/oidc"
+
+// OIDCHandler serves sign in with an OpenID Connect provider
+type OIDCHandler struct {
+	service Service
+	flow    oidc.Service
+}
+
+func NewOIDCHandler(service Service, flow oidc.Service) *OIDCHandler {
+	return &OIDCHandler{service: service, flow: flow}
+}
+
+// GET /api/users/oidc/login - send the user to the identity provider
+func (h *OIDCHandler) StartHandler(c *gin.Context) {
+	authorization, err := h.flow.Begin(c.Request.Context())
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, oidc.ErrProvider) {
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookie, authorization.State, int(oidc.StateTTL.Seconds()), oidcCookiePath, "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authorization.URL)
+}
+
+// GET /api/users/oidc/callback - finish the login, answers like POST /api/users/login
+func (h *OIDCHandler) CallbackHandler(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "the identity provider refused the login",
+			"details": providerErr + " " + c.Query("error_description"),
+		})
+		return
+	}
+
+	state := c.Query("state")
+	cookie, err := c.Cookie(stateCookie)
+	if err != nil || state == "" || cookie != state {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": oidc.ErrInvalidState.Error(),
+		})
+		return
+	}
+	c.SetCookie(stateCookie, "", -1, oidcCookiePath, "", c.Request.TLS != nil, true)
+
+	identity, err := h.flow.Finish(c.Request.Context(), state, c.Query("code"))
+	if err != nil {
+		c.JSON(oidcErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.service.LoginWithIdentity(c.Request.Context(), identity, clientFromRequest(c))
+	if err != nil {
+		c.JSON(oidcErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, loginResponseBody(response))
+}
+
+func oidcErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, oidc.ErrInvalidState):
+		return http.StatusBadRequest
+	case errors.Is(err, oidc.ErrInvalidIDToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrUnverifiedEmail):
+		return http.StatusForbidden
+	case errors.Is(err, oidc.ErrProvider):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
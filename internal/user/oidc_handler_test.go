@@ -0,0 +1,172 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/oidc"
+	"github.com/luis-octavius/cintia/internal/oidc/oidctest"
+	"github.com/luis-octavius/cintia/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCLogin_CreatesAndLinksUser(t *testing.T) {
+	// Setup
+	server, router, service, _ := newOIDCTestRouter(t)
+	server.SetUser(oidctest.User{Subject: "42", Email: "grace@example.com", EmailVerified: true, Name: "Grace Hopper"})
+
+	// Execute
+	w := oidcLogin(t, router)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Token string `json:"token"`
+		User  struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"user"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	claims, err := testKeys.ValidateJWT(response.Token)
+	require.NoError(t, err)
+	assert.Equal(t, response.User.ID, claims.UserID)
+	assert.Equal(t, "Grace Hopper", response.User.Name)
+
+	profile, err := service.GetProfile(context.Background(), uuid.MustParse(claims.UserID))
+	require.NoError(t, err)
+	assert.NotNil(t, profile.EmailVerifiedAt)
+
+	// the link holds even when the email changes at the provider
+	server.SetUser(oidctest.User{Subject: "42", Email: "grace@navy.example.com", EmailVerified: false})
+	w = oidcLogin(t, router)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, claims.UserID, response.User.ID)
+}
+
+func TestOIDCLogin_ClaimsUnverifiedAccount(t *testing.T) {
+	// Setup
+	server, router, service, sessions := newOIDCTestRouter(t)
+	login := registerAndLogin(t, service)
+	server.SetUser(oidctest.User{Subject: "ada", Email: "ada@example.com", EmailVerified: true})
+
+	// Execute
+	w := oidcLogin(t, router)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, login.User.ID.String(), response.User.ID)
+
+	_, err := service.Login(context.Background(), LoginInput{Email: "ada@example.com", Password: "correct horse"})
+	assert.Error(t, err, "the password set before the email was verified no longer works")
+	claims, err := testKeys.ValidateJWT(login.Token)
+	require.NoError(t, err)
+	active, err := sessions.IsActive(context.Background(), uuid.MustParse(claims.SessionID))
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestOIDCLogin_Refusals(t *testing.T) {
+	server, router, _, _ := newOIDCTestRouter(t)
+
+	t.Run("unverified email", func(t *testing.T) {
+		server.SetUser(oidctest.User{Subject: "7", Email: "mallory@example.com", EmailVerified: false})
+
+		w := oidcLogin(t, router)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("callback from another browser", func(t *testing.T) {
+		server.SetUser(oidctest.User{Subject: "8", Email: "bob@example.com", EmailVerified: true})
+		callback, _ := startOIDCLogin(t, router)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", callback, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/oidc/callback?error=access_denied", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func newOIDCTestRouter(t *testing.T) (*oidctest.Server, *gin.Engine, Service, session.Service) {
+	t.Helper()
+
+	server, err := oidctest.NewServer("cintia", "cintia-secret")
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:       server.Issuer,
+		ClientID:     "cintia",
+		ClientSecret: "cintia-secret",
+		RedirectURL:  "http://localhost/api/users/oidc/callback",
+	}, nil)
+	service, _, sessions := newMailingTestService()
+	handler := NewOIDCHandler(service, oidc.NewService(oidc.NewMockRepository(), provider))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/users/oidc/login", handler.StartHandler)
+	router.GET("/api/users/oidc/callback", handler.CallbackHandler)
+	return server, router, service, sessions
+}
+
+// startOIDCLogin starts a login and plays the browser at the provider. It
+// returns the callback path and the state cookie
+func startOIDCLogin(t *testing.T, router *gin.Engine) (string, *http.Cookie) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/oidc/login", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(w.Header().Get("Location"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return callback.RequestURI(), cookies[0]
+}
+
+func oidcLogin(t *testing.T, router *gin.Engine) *httptest.ResponseRecorder {
+	t.Helper()
+
+	callback, cookie := startOIDCLogin(t, router)
+	req := httptest.NewRequest("GET", callback, nil)
+	req.AddCookie(cookie)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
@@ -2,14 +2,40 @@ package user
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidUser   = errors.New("user does not exist")
+	ErrTokenNotFound = errors.New("token not found")
+)
+
 type Repository interface {
 	Create(ctx context.Context, user *User) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// List returns users from the oldest account on
+	List(ctx context.Context, limit, offset int) ([]*User, error)
+	// UpdateRole returns ErrInvalidUser when the user does not exist
+	UpdateRole(ctx context.Context, id uuid.UUID, role string) error
+	// MarkEmailVerified returns ErrInvalidUser when the user does not exist
+	// or their email is no longer the given one
+	MarkEmailVerified(ctx context.Context, id uuid.UUID, email string) error
+
+	// FindByIdentity returns the user an external account is linked to,
+	// nil when it is not linked
+	FindByIdentity(ctx context.Context, issuer, subject string) (*User, error)
+	// LinkIdentity links an external account to a user. An account that is
+	// already linked stays with its user
+	LinkIdentity(ctx context.Context, userID uuid.UUID, issuer, subject, email string) error
+
+	CreateToken(ctx context.Context, token *Token) (*Token, error)
+	// ConsumeToken marks a token as used and returns it. Tokens with another
+	// purpose, already used or expired give ErrTokenNotFound
+	ConsumeToken(ctx context.Context, tokenHash string, purpose TokenPurpose) (*Token, error)
+	DeleteTokens(ctx context.Context, userID uuid.UUID, purpose TokenPurpose) error
 }
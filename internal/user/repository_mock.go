@@ -2,25 +2,27 @@ package user
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-var (
-	ErrInvalidUser = errors.New("user does not exist")
-)
-
 type mockRepository struct {
-	mu    sync.RWMutex
-	users map[string]*User
+	mu     sync.RWMutex
+	users  map[string]*User
+	tokens map[string]*Token
+	// identities maps issuer and subject to a user id
+	identities map[[2]string]uuid.UUID
 }
 
 func NewMockRepository() *mockRepository {
 	return &mockRepository{
-		users: make(map[string]*User),
+		users:      make(map[string]*User),
+		tokens:     make(map[string]*Token),
+		identities: make(map[[2]string]uuid.UUID),
 	}
 }
 
@@ -65,13 +67,20 @@ func (m *mockRepository) Update(ctx context.Context, user *User) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	_, exists := m.users[user.Email]
-	if !exists {
-		return ErrInvalidUser
+	// users are keyed by email, which may be the field being changed
+	for email, existing := range m.users {
+		if existing.ID != user.ID {
+			continue
+		}
+		if email != user.Email {
+			delete(m.users, email)
+			user.EmailVerifiedAt = nil
+		}
+		m.users[user.Email] = user
+		return nil
 	}
 
-	m.users[user.Email] = user
-	return nil
+	return ErrInvalidUser
 }
 
 func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -87,3 +96,123 @@ func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return ErrInvalidUser
 }
+
+func (m *mockRepository) List(ctx context.Context, limit, offset int) ([]*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make([]*User, 0, len(m.users))
+	for _, user := range m.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+
+	if offset >= len(users) {
+		return []*User{}, nil
+	}
+	users = users[offset:]
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (m *mockRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.ID == id {
+			user.Role = role
+			user.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	return ErrInvalidUser
+}
+
+func (m *mockRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, exists := m.users[email]
+	if !exists || user.ID != id {
+		return ErrInvalidUser
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	return nil
+}
+
+func (m *mockRepository) FindByIdentity(ctx context.Context, issuer, subject string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	userID, exists := m.identities[[2]string{issuer, subject}]
+	if !exists {
+		return nil, nil
+	}
+	for _, user := range m.users {
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) LinkIdentity(ctx context.Context, userID uuid.UUID, issuer, subject, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := [2]string{issuer, subject}
+	if _, exists := m.identities[key]; !exists {
+		m.identities[key] = userID
+	}
+	return nil
+}
+
+func (m *mockRepository) CreateToken(ctx context.Context, token *Token) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	token.CreatedAt = time.Now()
+
+	stored := *token
+	m.tokens[token.TokenHash] = &stored
+	return token, nil
+}
+
+func (m *mockRepository) ConsumeToken(ctx context.Context, tokenHash string, purpose TokenPurpose) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, exists := m.tokens[tokenHash]
+	now := time.Now()
+	if !exists || token.Purpose != purpose || token.UsedAt != nil || !token.ExpiresAt.After(now) {
+		return nil, ErrTokenNotFound
+	}
+
+	token.UsedAt = &now
+	consumed := *token
+	return &consumed, nil
+}
+
+func (m *mockRepository) DeleteTokens(ctx context.Context, userID uuid.UUID, purpose TokenPurpose) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, token := range m.tokens {
+		if token.UserID == userID && token.Purpose == purpose {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
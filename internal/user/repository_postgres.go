@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/luis-octavius/cintia/internal/database"
@@ -31,13 +32,16 @@ func (r *PostgresRepository) Create(ctx context.Context, user *User) (*User, err
 	}
 
 	return &User{
-		ID:           dbUser.ID,
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Role:         dbUser.Role,
-		CreatedAt:    dbUser.CreatedAt,
-		UpdatedAt:    dbUser.UpdatedAt,
+		ID:                 dbUser.ID,
+		Name:               dbUser.Name,
+		Email:              dbUser.Email,
+		PasswordHash:       dbUser.PasswordHash,
+		Role:               dbUser.Role,
+		Locale:             dbUser.Locale,
+		MutedNotifications: dbUser.MutedNotifications,
+		EmailVerifiedAt:    fromNullTime(dbUser.EmailVerifiedAt),
+		CreatedAt:          dbUser.CreatedAt,
+		UpdatedAt:          dbUser.UpdatedAt,
 	}, nil
 }
 
@@ -51,13 +55,16 @@ func (r *PostgresRepository) FindByEmail(ctx context.Context, email string) (*Us
 	}
 
 	return &User{
-		ID:           dbUser.ID,
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Role:         dbUser.Role,
-		CreatedAt:    dbUser.CreatedAt,
-		UpdatedAt:    dbUser.UpdatedAt,
+		ID:                 dbUser.ID,
+		Name:               dbUser.Name,
+		Email:              dbUser.Email,
+		PasswordHash:       dbUser.PasswordHash,
+		Role:               dbUser.Role,
+		Locale:             dbUser.Locale,
+		MutedNotifications: dbUser.MutedNotifications,
+		EmailVerifiedAt:    fromNullTime(dbUser.EmailVerifiedAt),
+		CreatedAt:          dbUser.CreatedAt,
+		UpdatedAt:          dbUser.UpdatedAt,
 	}, nil
 }
 
@@ -71,13 +78,16 @@ func (r *PostgresRepository) FindByID(ctx context.Context, id uuid.UUID) (*User,
 	}
 
 	return &User{
-		ID:           dbUser.ID,
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Role:         dbUser.Role,
-		CreatedAt:    dbUser.CreatedAt,
-		UpdatedAt:    dbUser.UpdatedAt,
+		ID:                 dbUser.ID,
+		Name:               dbUser.Name,
+		Email:              dbUser.Email,
+		PasswordHash:       dbUser.PasswordHash,
+		Role:               dbUser.Role,
+		Locale:             dbUser.Locale,
+		MutedNotifications: dbUser.MutedNotifications,
+		EmailVerifiedAt:    fromNullTime(dbUser.EmailVerifiedAt),
+		CreatedAt:          dbUser.CreatedAt,
+		UpdatedAt:          dbUser.UpdatedAt,
 	}, nil
 }
 
@@ -96,6 +106,11 @@ func (r *PostgresRepository) Update(ctx context.Context, user *User) error {
 	if user.PasswordHash != "" {
 		params.PasswordHash = sql.NullString{String: user.PasswordHash, Valid: true}
 	}
+	if user.Locale != "" {
+		params.Locale = sql.NullString{String: user.Locale, Valid: true}
+	}
+	// nil keeps the stored list, an empty slice clears it
+	params.MutedNotifications = user.MutedNotifications
 
 	dbUser, err := r.queries.UpdateUser(ctx, params)
 	if err != nil {
@@ -106,6 +121,9 @@ func (r *PostgresRepository) Update(ctx context.Context, user *User) error {
 	user.Name = dbUser.Name
 	user.Email = dbUser.Email
 	user.PasswordHash = dbUser.PasswordHash
+	user.Locale = dbUser.Locale
+	user.MutedNotifications = dbUser.MutedNotifications
+	user.EmailVerifiedAt = fromNullTime(dbUser.EmailVerifiedAt)
 	user.UpdatedAt = dbUser.UpdatedAt
 
 	return nil
@@ -114,3 +132,151 @@ func (r *PostgresRepository) Update(ctx context.Context, user *User) error {
 func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.queries.DeleteUser(ctx, id)
 }
+
+func (r *PostgresRepository) List(ctx context.Context, limit, offset int) ([]*User, error) {
+	dbUsers, err := r.queries.ListUsers(ctx, database.ListUsersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*User, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		users = append(users, &User{
+			ID:                 dbUser.ID,
+			Name:               dbUser.Name,
+			Email:              dbUser.Email,
+			PasswordHash:       dbUser.PasswordHash,
+			Role:               dbUser.Role,
+			Locale:             dbUser.Locale,
+			MutedNotifications: dbUser.MutedNotifications,
+			EmailVerifiedAt:    fromNullTime(dbUser.EmailVerifiedAt),
+			CreatedAt:          dbUser.CreatedAt,
+			UpdatedAt:          dbUser.UpdatedAt,
+		})
+	}
+
+	return users, nil
+}
+
+func (r *PostgresRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
+	rows, err := r.queries.UpdateUserRole(ctx, database.UpdateUserRoleParams{
+		ID:   id,
+		Role: role,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvalidUser
+	}
+	return nil
+}
+
+func (r *PostgresRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email string) error {
+	rows, err := r.queries.MarkEmailVerified(ctx, database.MarkEmailVerifiedParams{
+		ID:    id,
+		Email: email,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvalidUser
+	}
+	return nil
+}
+
+func (r *PostgresRepository) FindByIdentity(ctx context.Context, issuer, subject string) (*User, error) {
+	dbUser, err := r.queries.GetUserByIdentity(ctx, database.GetUserByIdentityParams{
+		Issuer:  issuer,
+		Subject: subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &User{
+		ID:                 dbUser.ID,
+		Name:               dbUser.Name,
+		Email:              dbUser.Email,
+		PasswordHash:       dbUser.PasswordHash,
+		Role:               dbUser.Role,
+		Locale:             dbUser.Locale,
+		MutedNotifications: dbUser.MutedNotifications,
+		EmailVerifiedAt:    fromNullTime(dbUser.EmailVerifiedAt),
+		CreatedAt:          dbUser.CreatedAt,
+		UpdatedAt:          dbUser.UpdatedAt,
+	}, nil
+}
+
+func (r *PostgresRepository) LinkIdentity(ctx context.Context, userID uuid.UUID, issuer, subject, email string) error {
+	return r.queries.CreateUserIdentity(ctx, database.CreateUserIdentityParams{
+		UserID:  userID,
+		Issuer:  issuer,
+		Subject: subject,
+		Email:   email,
+	})
+}
+
+func (r *PostgresRepository) CreateToken(ctx context.Context, token *Token) (*Token, error) {
+	dbToken, err := r.queries.CreateUserToken(ctx, database.CreateUserTokenParams{
+		UserID:    token.UserID,
+		Purpose:   string(token.Purpose),
+		TokenHash: token.TokenHash,
+		Email:     token.Email,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbTokenToToken(&dbToken), nil
+}
+
+func (r *PostgresRepository) ConsumeToken(ctx context.Context, tokenHash string, purpose TokenPurpose) (*Token, error) {
+	dbToken, err := r.queries.ConsumeUserToken(ctx, database.ConsumeUserTokenParams{
+		TokenHash: tokenHash,
+		Purpose:   string(purpose),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, err
+	}
+
+	return dbTokenToToken(&dbToken), nil
+}
+
+func (r *PostgresRepository) DeleteTokens(ctx context.Context, userID uuid.UUID, purpose TokenPurpose) error {
+	return r.queries.DeleteUserTokens(ctx, database.DeleteUserTokensParams{
+		UserID:  userID,
+		Purpose: string(purpose),
+	})
+}
+
+func dbTokenToToken(dbToken *database.UserToken) *Token {
+	return &Token{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		Purpose:   TokenPurpose(dbToken.Purpose),
+		TokenHash: dbToken.TokenHash,
+		Email:     dbToken.Email,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    fromNullTime(dbToken.UsedAt),
+		CreatedAt: dbToken.CreatedAt,
+	}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
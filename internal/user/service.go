@@ -11,40 +11,124 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/luis-octavius/cintia/internal/auth"
+	"github.com/luis-octavius/cintia/internal/loginguard"
+	"github.com/luis-octavius/cintia/internal/mfa"
+	"github.com/luis-octavius/cintia/internal/notification"
+	"github.com/luis-octavius/cintia/internal/oidc"
+	"github.com/luis-octavius/cintia/internal/session"
 )
 
 var (
-	ErrEmailExists     = errors.New("email already exists")
-	ErrInvalidName     = errors.New("name must not be empty")
-	ErrInvalidEmail    = errors.New("invalid email")
-	ErrWeakPassword    = errors.New("password must be at least 8 characters")
-	ErrInvalidPassword = errors.New("password invalid")
+	ErrEmailExists      = errors.New("email already exists")
+	ErrInvalidName      = errors.New("name must not be empty")
+	ErrInvalidEmail     = errors.New("invalid email")
+	ErrWeakPassword     = errors.New("password must be at least 8 characters")
+	ErrInvalidPassword  = errors.New("password invalid")
+	ErrInvalidLocale    = errors.New("unsupported locale")
+	ErrUnknownKind      = errors.New("unknown notification kind")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrInvalidRole      = errors.New("invalid role, use candidate, recruiter or admin")
+	ErrOwnRole          = errors.New("you cannot change your own role")
+	ErrInvalidToken     = errors.New("invalid or expired token")
+	ErrAlreadyVerified  = errors.New("email is already verified")
+	ErrInvalidChallenge = errors.New("invalid or expired challenge token, log in again")
+	ErrUnverifiedEmail  = errors.New("the identity provider did not confirm an email for this account")
 )
 
+const (
+	defaultUsersLimit = 50
+	maxUsersLimit     = 200
+
+	// PasswordResetTTL is short, a reset link grants full account access
+	PasswordResetTTL     = time.Hour
+	EmailVerificationTTL = 48 * time.Hour
+	AccountDeletionTTL   = time.Hour
+)
+
+// AccountMailer delivers the links carrying password reset, email
+// verification and account deletion tokens. notification.AccountMailer
+// implements it
+type AccountMailer interface {
+	SendPasswordReset(ctx context.Context, to notification.Recipient, token string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, to notification.Recipient, token string, expiresAt time.Time) error
+	SendDeletionConfirmation(ctx context.Context, to notification.Recipient, token string, expiresAt time.Time) error
+}
+
+// PendingDeletions cancels the scheduled deletion of an account, it is
+// not an error when none is pending. account.NewLoginRestorer implements it
+type PendingDeletions interface {
+	CancelPendingDeletion(ctx context.Context, userID uuid.UUID) error
+}
+
 var ctx = context.Background()
 
 type Service interface {
 	Register(ctx context.Context, input RegisterInput) (*User, error)
+	// Login checks the password. For accounts with two-factor
+	// authentication it returns a challenge for LoginSecondFactor. Too
+	// many failures return a *loginguard.ThrottledError
 	Login(ctx context.Context, input LoginInput) (*LoginResponse, error)
+	LoginSecondFactor(ctx context.Context, input SecondFactorInput) (*LoginResponse, error)
+	// LoginWithIdentity signs in with an account of an OpenID Connect
+	// provider, linking it to a user by verified email the first time
+	LoginWithIdentity(ctx context.Context, identity *oidc.Identity, client session.Client) (*LoginResponse, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*User, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, updates UpdatesInput) (*User, error)
+	Refresh(ctx context.Context, input RefreshInput) (*LoginResponse, error)
+	Logout(ctx context.Context, userID, sessionID uuid.UUID) error
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	ListUsers(ctx context.Context, page, limit int) ([]*User, error)
+	ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) (*User, error)
+	ForgotPassword(ctx context.Context, input ForgotPasswordInput) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+	VerifyEmail(ctx context.Context, token string) (*User, error)
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
+	// ConfirmPassword checks the password of a signed in user again, before
+	// an action that cannot be undone
+	ConfirmPassword(ctx context.Context, userID uuid.UUID, password string) error
+	// SendDeletionConfirmation mails a token that confirms the deletion of
+	// the account in place of the password, which users signing in with an
+	// identity provider do not know
+	SendDeletionConfirmation(ctx context.Context, userID uuid.UUID) error
+	// ConfirmDeletionToken uses up a token from SendDeletionConfirmation
+	// sent to the user
+	ConfirmDeletionToken(ctx context.Context, userID uuid.UUID, token string) error
+	// Delete removes the account. Everything the user owns goes with it
+	Delete(ctx context.Context, userID uuid.UUID) error
 }
 
 type service struct {
-	repo      Repository
-	jwtSecret string
+	repo     Repository
+	sessions session.Service
+	mfa      mfa.Service
+	guard    loginguard.Service
+	mailer   AccountMailer
+	keys     *auth.KeySet
+	// deletions is optional, without it a scheduled deletion only goes away
+	// when cancelled explicitly
+	deletions PendingDeletions
 	// in the future, it is possible to add logger, metrics, etc. here
 }
 
-func NewService(r Repository, s string) Service {
+func NewService(r Repository, sessions session.Service, mfaService mfa.Service, guard loginguard.Service, mailer AccountMailer, keys *auth.KeySet, deletions PendingDeletions) Service {
+	// hash ahead of the first login for an unknown email
+	go dummyPasswordHash()
+
 	return &service{
 		repo:      r,
-		jwtSecret: s,
+		sessions:  sessions,
+		mfa:       mfaService,
+		guard:     guard,
+		mailer:    mailer,
+		keys:      keys,
+		deletions: deletions,
 	}
 }
 
@@ -76,12 +160,15 @@ func (s *service) Register(ctx context.Context, input RegisterInput) (*User, err
 	}
 
 	user := &User{
-		ID:           uuid.New(),
-		Name:         input.Name,
-		Email:        input.Email,
-		PasswordHash: hash,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:                 uuid.New(),
+		Name:               input.Name,
+		Email:              input.Email,
+		PasswordHash:       hash,
+		Role:               string(auth.RoleCandidate),
+		Locale:             notification.DefaultLocale,
+		MutedNotifications: []string{},
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
 	}
 
 	createdUser, err := s.repo.Create(ctx, user)
@@ -89,6 +176,12 @@ func (s *service) Register(ctx context.Context, input RegisterInput) (*User, err
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	// the account works without a verified email, so a mail failure must
+	// not fail the registration; the user can ask for a new link
+	if err := s.sendVerification(ctx, createdUser); err != nil {
+		log.Printf("failed to send verification email to user %s: %v", createdUser.ID, err)
+	}
+
 	return createdUser, nil
 }
 
@@ -97,12 +190,22 @@ func (s *service) Login(ctx context.Context, input LoginInput) (*LoginResponse,
 		return nil, errors.New("email and password are required")
 	}
 
+	attempt := loginAttempt(input.Email, input.Client)
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.FindByEmail(ctx, input.Email)
 	if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
 	if user == nil {
+		// compare anyway so unknown emails take as long as wrong passwords
+		if hash, err := dummyPasswordHash(); err == nil {
+			auth.CheckPasswordHash(input.Password, hash)
+		}
+		s.loginFailed(ctx, attempt, nil, loginguard.ReasonUnknownEmail)
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -112,18 +215,328 @@ func (s *service) Login(ctx context.Context, input LoginInput) (*LoginResponse,
 	}
 
 	if !match {
+		s.loginFailed(ctx, attempt, &user.ID, loginguard.ReasonWrongPassword)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
-	token, err := auth.MakeJWT(user.ID, user.Email, user.Role, s.jwtSecret)
+	return s.completeLogin(ctx, user, attempt, input.Client)
+}
+
+// LoginSecondFactor finishes a login with the challenge token from Login
+// and a second factor, and only then opens a session. Wrong codes count
+// as failed logins of the account
+func (s *service) LoginSecondFactor(ctx context.Context, input SecondFactorInput) (*LoginResponse, error) {
+	userID, err := s.keys.ValidateChallengeJWT(input.ChallengeToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, ErrInvalidChallenge
 	}
 
-	return &LoginResponse{
-		User:  user,
-		Token: token,
-	}, nil
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	attempt := loginAttempt(user.Email, input.Client)
+	if err := s.guard.Check(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfa.Verify(ctx, userID, input.Code); err != nil {
+		// turned off since the challenge was issued
+		if errors.Is(err, mfa.ErrNotEnabled) {
+			return nil, ErrInvalidChallenge
+		}
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			s.loginFailed(ctx, attempt, &user.ID, loginguard.ReasonWrongCode)
+		}
+		return nil, err
+	}
+
+	return s.startSession(ctx, user, attempt, input.Client)
+}
+
+// LoginWithIdentity signs in the user an external account is linked to.
+// The first time, the account is linked by its verified email to an
+// existing user or to a new one. Throttling is left to the provider
+func (s *service) LoginWithIdentity(ctx context.Context, identity *oidc.Identity, client session.Client) (*LoginResponse, error) {
+	user, err := s.repo.FindByIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if user == nil {
+		user, err = s.linkIdentity(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.completeLogin(ctx, user, loginAttempt(user.Email, client), client)
+}
+
+// completeLogin opens a session once the first factor was accepted, or
+// returns a challenge for LoginSecondFactor when the account has one
+func (s *service) completeLogin(ctx context.Context, user *User, attempt loginguard.Attempt, client session.Client) (*LoginResponse, error) {
+	mfaEnabled, err := s.mfa.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		// the login only succeeds with the second factor
+		challenge, err := s.keys.MakeChallengeJWT(user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate challenge: %w", err)
+		}
+		return &LoginResponse{
+			User:           user,
+			ExpiresAt:      time.Now().Add(auth.ChallengeTokenTTL),
+			MFARequired:    true,
+			ChallengeToken: challenge,
+		}, nil
+	}
+
+	return s.startSession(ctx, user, attempt, client)
+}
+
+// startSession signs the user in once every factor was accepted. Signing
+// in during the grace period of a scheduled deletion restores the account:
+// the deletion is cancelled before the session opens, and the login fails
+// if it cannot be
+func (s *service) startSession(ctx context.Context, user *User, attempt loginguard.Attempt, client session.Client) (*LoginResponse, error) {
+	if s.deletions != nil {
+		if err := s.deletions.CancelPendingDeletion(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to restore account: %w", err)
+		}
+	}
+
+	issued, err := s.sessions.Start(ctx, user.ID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	s.loginSucceeded(ctx, attempt, user.ID)
+	return s.tokens(user, issued)
+}
+
+// linkIdentity finds the user with the verified email of an external
+// account, or creates one, and links the account to them
+func (s *service) linkIdentity(ctx context.Context, identity *oidc.Identity) (*User, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrUnverifiedEmail
+	}
+
+	user, err := s.repo.FindByEmail(ctx, identity.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	switch {
+	case user == nil:
+		user, err = s.createExternalUser(ctx, identity)
+	case user.EmailVerifiedAt == nil:
+		err = s.claimUnverified(ctx, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.LinkIdentity(ctx, user.ID, identity.Issuer, identity.Subject, identity.Email); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+	return user, nil
+}
+
+// createExternalUser creates a user for an external account. Its password
+// is random; the user can set one with a password reset
+func (s *service) createExternalUser(ctx context.Context, identity *oidc.Identity) (*User, error) {
+	hash, err := randomPasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	user, err := s.repo.Create(ctx, &User{
+		ID:                 uuid.New(),
+		Name:               name,
+		Email:              identity.Email,
+		PasswordHash:       hash,
+		Role:               string(auth.RoleCandidate),
+		Locale:             notification.DefaultLocale,
+		MutedNotifications: []string{},
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, user.ID, user.Email); err != nil {
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	return user, nil
+}
+
+// claimUnverified hands an account whose email was never verified to the
+// owner of the address, as proven by the provider. Whoever registered it
+// may not be that owner, so the password is replaced and sessions end,
+// like after a password reset
+func (s *service) claimUnverified(ctx context.Context, user *User) error {
+	hash, err := randomPasswordHash()
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	user.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := s.repo.MarkEmailVerified(ctx, user.ID, user.Email); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+
+	return s.sessions.RevokeAll(ctx, user.ID)
+}
+
+// dummyPasswordHash is what Login checks passwords against when no user has
+// the email. It is hashed once, with the same parameters as real passwords
+var dummyPasswordHash = sync.OnceValues(randomPasswordHash)
+
+func randomPasswordHash() (string, error) {
+	password, err := auth.MakeToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return hash, nil
+}
+
+func loginAttempt(email string, client session.Client) loginguard.Attempt {
+	return loginguard.Attempt{
+		Email:     email,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+	}
+}
+
+// loginFailed and loginSucceeded only log errors, the answer to the login
+// must not depend on the bookkeeping
+func (s *service) loginFailed(ctx context.Context, attempt loginguard.Attempt, userID *uuid.UUID, reason string) {
+	if err := s.guard.Failure(ctx, attempt, userID, reason); err != nil {
+		log.Printf("failed to record failed login: %v", err)
+	}
+}
+
+func (s *service) loginSucceeded(ctx context.Context, attempt loginguard.Attempt, userID uuid.UUID) {
+	if err := s.guard.Success(ctx, attempt, userID); err != nil {
+		log.Printf("failed to record login of user %s: %v", userID, err)
+	}
+}
+
+// Refresh rotates the refresh token and issues a new access token. Email
+// and role are read again so changes show up in the new token
+func (s *service) Refresh(ctx context.Context, input RefreshInput) (*LoginResponse, error) {
+	issued, err := s.sessions.Rotate(ctx, input.RefreshToken, input.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.FindByID(ctx, issued.Session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		return nil, session.ErrInvalidRefreshToken
+	}
+
+	return s.tokens(user, issued)
+}
+
+// Logout revokes the session the access token was issued for
+func (s *service) Logout(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.sessions.Revoke(ctx, userID, sessionID)
+}
+
+// LogoutAll revokes every session of the user
+func (s *service) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	return s.sessions.RevokeAll(ctx, userID)
+}
+
+func (s *service) ConfirmPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	match, err := auth.CheckPasswordHash(password, user.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("CheckPasswordHash error: %w", err)
+	}
+	if !match {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// SendDeletionConfirmation mails a new deletion link, replacing older ones
+func (s *service) SendDeletionConfirmation(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := s.repo.DeleteTokens(ctx, user.ID, PurposeAccountDeletion); err != nil {
+		return fmt.Errorf("failed to delete deletion tokens: %w", err)
+	}
+
+	token, expiresAt, err := s.issueToken(ctx, user, PurposeAccountDeletion, AccountDeletionTTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mailer.SendDeletionConfirmation(ctx, recipient(user), token, expiresAt); err != nil {
+		return fmt.Errorf("failed to send deletion confirmation email: %w", err)
+	}
+	return nil
+}
+
+// ConfirmDeletionToken rejects tokens of other users, so a leaked link is
+// of no use without the session of its owner
+func (s *service) ConfirmDeletionToken(ctx context.Context, userID uuid.UUID, rawToken string) error {
+	token, err := s.consumeToken(ctx, rawToken, PurposeAccountDeletion)
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// Delete relies on the foreign keys to remove what the user owns, their
+// sessions included
+func (s *service) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
 }
 
 func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*User, error) {
@@ -157,6 +570,7 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, updates U
 
 	// updates provided fields
 	updated := false
+	emailChanged := false
 
 	if updates.Name != "" {
 		user.Name = updates.Name
@@ -178,6 +592,8 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, updates U
 				return nil, ErrEmailExists
 			}
 			user.Email = updates.Email
+			user.EmailVerifiedAt = nil
+			emailChanged = true
 			updated = true
 		}
 	}
@@ -196,6 +612,24 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, updates U
 		updated = true
 	}
 
+	if updates.Locale != "" {
+		locale, ok := notification.MatchLocale(updates.Locale)
+		if !ok {
+			return nil, ErrInvalidLocale
+		}
+		user.Locale = locale
+		updated = true
+	}
+
+	if len(updates.Notifications) > 0 {
+		muted, err := applyNotificationPreferences(user.MutedNotifications, updates.Notifications)
+		if err != nil {
+			return nil, err
+		}
+		user.MutedNotifications = muted
+		updated = true
+	}
+
 	if !updated {
 		return nil, errors.New("no fields to update provided")
 	}
@@ -208,5 +642,268 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, updates U
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	// a new password signs out every session, whoever else knew the old
+	// one included
+	if updates.Password != "" {
+		if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if emailChanged {
+		if err := s.sendVerification(ctx, user); err != nil {
+			log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// ForgotPassword mails a password reset link. It succeeds for unknown
+// emails too, so the endpoint cannot be used to find out who has an account
+func (s *service) ForgotPassword(ctx context.Context, input ForgotPasswordInput) error {
+	user, err := s.repo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	// only the latest link works
+	if err := s.repo.DeleteTokens(ctx, user.ID, PurposePasswordReset); err != nil {
+		return fmt.Errorf("failed to delete reset tokens: %w", err)
+	}
+
+	token, expiresAt, err := s.issueToken(ctx, user, PurposePasswordReset, PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	// failing here would tell the caller the account exists
+	if err := s.mailer.SendPasswordReset(ctx, recipient(user), token, expiresAt); err != nil {
+		log.Printf("failed to send password reset email to user %s: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword and
+// signs out every session
+func (s *service) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	if len(input.Password) < 8 {
+		return ErrWeakPassword
+	}
+
+	token, err := s.consumeToken(ctx, input.Token, PurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.FindByID(ctx, token.UserID)
+	if err != nil || user == nil {
+		return ErrInvalidToken
+	}
+
+	hash, err := auth.HashPassword(input.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = hash
+	user.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return s.sessions.RevokeAll(ctx, user.ID)
+}
+
+// VerifyEmail confirms the email a verification token was sent to. A token
+// sent before the user changed their email again is rejected
+func (s *service) VerifyEmail(ctx context.Context, rawToken string) (*User, error) {
+	token, err := s.consumeToken(ctx, rawToken, PurposeEmailVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, token.UserID, token.Email); err != nil {
+		if errors.Is(err, ErrInvalidUser) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	user, err := s.repo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
 	return user, nil
 }
+
+// ResendVerification mails a new verification link, replacing older ones
+func (s *service) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrAlreadyVerified
+	}
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+func (s *service) sendVerification(ctx context.Context, user *User) error {
+	if err := s.repo.DeleteTokens(ctx, user.ID, PurposeEmailVerification); err != nil {
+		return fmt.Errorf("failed to delete verification tokens: %w", err)
+	}
+
+	token, expiresAt, err := s.issueToken(ctx, user, PurposeEmailVerification, EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.SendEmailVerification(ctx, recipient(user), token, expiresAt)
+}
+
+// issueToken stores the hash of a new token for the user's current email
+// and returns the token itself, which is only ever mailed
+func (s *service) issueToken(ctx context.Context, user *User, purpose TokenPurpose, ttl time.Duration) (string, time.Time, error) {
+	token, err := auth.MakeToken()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	_, err = s.repo.CreateToken(ctx, &Token{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: auth.HashToken(token),
+		Email:     user.Email,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
+
+func (s *service) consumeToken(ctx context.Context, rawToken string, purpose TokenPurpose) (*Token, error) {
+	if rawToken == "" {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := s.repo.ConsumeToken(ctx, auth.HashToken(rawToken), purpose)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to consume token: %w", err)
+	}
+	return token, nil
+}
+
+func recipient(user *User) notification.Recipient {
+	return notification.Recipient{
+		Email:  user.Email,
+		Name:   user.Name,
+		Locale: user.Locale,
+	}
+}
+
+// ListUsers pages through all accounts. Zero page or limit fall back to the
+// defaults, larger limits are capped
+func (s *service) ListUsers(ctx context.Context, page, limit int) ([]*User, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultUsersLimit
+	}
+	if limit > maxUsersLimit {
+		limit = maxUsersLimit
+	}
+
+	users, err := s.repo.List(ctx, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// ChangeRole sets the role of another user and signs them out, so tokens
+// carrying the old role stop working right away
+func (s *service) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) (*User, error) {
+	if !auth.Role(role).IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	// keeps the last admin from locking everyone out by demoting themselves
+	if actorID == userID {
+		return nil, ErrOwnRole
+	}
+
+	if err := s.repo.UpdateRole(ctx, userID, role); err != nil {
+		if errors.Is(err, ErrInvalidUser) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	if err := s.sessions.RevokeAll(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *service) tokens(user *User, issued *session.Issued) (*LoginResponse, error) {
+	token, err := s.keys.MakeJWT(user.ID, issued.Session.FamilyID, user.Email, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &LoginResponse{
+		User:         user,
+		Token:        token,
+		ExpiresAt:    time.Now().Add(auth.AccessTokenTTL),
+		RefreshToken: issued.RefreshToken,
+	}, nil
+}
+
+// applyNotificationPreferences returns the muted kinds after turning the
+// given kinds on or off, in the order of notification.Kinds
+func applyNotificationPreferences(muted []string, prefs map[string]bool) ([]string, error) {
+	for kind := range prefs {
+		if !notification.Kind(kind).IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+		}
+	}
+
+	result := []string{}
+	for _, kind := range notification.Kinds {
+		enabled, changed := prefs[string(kind)]
+		if !changed {
+			enabled = !notification.IsMuted(muted, kind)
+		}
+		if !enabled {
+			result = append(result, string(kind))
+		}
+	}
+
+	return result, nil
+}
@@ -4,16 +4,43 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/notification"
+	"github.com/luis-octavius/cintia/internal/session"
 )
 
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Name         string    `json:"name"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"` // does not include in json
-	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	Email              string     `json:"email"`
+	PasswordHash       string     `json:"-"` // does not include in json
+	Role               string     `json:"role"`
+	Locale             string     `json:"locale"`
+	MutedNotifications []string   `json:"muted_notifications"` // kinds the user opted out of
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`   // nil until the current email is confirmed
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// TokenPurpose is what a mailed token can be used for
+type TokenPurpose string
+
+const (
+	PurposePasswordReset     TokenPurpose = "password_reset"
+	PurposeEmailVerification TokenPurpose = "email_verification"
+	PurposeAccountDeletion   TokenPurpose = "account_deletion"
+)
+
+// Token is a single use token mailed to a user. Only its hash is stored
+type Token struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   TokenPurpose
+	TokenHash string
+	// Email is the address the token was sent to
+	Email     string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
 }
 
 type RegisterInput struct {
@@ -25,15 +52,59 @@ type RegisterInput struct {
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Client is filled in from the request, not the body
+	Client session.Client `json:"-"`
+}
+
+type RefreshInput struct {
+	RefreshToken string         `json:"refresh_token" binding:"required"`
+	Client       session.Client `json:"-"`
 }
 
+// LoginResponse holds a short-lived access token and the refresh token
+// that renews it. When the account has two-factor authentication it holds
+// only a challenge token instead, to be sent back with the code
 type LoginResponse struct {
-	User  *User  `json:"user"`
-	Token string `json:"token"`
+	User           *User     `json:"user"`
+	Token          string    `json:"token,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	RefreshToken   string    `json:"refresh_token,omitempty"`
+	MFARequired    bool      `json:"mfa_required"`
+	ChallengeToken string    `json:"challenge_token,omitempty"`
+}
+
+// SecondFactorInput finishes a login that returned a challenge. Code is an
+// authenticator code or a recovery code
+type SecondFactorInput struct {
+	ChallengeToken string         `json:"challenge_token" binding:"required"`
+	Code           string         `json:"code" binding:"required"`
+	Client         session.Client `json:"-"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
 
 type UpdatesInput struct {
 	Name     string `json:"name,omitempty"`
 	Email    string `json:"email,omitempty"`
 	Password string `json:"password,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+	// Notifications turns notification kinds on (true) or off (false).
+	// Kinds left out keep their current setting
+	Notifications map[string]bool `json:"notifications,omitempty"`
+}
+
+// NotificationPreferences returns whether each notification kind is enabled
+func (u *User) NotificationPreferences() map[string]bool {
+	prefs := make(map[string]bool, len(notification.Kinds))
+	for _, kind := range notification.Kinds {
+		prefs[string(kind)] = !notification.IsMuted(u.MutedNotifications, kind)
+	}
+	return prefs
 }
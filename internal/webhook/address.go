@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// blockedPrefixes are ranges not covered by the netip predicates that are
+// still not reachable from the internet
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, broadcast included
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, maps onto IPv4
+}
+
+// isPublicAddr reports whether an endpoint may be reached at addr. Loopback,
+// private, link-local (cloud metadata services live there), unspecified and
+// multicast addresses are refused
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupFunc resolves a host name, net.DefaultResolver.LookupIPAddr in
+// production
+type lookupFunc func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+// publicHost returns a check refusing hosts that resolve to any address
+// that is not public. It runs when endpoints are saved, the dialer of
+// NewClient checks again at delivery time in case the name changed since
+func publicHost(lookup lookupFunc) func(ctx context.Context, host string) error {
+	return func(ctx context.Context, host string) error {
+		addrs, err := lookup(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			return ErrInvalidURL
+		}
+		for _, a := range addrs {
+			addr, ok := netip.AddrFromSlice(a.IP)
+			if !ok || !isPublicAddr(addr) {
+				return ErrPrivateAddress
+			}
+		}
+		return nil
+	}
+}
+
+// dialControl refuses connections to non-public addresses. It sees the
+// address actually dialed, after DNS resolution, so a name rebound to an
+// internal address between validation and delivery is still caught
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !isPublicAddr(addrPort.Addr()) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// NewClient returns the client deliveries are sent with. It only dials
+// public addresses, ignores proxy settings (the proxy would be the address
+// checked) and does not follow redirects
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: dialControl,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        20,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: noRedirects,
+	}
+}
+
+// noRedirects hands the redirect response back as the delivery result,
+// following it could lead anywhere
+func noRedirects(req *http.Request, via []*http.Request) error {
+	return http.ErrUseLastResponse
+}
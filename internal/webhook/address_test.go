@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::1", false},
+		{"::", false},
+		{"fc00::1", false},
+		{"fe80::1", false},
+		{"ff02::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, isPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestCreateEndpoint_RejectsInternalHosts(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		err  error
+	}{
+		{"loopback", "http://127.0.0.1:5432", ErrPrivateAddress},
+		{"private", "http://10.0.0.8/hook", ErrPrivateAddress},
+		{"metadata service", "http://169.254.169.254/latest/meta-data", ErrPrivateAddress},
+		{"ipv6 loopback", "http://[::1]:8080/", ErrPrivateAddress},
+		{"name resolving to private", "https://internal.example.com/hook", ErrPrivateAddress},
+		{"name with one private address", "https://mixed.example.com/hook", ErrPrivateAddress},
+		{"unresolvable", "https://missing.example.com/hook", ErrInvalidURL},
+		{"no scheme", "//hooks.example.com", ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			service := NewService(NewMockRepository()).(*service)
+			service.checkHost = publicHost(fakeLookup)
+
+			// Execute
+			_, err := service.CreateEndpoint(context.Background(), uuid.New(), CreateEndpointInput{URL: tt.url, EventTypes: []string{"application.created"}})
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCreateEndpoint_AcceptsPublicHost(t *testing.T) {
+	// Setup
+	service := NewService(NewMockRepository()).(*service)
+	service.checkHost = publicHost(fakeLookup)
+
+	// Execute
+	endpoint, err := service.CreateEndpoint(context.Background(), uuid.New(), CreateEndpointInput{URL: "https://hooks.example.com/cintia", EventTypes: []string{"application.created"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/cintia", endpoint.URL)
+}
+
+func TestNewClient_RefusesPrivateAddressAtDialTime(t *testing.T) {
+	// Setup
+	// the endpoint passed validation, then its name was rebound to loopback
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback address")
+	}))
+	defer target.Close()
+
+	// Execute
+	_, err := NewClient(time.Second).Get(target.URL)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+}
+
+func TestRunOnce_DoesNotFollowRedirects(t *testing.T) {
+	// Setup
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("redirect was followed")
+	}))
+	defer internal.Close()
+	redirector := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer redirector.Close()
+
+	repo := NewMockRepository()
+	endpoint := createEndpoint(t, repo, redirector.URL, EventApplicationCreated)
+	NewPublisher(repo, quietLogger()).Publish(context.Background(), NewEvent(EventApplicationCreated, endpoint.UserID, nil))
+	dispatcher := NewDispatcher(repo, redirector.Client(), DeliveryOptions{}, time.Minute, quietLogger())
+
+	// Execute
+	stats, err := dispatcher.RunOnce(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Retried)
+	deliveries, err := repo.GetEndpointDeliveries(context.Background(), endpoint.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, http.StatusFound, deliveries[0].ResponseStatus)
+}
+
+// newTestService skips the address check, test receivers listen on
+// loopback
+func newTestService(repo Repository) Service {
+	return &service{
+		repo:      repo,
+		checkHost: func(ctx context.Context, host string) error { return nil },
+	}
+}
+
+func fakeLookup(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []net.IPAddr{{IP: addr.AsSlice()}}, nil
+	}
+
+	switch host {
+	case "hooks.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	case "internal.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("10.0.0.8")}}, nil
+	case "mixed.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("127.0.0.1")}}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DeliveryOptions controls retries of failed deliveries
+type DeliveryOptions struct {
+	// MaxAttempts is how many times a delivery is tried before giving up
+	MaxAttempts int
+	// BaseBackoff is the wait after the first failure, doubled after each
+	// further failure up to MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Lease is how long a claimed delivery stays hidden from other workers
+	Lease time.Duration
+	Limit int
+}
+
+type DeliveryStats struct {
+	Claimed   int
+	Succeeded int
+	Retried   int
+	Failed    int
+}
+
+// Dispatcher periodically sends due webhook deliveries, signing each
+// request with the endpoint secret
+type Dispatcher struct {
+	repo     Repository
+	client   *http.Client
+	opts     DeliveryOptions
+	interval time.Duration
+	logger   *log.Logger
+}
+
+func NewDispatcher(repo Repository, client *http.Client, opts DeliveryOptions, interval time.Duration, logger *log.Logger) *Dispatcher {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	if client == nil {
+		client = NewClient(10 * time.Second)
+	} else {
+		// a copy, the caller's client may be shared
+		c := *client
+		c.CheckRedirect = noRedirects
+		client = &c
+	}
+
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = time.Minute
+	}
+
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 6 * time.Hour
+	}
+
+	if opts.Lease <= 0 {
+		opts.Lease = 2 * time.Minute
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	return &Dispatcher{
+		repo:     repo,
+		client:   client,
+		opts:     opts,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// RunOnce sends every delivery currently due
+func (d *Dispatcher) RunOnce(ctx context.Context) (DeliveryStats, error) {
+	stats := DeliveryStats{}
+
+	claimed, err := d.repo.ClaimDue(ctx, d.opts.Limit, time.Now().Add(d.opts.Lease))
+	if err != nil {
+		return stats, err
+	}
+	stats.Claimed = len(claimed)
+
+	for _, delivery := range claimed {
+		result := d.deliver(ctx, delivery)
+
+		switch result.Status {
+		case DeliverySucceeded:
+			stats.Succeeded++
+		case DeliveryPending:
+			stats.Retried++
+		case DeliveryFailed:
+			stats.Failed++
+			d.logger.Printf("webhook delivery %s to %s failed after %d attempts: %s", delivery.ID, delivery.URL, delivery.Attempts, result.Error)
+		}
+
+		if err := d.repo.Complete(ctx, delivery.ID, result); err != nil {
+			d.logger.Printf("failed recording webhook delivery %s: %v", delivery.ID, err)
+		}
+	}
+
+	return stats, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *ClaimedDelivery) AttemptResult {
+	status, err := d.send(ctx, delivery)
+	if err == nil {
+		return AttemptResult{
+			Status:         DeliverySucceeded,
+			ResponseStatus: status,
+			NextAttemptAt:  time.Now(),
+		}
+	}
+
+	result := AttemptResult{
+		Status:         DeliveryPending,
+		ResponseStatus: status,
+		Error:          truncate(err.Error(), 500),
+		NextAttemptAt:  time.Now().Add(d.backoff(delivery.Attempts)),
+	}
+	if delivery.Attempts >= d.opts.MaxAttempts {
+		result.Status = DeliveryFailed
+		result.NextAttemptAt = time.Now()
+	}
+
+	return result
+}
+
+// send posts the payload and returns the response status code
+func (d *Dispatcher) send(ctx context.Context, delivery *ClaimedDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Cintia-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	now := time.Now()
+	req.Header.Set(HeaderTimestamp, fmt.Sprintf("%d", now.Unix()))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, now, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait before the next attempt after the given number
+// of failed attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= d.opts.MaxBackoff {
+			return d.opts.MaxBackoff
+		}
+	}
+	return wait
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.Printf("webhook dispatcher started: interval=%s max_attempts=%d", d.interval, d.opts.MaxAttempts)
+
+	for {
+		stats, err := d.RunOnce(ctx)
+		if err != nil {
+			d.logger.Printf("webhook dispatcher run failed: %v", err)
+		} else if stats.Claimed > 0 {
+			d.logger.Printf("webhook dispatcher run finished: claimed=%d succeeded=%d retried=%d failed=%d", stats.Claimed, stats.Succeeded, stats.Retried, stats.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Println("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
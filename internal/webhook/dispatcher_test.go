@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRunOnce_DeliversSignedPayload(t *testing.T) {
+	repo := NewMockRepository()
+	receiver := newReceiver(http.StatusOK)
+	defer receiver.Close()
+
+	endpoint := createEndpoint(t, repo, receiver.URL, EventApplicationCreated)
+	publisher := NewPublisher(repo, quietLogger())
+	publisher.Publish(context.Background(), NewEvent(EventApplicationCreated, endpoint.UserID, map[string]string{"company": "Acme"}))
+	// Another user's event must not reach this endpoint
+	publisher.Publish(context.Background(), NewEvent(EventApplicationCreated, uuid.New(), nil))
+
+	dispatcher := NewDispatcher(repo, receiver.Client(), DeliveryOptions{}, time.Minute, quietLogger())
+	stats, err := dispatcher.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Claimed != 1 || stats.Succeeded != 1 {
+		t.Fatalf("expected 1 successful delivery, got %+v", stats)
+	}
+
+	requests := receiver.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	req := requests[0]
+	if req.header.Get(HeaderEvent) != string(EventApplicationCreated) {
+		t.Fatalf("unexpected event header %q", req.header.Get(HeaderEvent))
+	}
+
+	timestamp := req.header.Get(HeaderTimestamp)
+	if !Verify(endpoint.Secret, req.header.Get(HeaderSignature), timestamp, req.body, 5*time.Minute) {
+		t.Fatal("expected a valid signature")
+	}
+	if Verify("whsec_other", req.header.Get(HeaderSignature), timestamp, req.body, 5*time.Minute) {
+		t.Fatal("expected signature check with another secret to fail")
+	}
+
+	deliveries, _ := repo.GetEndpointDeliveries(context.Background(), endpoint.ID, 10)
+	if deliveries[0].Status != DeliverySucceeded || deliveries[0].ResponseStatus != http.StatusOK {
+		t.Fatalf("expected delivery to be recorded as succeeded, got %+v", deliveries[0])
+	}
+}
+
+func TestRunOnce_RetriesUntilMaxAttempts(t *testing.T) {
+	repo := NewMockRepository()
+	receiver := newReceiver(http.StatusInternalServerError)
+	defer receiver.Close()
+
+	endpoint := createEndpoint(t, repo, receiver.URL, EventJobCreated)
+	NewPublisher(repo, quietLogger()).Publish(context.Background(), NewEvent(EventJobCreated, endpoint.UserID, nil))
+
+	opts := DeliveryOptions{MaxAttempts: 3, BaseBackoff: time.Nanosecond, MaxBackoff: time.Nanosecond}
+	dispatcher := NewDispatcher(repo, receiver.Client(), opts, time.Minute, quietLogger())
+
+	for attempt := 1; attempt < 3; attempt++ {
+		stats, _ := dispatcher.RunOnce(context.Background())
+		if stats.Retried != 1 {
+			t.Fatalf("attempt %d: expected a retry, got %+v", attempt, stats)
+		}
+	}
+
+	stats, _ := dispatcher.RunOnce(context.Background())
+	if stats.Failed != 1 {
+		t.Fatalf("expected delivery to fail after max attempts, got %+v", stats)
+	}
+
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Claimed != 0 {
+		t.Fatalf("expected failed delivery not to be claimed again, got %+v", stats)
+	}
+
+	deliveries, _ := repo.GetEndpointDeliveries(context.Background(), endpoint.ID, 10)
+	if deliveries[0].Status != DeliveryFailed || deliveries[0].Attempts != 3 {
+		t.Fatalf("expected failed delivery after 3 attempts, got %+v", deliveries[0])
+	}
+	if deliveries[0].ResponseStatus != http.StatusInternalServerError || deliveries[0].LastError == "" {
+		t.Fatalf("expected last response to be recorded, got %+v", deliveries[0])
+	}
+}
+
+func TestRunOnce_FailedAttemptIsNotRetriedBeforeBackoff(t *testing.T) {
+	repo := NewMockRepository()
+	receiver := newReceiver(http.StatusServiceUnavailable)
+	defer receiver.Close()
+
+	endpoint := createEndpoint(t, repo, receiver.URL, EventJobCreated)
+	NewPublisher(repo, quietLogger()).Publish(context.Background(), NewEvent(EventJobCreated, endpoint.UserID, nil))
+
+	dispatcher := NewDispatcher(repo, receiver.Client(), DeliveryOptions{BaseBackoff: time.Hour}, time.Minute, quietLogger())
+
+	stats, _ := dispatcher.RunOnce(context.Background())
+	if stats.Retried != 1 {
+		t.Fatalf("expected a retry to be scheduled, got %+v", stats)
+	}
+
+	stats, _ = dispatcher.RunOnce(context.Background())
+	if stats.Claimed != 0 {
+		t.Fatalf("expected delivery to wait for its backoff, got %+v", stats)
+	}
+}
+
+func TestBackoff_DoublesUpToMax(t *testing.T) {
+	dispatcher := NewDispatcher(NewMockRepository(), nil, DeliveryOptions{BaseBackoff: time.Minute, MaxBackoff: 5 * time.Minute}, time.Minute, quietLogger())
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, want := range expected {
+		if got := dispatcher.backoff(i + 1); got != want {
+			t.Fatalf("attempt %d: expected backoff %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []receivedRequest
+}
+
+func newReceiver(status int) *receiver {
+	r := &receiver{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		r.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	return r
+}
+
+func (r *receiver) Requests() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func createEndpoint(t *testing.T, repo Repository, url string, eventTypes ...EventType) *Endpoint {
+	t.Helper()
+
+	types := make([]string, 0, len(eventTypes))
+	for _, et := range eventTypes {
+		types = append(types, string(et))
+	}
+
+	endpoint, err := newTestService(repo).CreateEndpoint(context.Background(), uuid.New(), CreateEndpointInput{URL: url, EventTypes: types})
+	if err != nil {
+		t.Fatalf("failed to create endpoint: %v", err)
+	}
+	return endpoint
+}
+
+func quietLogger() *log.Logger {
+	return log.New(io.Discard, "", 0)
+}
@@ -0,0 +1,276 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	CreateEndpointHandler(c *gin.Context)
+	GetEndpointsHandler(c *gin.Context)
+	GetEndpointHandler(c *gin.Context)
+	UpdateEndpointHandler(c *gin.Context)
+	DeleteEndpointHandler(c *gin.Context)
+	GetDeliveriesHandler(c *gin.Context)
+	ReplayDeliveryHandler(c *gin.Context)
+}
+
+type GinHandler struct {
+	service Service
+}
+
+func NewGinHandler(service Service) *GinHandler {
+	return &GinHandler{service: service}
+}
+
+// POST /api/webhooks - register an endpoint, returning its signing secret once
+func (h *GinHandler) CreateEndpointHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req CreateEndpointInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "webhook endpoint created successfully",
+		"endpoint": endpoint,
+		"secret":   endpoint.Secret,
+	})
+}
+
+// GET /api/webhooks - list the user's endpoints
+func (h *GinHandler) GetEndpointsHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.service.GetUserEndpoints(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"endpoints": endpoints,
+		"total":     len(endpoints),
+	})
+}
+
+// GET /api/webhooks/:id - get an endpoint
+func (h *GinHandler) GetEndpointHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	endpointID, ok := parseID(c, "id", "invalid webhook id format")
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.service.GetEndpoint(c.Request.Context(), userID, endpointID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// PUT /api/webhooks/:id - change the url, subscribed events or active flag
+func (h *GinHandler) UpdateEndpointHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	endpointID, ok := parseID(c, "id", "invalid webhook id format")
+	if !ok {
+		return
+	}
+
+	var req UpdateEndpointInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(c.Request.Context(), userID, endpointID, req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "webhook endpoint updated successfully",
+		"endpoint": endpoint,
+	})
+}
+
+// DELETE /api/webhooks/:id - remove an endpoint and its delivery log
+func (h *GinHandler) DeleteEndpointHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	endpointID, ok := parseID(c, "id", "invalid webhook id format")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEndpoint(c.Request.Context(), userID, endpointID); err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "webhook endpoint deleted successfully",
+	})
+}
+
+// GET /api/webhooks/:id/deliveries?limit=50 - recent deliveries of an endpoint
+func (h *GinHandler) GetDeliveriesHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	endpointID, ok := parseID(c, "id", "invalid webhook id format")
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "limit must be a positive integer",
+		})
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), userID, endpointID, limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"total":      len(deliveries),
+	})
+}
+
+// POST /api/webhooks/:id/deliveries/:deliveryID/replay - send a past payload again
+func (h *GinHandler) ReplayDeliveryHandler(c *gin.Context) {
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	endpointID, ok := parseID(c, "id", "invalid webhook id format")
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := parseID(c, "deliveryID", "invalid delivery id format")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.ReplayDelivery(c.Request.Context(), userID, endpointID, deliveryID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":  "webhook delivery queued for replay",
+		"delivery": delivery,
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrEndpointNotFound),
+		errors.Is(err, ErrDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidURL),
+		errors.Is(err, ErrPrivateAddress),
+		errors.Is(err, ErrNoEventTypes),
+		errors.Is(err, ErrUnknownEventType):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "user not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid user id format",
+		})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
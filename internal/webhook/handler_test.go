@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEndpointHandler_ReturnsSecretOnce(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	service := newTestService(NewMockRepository())
+	handler := NewGinHandler(service)
+
+	reqBody := CreateEndpointInput{
+		URL:        "https://hooks.example.com/cintia",
+		EventTypes: []string{"application.created", "application.status_changed", "application.created"},
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/webhooks", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", userID.String())
+
+	// Execute
+	handler.CreateEndpointHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Endpoint Endpoint `json:"endpoint"`
+		Secret   string   `json:"secret"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(response.Secret, "whsec_"))
+	assert.Equal(t, []string{"application.created", "application.status_changed"}, response.Endpoint.EventTypes)
+	assert.True(t, response.Endpoint.IsActive)
+
+	// The secret is not part of later reads
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/webhooks", nil)
+	c.Set("userID", userID.String())
+
+	handler.GetEndpointsHandler(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), response.Secret)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+}
+
+func TestCreateEndpointHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CreateEndpointInput
+	}{
+		{"relative url", CreateEndpointInput{URL: "/hooks", EventTypes: []string{"job.created"}}},
+		{"unsupported scheme", CreateEndpointInput{URL: "ftp://example.com", EventTypes: []string{"job.created"}}},
+		{"unknown event", CreateEndpointInput{URL: "https://example.com", EventTypes: []string{"job.deleted"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler := NewGinHandler(newTestService(NewMockRepository()))
+			body, _ := json.Marshal(tt.input)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/webhooks", bytes.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uuid.New().String())
+
+			// Execute
+			handler.CreateEndpointHandler(c)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGetEndpointHandler_OtherUser(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	endpoint := createEndpoint(t, repo, "https://hooks.example.com", EventJobCreated)
+	handler := NewGinHandler(newTestService(repo))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/webhooks/"+endpoint.ID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: endpoint.ID.String()}}
+	c.Set("userID", uuid.New().String())
+
+	// Execute
+	handler.GetEndpointHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestReplayDeliveryHandler_QueuesNewDelivery(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	endpoint := createEndpoint(t, repo, "https://hooks.example.com", EventJobCreated)
+	NewPublisher(repo, quietLogger()).Publish(context.Background(), NewEvent(EventJobCreated, endpoint.UserID, map[string]string{"title": "Go Developer"}))
+
+	deliveries, _ := repo.GetEndpointDeliveries(context.Background(), endpoint.ID, 10)
+	require.Len(t, deliveries, 1)
+	original := deliveries[0]
+	handler := NewGinHandler(newTestService(repo))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/webhooks/"+endpoint.ID.String()+"/deliveries/"+original.ID.String()+"/replay", nil)
+	c.Params = gin.Params{
+		{Key: "id", Value: endpoint.ID.String()},
+		{Key: "deliveryID", Value: original.ID.String()},
+	}
+	c.Set("userID", endpoint.UserID.String())
+
+	// Execute
+	handler.ReplayDeliveryHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var response struct {
+		Delivery Delivery `json:"delivery"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.NotEqual(t, original.ID, response.Delivery.ID)
+	assert.Equal(t, original.EventID, response.Delivery.EventID)
+	assert.Equal(t, original.Payload, response.Delivery.Payload)
+	assert.Equal(t, DeliveryPending, response.Delivery.Status)
+
+	deliveries, _ = repo.GetEndpointDeliveries(context.Background(), endpoint.ID, 10)
+	assert.Len(t, deliveries, 2)
+}
+
+func TestReplayDeliveryHandler_DeliveryOfAnotherEndpoint(t *testing.T) {
+	// Setup
+	repo := NewMockRepository()
+	first := createEndpoint(t, repo, "https://one.example.com", EventJobCreated)
+	second := createEndpoint(t, repo, "https://two.example.com", EventJobCreated)
+	NewPublisher(repo, quietLogger()).Publish(context.Background(), NewEvent(EventJobCreated, second.UserID, nil))
+
+	deliveries, _ := repo.GetEndpointDeliveries(context.Background(), second.ID, 10)
+	require.Len(t, deliveries, 1)
+	handler := NewGinHandler(newTestService(repo))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/webhooks/replay", nil)
+	c.Params = gin.Params{
+		{Key: "id", Value: first.ID.String()},
+		{Key: "deliveryID", Value: deliveries[0].ID.String()},
+	}
+	c.Set("userID", first.UserID.String())
+
+	// Execute
+	handler.ReplayDeliveryHandler(c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Publisher hands events to webhook subscribers. Publishing never fails the
+// caller: the action that raised the event already happened
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// payload is the JSON body sent to endpoints
+type payload struct {
+	ID        uuid.UUID `json:"id"`
+	Type      EventType `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// QueuePublisher stores a pending delivery per subscribed endpoint, which a
+// Dispatcher sends later
+type QueuePublisher struct {
+	repo   Repository
+	logger *log.Logger
+}
+
+func NewPublisher(repo Repository, logger *log.Logger) *QueuePublisher {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &QueuePublisher{repo: repo, logger: logger}
+}
+
+func (p *QueuePublisher) Publish(ctx context.Context, event Event) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	body, err := json.Marshal(payload{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Data,
+	})
+	if err != nil {
+		p.logger.Printf("failed encoding webhook event %s: %v", event.Type, err)
+		return
+	}
+
+	if _, err := p.repo.Enqueue(ctx, event, body); err != nil {
+		p.logger.Printf("failed queueing webhook event %s %s: %v", event.Type, event.ID, err)
+	}
+}
+
+// NewEvent builds an event for a single user's endpoints
+func NewEvent(eventType EventType, userID uuid.UUID, data any) Event {
+	return Event{Type: eventType, UserID: userID, Data: data}
+}
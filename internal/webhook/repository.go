@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("webhook not found")
+
+// ClaimedDelivery is a delivery taken by a worker, with what it needs to
+// send it
+type ClaimedDelivery struct {
+	Delivery
+	URL    string
+	Secret string
+}
+
+// AttemptResult is the outcome of one delivery attempt
+type AttemptResult struct {
+	Status         DeliveryStatus
+	ResponseStatus int
+	Error          string
+	NextAttemptAt  time.Time
+}
+
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error)
+	GetUserEndpoints(ctx context.Context, userID uuid.UUID) ([]*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	// Enqueue creates a pending delivery for every endpoint subscribed to
+	// the event and returns how many were created
+	Enqueue(ctx context.Context, event Event, payload []byte) (int, error)
+	CreateDelivery(ctx context.Context, delivery *Delivery) (*Delivery, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	GetEndpointDeliveries(ctx context.Context, endpointID uuid.UUID, limit int) ([]*Delivery, error)
+	// ClaimDue takes up to limit due deliveries, hiding them from other
+	// workers until leaseUntil
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*ClaimedDelivery, error)
+	Complete(ctx context.Context, id uuid.UUID, result AttemptResult) error
+}
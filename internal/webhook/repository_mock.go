@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepository struct {
+	mu         sync.Mutex
+	endpoints  map[uuid.UUID]*Endpoint
+	deliveries map[uuid.UUID]*Delivery
+}
+
+func NewMockRepository() Repository {
+	return &mockRepository{
+		endpoints:  make(map[uuid.UUID]*Endpoint),
+		deliveries: make(map[uuid.UUID]*Delivery),
+	}
+}
+
+func (m *mockRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if endpoint.ID == uuid.Nil {
+		endpoint.ID = uuid.New()
+	}
+	endpoint.IsActive = true
+	now := time.Now()
+	endpoint.CreatedAt = now
+	endpoint.UpdatedAt = now
+
+	m.endpoints[endpoint.ID] = endpoint
+	return endpoint, nil
+}
+
+func (m *mockRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoint, exists := m.endpoints[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	copied := *endpoint
+	return &copied, nil
+}
+
+func (m *mockRepository) GetUserEndpoints(ctx context.Context, userID uuid.UUID) ([]*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoints := []*Endpoint{}
+	for _, endpoint := range m.endpoints {
+		if endpoint.UserID == userID {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt)
+	})
+	return endpoints, nil
+}
+
+func (m *mockRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.endpoints[endpoint.ID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	if endpoint.URL != "" {
+		existing.URL = endpoint.URL
+	}
+	if endpoint.EventTypes != nil {
+		existing.EventTypes = endpoint.EventTypes
+	}
+	existing.IsActive = endpoint.IsActive
+	existing.UpdatedAt = time.Now()
+
+	*endpoint = *existing
+	return nil
+}
+
+func (m *mockRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.endpoints[id]; !exists {
+		return ErrNotFound
+	}
+
+	delete(m.endpoints, id)
+	for deliveryID, delivery := range m.deliveries {
+		if delivery.EndpointID == id {
+			delete(m.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Enqueue(ctx context.Context, event Event, payload []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, endpoint := range m.endpoints {
+		if !endpoint.IsActive || !subscribed(endpoint, event.Type) {
+			continue
+		}
+		if endpoint.UserID != event.UserID {
+			continue
+		}
+
+		m.addDelivery(&Delivery{
+			EndpointID: endpoint.ID,
+			EventID:    event.ID,
+			EventType:  string(event.Type),
+			Payload:    string(payload),
+		})
+		count++
+	}
+
+	return count, nil
+}
+
+func (m *mockRepository) CreateDelivery(ctx context.Context, delivery *Delivery) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.addDelivery(delivery), nil
+}
+
+func (m *mockRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delivery, exists := m.deliveries[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	copied := *delivery
+	return &copied, nil
+}
+
+func (m *mockRepository) GetEndpointDeliveries(ctx context.Context, endpointID uuid.UUID, limit int) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deliveries := []*Delivery{}
+	for _, delivery := range m.deliveries {
+		if delivery.EndpointID == endpointID {
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (m *mockRepository) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*ClaimedDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	due := []*Delivery{}
+	for _, delivery := range m.deliveries {
+		if delivery.Status == DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*ClaimedDelivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttemptAt = leaseUntil
+		delivery.Attempts++
+		delivery.LastAttemptAt = &now
+
+		endpoint := m.endpoints[delivery.EndpointID]
+		claimed = append(claimed, &ClaimedDelivery{
+			Delivery: *delivery,
+			URL:      endpoint.URL,
+			Secret:   endpoint.Secret,
+		})
+	}
+
+	return claimed, nil
+}
+
+func (m *mockRepository) Complete(ctx context.Context, id uuid.UUID, result AttemptResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delivery, exists := m.deliveries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	delivery.Status = result.Status
+	delivery.ResponseStatus = result.ResponseStatus
+	delivery.LastError = result.Error
+	delivery.NextAttemptAt = result.NextAttemptAt
+	if result.Status != DeliveryPending {
+		now := time.Now()
+		delivery.CompletedAt = &now
+	}
+
+	return nil
+}
+
+// addDelivery stores a new pending delivery. Callers must hold the lock
+func (m *mockRepository) addDelivery(delivery *Delivery) *Delivery {
+	now := time.Now()
+	stored := &Delivery{
+		ID:            uuid.New(),
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	m.deliveries[stored.ID] = stored
+	copied := *stored
+	return &copied
+}
+
+func subscribed(endpoint *Endpoint, eventType EventType) bool {
+	for _, et := range endpoint.EventTypes {
+		if EventType(et) == eventType {
+			return true
+		}
+	}
+	return false
+}
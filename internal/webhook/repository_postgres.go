@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luis-octavius/cintia/internal/database"
+)
+
+type PostgresRepository struct {
+	queries *database.Queries
+}
+
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{
+		queries: database.New(db),
+	}
+}
+
+func (r *PostgresRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	dbEndpoint, err := r.queries.CreateWebhookEndpoint(ctx, database.CreateWebhookEndpointParams{
+		UserID:     endpoint.UserID,
+		Url:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		EventTypes: endpoint.EventTypes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbEndpointToEndpoint(&dbEndpoint), nil
+}
+
+func (r *PostgresRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error) {
+	dbEndpoint, err := r.queries.GetWebhookEndpointByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbEndpointToEndpoint(&dbEndpoint), nil
+}
+
+func (r *PostgresRepository) GetUserEndpoints(ctx context.Context, userID uuid.UUID) ([]*Endpoint, error) {
+	dbEndpoints, err := r.queries.GetUserWebhookEndpoints(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*Endpoint, len(dbEndpoints))
+	for i, dbEndpoint := range dbEndpoints {
+		endpoints[i] = dbEndpointToEndpoint(&dbEndpoint)
+	}
+
+	return endpoints, nil
+}
+
+func (r *PostgresRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	params := database.UpdateWebhookEndpointParams{
+		ID:         endpoint.ID,
+		EventTypes: endpoint.EventTypes,
+		IsActive:   sql.NullBool{Bool: endpoint.IsActive, Valid: true},
+	}
+	if endpoint.URL != "" {
+		params.Url = sql.NullString{String: endpoint.URL, Valid: true}
+	}
+
+	dbEndpoint, err := r.queries.UpdateWebhookEndpoint(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	*endpoint = *dbEndpointToEndpoint(&dbEndpoint)
+	return nil
+}
+
+func (r *PostgresRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteWebhookEndpoint(ctx, id)
+}
+
+func (r *PostgresRepository) Enqueue(ctx context.Context, event Event, payload []byte) (int, error) {
+	rows, err := r.queries.EnqueueWebhookEvent(ctx, database.EnqueueWebhookEventParams{
+		EventID:   event.ID,
+		EventType: string(event.Type),
+		Payload:   string(payload),
+		UserID:    event.UserID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
+
+func (r *PostgresRepository) CreateDelivery(ctx context.Context, delivery *Delivery) (*Delivery, error) {
+	dbDelivery, err := r.queries.CreateWebhookDelivery(ctx, database.CreateWebhookDeliveryParams{
+		EndpointID: delivery.EndpointID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbDeliveryToDelivery(&dbDelivery), nil
+}
+
+func (r *PostgresRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	dbDelivery, err := r.queries.GetWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dbDeliveryToDelivery(&dbDelivery), nil
+}
+
+func (r *PostgresRepository) GetEndpointDeliveries(ctx context.Context, endpointID uuid.UUID, limit int) ([]*Delivery, error) {
+	dbDeliveries, err := r.queries.GetEndpointWebhookDeliveries(ctx, database.GetEndpointWebhookDeliveriesParams{
+		EndpointID: endpointID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*Delivery, len(dbDeliveries))
+	for i, dbDelivery := range dbDeliveries {
+		deliveries[i] = dbDeliveryToDelivery(&dbDelivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *PostgresRepository) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*ClaimedDelivery, error) {
+	rows, err := r.queries.ClaimDueWebhookDeliveries(ctx, database.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: leaseUntil,
+		BatchSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*ClaimedDelivery, len(rows))
+	for i, row := range rows {
+		claimed[i] = &ClaimedDelivery{
+			Delivery: Delivery{
+				ID:            row.ID,
+				EndpointID:    row.EndpointID,
+				EventID:       row.EventID,
+				EventType:     row.EventType,
+				Payload:       row.Payload,
+				Status:        DeliveryPending,
+				Attempts:      int(row.Attempts),
+				NextAttemptAt: leaseUntil,
+			},
+			URL:    row.Url,
+			Secret: row.Secret,
+		}
+	}
+
+	return claimed, nil
+}
+
+func (r *PostgresRepository) Complete(ctx context.Context, id uuid.UUID, result AttemptResult) error {
+	params := database.CompleteWebhookDeliveryParams{
+		ID:            id,
+		Status:        string(result.Status),
+		NextAttemptAt: result.NextAttemptAt,
+	}
+	if result.ResponseStatus != 0 {
+		params.ResponseStatus = sql.NullInt32{Int32: int32(result.ResponseStatus), Valid: true}
+	}
+	if result.Error != "" {
+		params.LastError = sql.NullString{String: result.Error, Valid: true}
+	}
+
+	return r.queries.CompleteWebhookDelivery(ctx, params)
+}
+
+// Helper functions to convert between domain and database models
+
+func dbEndpointToEndpoint(dbEndpoint *database.WebhookEndpoint) *Endpoint {
+	return &Endpoint{
+		ID:         dbEndpoint.ID,
+		UserID:     dbEndpoint.UserID,
+		URL:        dbEndpoint.Url,
+		Secret:     dbEndpoint.Secret,
+		EventTypes: dbEndpoint.EventTypes,
+		IsActive:   dbEndpoint.IsActive,
+		CreatedAt:  dbEndpoint.CreatedAt,
+		UpdatedAt:  dbEndpoint.UpdatedAt,
+	}
+}
+
+func dbDeliveryToDelivery(dbDelivery *database.WebhookDelivery) *Delivery {
+	delivery := &Delivery{
+		ID:            dbDelivery.ID,
+		EndpointID:    dbDelivery.EndpointID,
+		EventID:       dbDelivery.EventID,
+		EventType:     dbDelivery.EventType,
+		Payload:       dbDelivery.Payload,
+		Status:        DeliveryStatus(dbDelivery.Status),
+		Attempts:      int(dbDelivery.Attempts),
+		NextAttemptAt: dbDelivery.NextAttemptAt,
+		CreatedAt:     dbDelivery.CreatedAt,
+	}
+
+	if dbDelivery.LastAttemptAt.Valid {
+		delivery.LastAttemptAt = &dbDelivery.LastAttemptAt.Time
+	}
+	if dbDelivery.ResponseStatus.Valid {
+		delivery.ResponseStatus = int(dbDelivery.ResponseStatus.Int32)
+	}
+	if dbDelivery.LastError.Valid {
+		delivery.LastError = dbDelivery.LastError.String
+	}
+	if dbDelivery.CompletedAt.Valid {
+		delivery.CompletedAt = &dbDelivery.CompletedAt.Time
+	}
+
+	return delivery
+}
@@ -0,0 +1,247 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrForbidden        = errors.New("webhook endpoint belongs to another user")
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrPrivateAddress   = errors.New("webhook url must point to a public address")
+	ErrNoEventTypes     = errors.New("at least one event type is required")
+	ErrUnknownEventType = errors.New("unknown event type")
+)
+
+const maxDeliveriesPage = 100
+
+type Service interface {
+	CreateEndpoint(ctx context.Context, userID uuid.UUID, input CreateEndpointInput) (*Endpoint, error)
+	GetUserEndpoints(ctx context.Context, userID uuid.UUID) ([]*Endpoint, error)
+	GetEndpoint(ctx context.Context, userID, id uuid.UUID) (*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, userID, id uuid.UUID, input UpdateEndpointInput) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, userID, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, userID, endpointID uuid.UUID, limit int) ([]*Delivery, error)
+	ReplayDelivery(ctx context.Context, userID, endpointID, deliveryID uuid.UUID) (*Delivery, error)
+}
+
+type service struct {
+	repo Repository
+	// checkHost refuses hosts endpoints must not point to
+	checkHost func(ctx context.Context, host string) error
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo:      repo,
+		checkHost: publicHost(net.DefaultResolver.LookupIPAddr),
+	}
+}
+
+// CreateEndpoint registers an endpoint with a fresh signing secret. The
+// returned endpoint is the only place the secret is exposed
+func (s *service) CreateEndpoint(ctx context.Context, userID uuid.UUID, input CreateEndpointInput) (*Endpoint, error) {
+	if err := s.validateURL(ctx, input.URL); err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := normalizeEventTypes(input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	endpoint := &Endpoint{
+		UserID:     userID,
+		URL:        input.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   true,
+	}
+
+	created, err := s.repo.CreateEndpoint(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *service) GetUserEndpoints(ctx context.Context, userID uuid.UUID) ([]*Endpoint, error) {
+	endpoints, err := s.repo.GetUserEndpoints(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+func (s *service) GetEndpoint(ctx context.Context, userID, id uuid.UUID) (*Endpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	if !endpoint.IsOwnedBy(userID) {
+		return nil, ErrForbidden
+	}
+
+	return endpoint, nil
+}
+
+func (s *service) UpdateEndpoint(ctx context.Context, userID, id uuid.UUID, input UpdateEndpointInput) (*Endpoint, error) {
+	endpoint, err := s.GetEndpoint(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &Endpoint{ID: endpoint.ID, IsActive: endpoint.IsActive}
+
+	if input.URL != "" {
+		if err := s.validateURL(ctx, input.URL); err != nil {
+			return nil, err
+		}
+		update.URL = input.URL
+	}
+
+	if input.EventTypes != nil {
+		eventTypes, err := normalizeEventTypes(input.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		update.EventTypes = eventTypes
+	}
+
+	if input.IsActive != nil {
+		update.IsActive = *input.IsActive
+	}
+
+	if err := s.repo.UpdateEndpoint(ctx, update); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	return update, nil
+}
+
+func (s *service) DeleteEndpoint(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.GetEndpoint(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteEndpoint(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the most recent deliveries of an endpoint
+func (s *service) GetDeliveries(ctx context.Context, userID, endpointID uuid.UUID, limit int) ([]*Delivery, error) {
+	if _, err := s.GetEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxDeliveriesPage {
+		limit = maxDeliveriesPage
+	}
+
+	deliveries, err := s.repo.GetEndpointDeliveries(ctx, endpointID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery queues the payload of a past delivery again. The original
+// delivery is left untouched so the log keeps every attempt
+func (s *service) ReplayDelivery(ctx context.Context, userID, endpointID, deliveryID uuid.UUID) (*Delivery, error) {
+	if _, err := s.GetEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+
+	original, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if original.EndpointID != endpointID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	replay, err := s.repo.CreateDelivery(ctx, &Delivery{
+		EndpointID: original.EndpointID,
+		EventID:    original.EventID,
+		EventType:  original.EventType,
+		Payload:    original.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay webhook delivery: %w", err)
+	}
+
+	return replay, nil
+}
+
+// validateURL accepts absolute http(s) urls whose host only resolves to
+// public addresses, endpoints must not reach into the internal network
+func (s *service) validateURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return ErrInvalidURL
+	}
+	return s.checkHost(ctx, u.Hostname())
+}
+
+// normalizeEventTypes validates and deduplicates subscribed event types
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, ErrNoEventTypes
+	}
+
+	seen := make(map[string]struct{}, len(eventTypes))
+	result := make([]string, 0, len(eventTypes))
+	for _, et := range eventTypes {
+		et = strings.ToLower(strings.TrimSpace(et))
+		if !EventType(et).IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, et)
+		}
+		if _, dup := seen[et]; dup {
+			continue
+		}
+		seen[et] = struct{}{}
+		result = append(result, et)
+	}
+
+	return result, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Cintia-Event"
+	HeaderDelivery  = "X-Cintia-Delivery"
+	HeaderTimestamp = "X-Cintia-Timestamp"
+	HeaderSignature = "X-Cintia-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature header value for a payload. The timestamp is
+// part of the signed content so receivers can reject replayed requests:
+// hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign, given the raw timestamp
+// header. Timestamps further than tolerance from now are rejected
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	ts := time.Unix(unix, 0)
+	if tolerance > 0 {
+		age := time.Since(ts)
+		if age > tolerance || age < -tolerance {
+			return false
+		}
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	expected := Sign(secret, ts, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventApplicationCreated       EventType = "application.created"
+	EventApplicationStatusChanged EventType = "application.status_changed"
+	EventJobCreated               EventType = "job.created"
+)
+
+// EventTypes lists every event endpoints can subscribe to
+var EventTypes = []EventType{
+	EventApplicationCreated,
+	EventApplicationStatusChanged,
+	EventJobCreated,
+}
+
+// IsValid validate the event type
+func (t EventType) IsValid() bool {
+	for _, et := range EventTypes {
+		if t == et {
+			return true
+		}
+	}
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type Endpoint struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"` // only shown once, on creation
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Delivery is one event sent, or to be sent, to one endpoint
+type Delivery struct {
+	ID             uuid.UUID      `json:"id"`
+	EndpointID     uuid.UUID      `json:"endpoint_id"`
+	EventID        uuid.UUID      `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	Payload        string         `json:"payload"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+	ResponseStatus int            `json:"response_status,omitempty"`
+	LastError      string         `json:"last_error,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CompletedAt    *time.Time     `json:"completed_at,omitempty"`
+}
+
+// Event is something that happened in the app. It only goes to the
+// endpoints of UserID, endpoints never see another user's data
+type Event struct {
+	ID        uuid.UUID
+	Type      EventType
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	Data      any
+}
+
+type CreateEndpointInput struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+type UpdateEndpointInput struct {
+	URL        string   `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	IsActive   *bool    `json:"is_active,omitempty"`
+}
+
+// IsOwnedBy checks whether the endpoint belongs to the user
+func (e *Endpoint) IsOwnedBy(userID uuid.UUID) bool {
+	return e.UserID == userID
+}
@@ -0,0 +1,21 @@
+-- name: ScheduleAccountDeletion :one
+INSERT INTO account_deletions (user_id, scheduled_for)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET scheduled_for = account_deletions.scheduled_for
+RETURNING user_id, requested_at, scheduled_for;
+
+-- name: GetAccountDeletion :one
+SELECT user_id, requested_at, scheduled_for
+FROM account_deletions
+WHERE user_id = $1;
+
+-- name: CancelAccountDeletion :execrows
+DELETE FROM account_deletions
+WHERE user_id = $1;
+
+-- name: ListDueAccountDeletions :many
+SELECT user_id, requested_at, scheduled_for
+FROM account_deletions
+WHERE scheduled_for <= $1
+ORDER BY scheduled_for
+LIMIT $2;
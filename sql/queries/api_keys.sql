@@ -0,0 +1,35 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, name, prefix, key_hash, scopes, last_used_at, revoked_at, created_at;
+
+-- name: ListUserAPIKeys :many
+SELECT id, user_id, name, prefix, key_hash, scopes, last_used_at, revoked_at, created_at
+FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: CountUserAPIKeys :one
+SELECT COUNT(*) AS count FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: GetActiveAPIKeyByHash :one
+-- The owner's email and role are read along with the key, so changes to
+-- the account apply to its keys right away
+SELECT k.id, k.user_id, k.name, k.prefix, k.key_hash, k.scopes, k.last_used_at, k.revoked_at, k.created_at,
+  u.email AS user_email, u.role AS user_role
+FROM api_keys k
+JOIN users u ON u.id = k.user_id
+WHERE k.key_hash = $1 AND k.revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+-- Writes at most once a minute per key, not on every request
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1
+  AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
@@ -0,0 +1,99 @@
+-- name: GetApplicationStatusCounts :many
+SELECT status, COUNT(*) AS count
+FROM applications
+WHERE user_id = $1
+GROUP BY status
+ORDER BY count DESC, status ASC;
+
+-- name: GetApplicationFunnel :one
+-- How many applications reached each funnel step. Steps follow the kind of
+-- the application's stage, so custom pipelines count the same way: an
+-- application reached the interview step when its stage is of kind
+-- interview or later, or when it has an interview round
+WITH funnel AS (
+  SELECT
+    a.applied_at,
+    a.first_response_at,
+    (s.kind IN ('interview', 'offer', 'accepted')
+      OR EXISTS (SELECT 1 FROM interviews i WHERE i.application_id = a.id)) AS reached_interview,
+    (s.kind IN ('offer', 'accepted')
+      OR a.offer_date IS NOT NULL
+      OR EXISTS (SELECT 1 FROM offers o WHERE o.application_id = a.id)) AS reached_offer,
+    s.kind = 'accepted' AS accepted
+  FROM applications a
+  JOIN pipeline_stages s ON s.id = a.stage_id
+  WHERE a.user_id = $1
+)
+SELECT
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE reached_interview) AS interviewing,
+  COUNT(*) FILTER (WHERE reached_offer) AS offer,
+  COUNT(*) FILTER (WHERE accepted) AS accepted,
+  COUNT(first_response_at) AS responded,
+  (percentile_cont(0.5) WITHIN GROUP (
+    ORDER BY EXTRACT(EPOCH FROM first_response_at - applied_at) / 86400
+  ) FILTER (WHERE first_response_at IS NOT NULL))::float8 AS median_days_to_response
+FROM funnel;
+
+-- name: GetApplicationsPerWeek :many
+-- Weeks start on Monday, in UTC. Weeks without applications are left out
+SELECT
+  (date_trunc('week', applied_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS week,
+  COUNT(*) AS count
+FROM applications
+WHERE user_id = $1
+  AND applied_at >= $2
+GROUP BY week
+ORDER BY week ASC;
+
+-- name: GetApplicationStatsBySource :many
+WITH funnel AS (
+  SELECT
+    j.source,
+    (s.kind IN ('interview', 'offer', 'accepted')
+      OR EXISTS (SELECT 1 FROM interviews i WHERE i.application_id = a.id)) AS reached_interview,
+    (s.kind IN ('offer', 'accepted')
+      OR a.offer_date IS NOT NULL
+      OR EXISTS (SELECT 1 FROM offers o WHERE o.application_id = a.id)) AS reached_offer,
+    s.kind = 'accepted' AS accepted
+  FROM applications a
+  JOIN jobs j ON j.id = a.job_id
+  JOIN pipeline_stages s ON s.id = a.stage_id
+  WHERE a.user_id = $1
+)
+SELECT
+  source,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE reached_interview) AS interviewing,
+  COUNT(*) FILTER (WHERE reached_offer) AS offer,
+  COUNT(*) FILTER (WHERE accepted) AS accepted
+FROM funnel
+GROUP BY source
+ORDER BY total DESC, source ASC;
+
+-- name: GetApplicationStatsByCompany :many
+-- The companies the user applied to the most
+WITH funnel AS (
+  SELECT
+    j.company,
+    (s.kind IN ('interview', 'offer', 'accepted')
+      OR EXISTS (SELECT 1 FROM interviews i WHERE i.application_id = a.id)) AS reached_interview,
+    (s.kind IN ('offer', 'accepted')
+      OR a.offer_date IS NOT NULL
+      OR EXISTS (SELECT 1 FROM offers o WHERE o.application_id = a.id)) AS reached_offer,
+    s.kind = 'accepted' AS accepted
+  FROM applications a
+  JOIN jobs j ON j.id = a.job_id
+  JOIN pipeline_stages s ON s.id = a.stage_id
+  WHERE a.user_id = $1
+)
+SELECT
+  company,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE reached_interview) AS interviewing,
+  COUNT(*) FILTER (WHERE reached_offer) AS offer,
+  COUNT(*) FILTER (WHERE accepted) AS accepted
+FROM funnel
+GROUP BY company
+ORDER BY total DESC, company ASC
+LIMIT $2;
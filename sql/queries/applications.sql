@@ -2,91 +2,130 @@
 INSERT INTO applications (
   user_id,
   job_id,
-  notes
+  status,
+  stage_id,
+  applied_at,
+  ghosted_at,
+  first_response_at
 )
-VALUES ($1, $2, $3)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, user_id, job_id, status, applied_at, updated_at, 
-          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date;
+          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at;
 
 -- name: GetApplicationByID :one
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE id = $1;
 
 -- name: GetUserApplications :many
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE user_id = $1
 ORDER BY applied_at DESC;
 
 -- name: GetJobApplications :many
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE job_id = $1
 ORDER BY applied_at DESC;
 
 -- name: GetApplicationByUserAndJob :one
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE user_id = $1 AND job_id = $2;
 
 -- name: UpdateApplication :one
 UPDATE applications
 SET
-  interview_date = COALESCE(sqlc.narg('interview_date'), interview_date),
   offer_date = COALESCE(sqlc.narg('offer_date'), offer_date),
-  notes = COALESCE(sqlc.narg('notes'), notes),
   salary_offer = COALESCE(sqlc.narg('salary_offer'), salary_offer),
   reminder_sent = COALESCE(sqlc.narg('reminder_sent'), reminder_sent),
   follow_up_date = COALESCE(sqlc.narg('follow_up_date'), follow_up_date),
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, user_id, job_id, status, applied_at, updated_at, 
-          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date;
+          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at;
 
 -- name: UpdateApplicationStatus :one
 UPDATE applications
 SET
   status = $2,
+  stage_id = $3,
+  ghosted_at = CASE WHEN $2 = 'ghosted' THEN COALESCE(ghosted_at, NOW()) ELSE NULL END,
+  first_response_at = CASE
+    WHEN $2 IN ('applied', 'withdrawn', 'ghosted') THEN first_response_at
+    ELSE COALESCE(first_response_at, NOW())
+  END,
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, user_id, job_id, status, applied_at, updated_at, 
-          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date;
+          interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at;
 
 -- name: DeleteApplication :exec
 DELETE FROM applications WHERE id = $1;
 
 -- name: GetApplicationsByStatus :many
 SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
+       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date, stage_id, ghosted_at, first_response_at
 FROM applications
 WHERE user_id = $1 AND status = $2
 ORDER BY applied_at DESC;
 
--- name: GetUpcomingInterviews :many
-SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
-FROM applications
-WHERE user_id = $1 
-  AND interview_date IS NOT NULL 
-  AND interview_date >= NOW()
-  AND status = 'interviewing'
-ORDER BY interview_date ASC;
-
--- name: GetPendingReminders :many
-SELECT id, user_id, job_id, status, applied_at, updated_at, 
-       interview_date, offer_date, notes, salary_offer, reminder_sent, follow_up_date
-FROM applications
-WHERE reminder_sent = false
-  AND interview_date IS NOT NULL
-  AND interview_date <= NOW() + INTERVAL '24 hours'
-  AND interview_date >= NOW();
+-- name: MarkGhostedApplications :many
+-- Moves applications with no activity since the cutoff into the ghosted
+-- stage of their pipeline, when the pipeline has one and allows the
+-- transition. Interviews, notes, offers and contact interactions do not
+-- touch applications.updated_at, so they are checked here: an interview
+-- still to come or anything written since the cutoff keeps the application.
+-- previous_status is the key of the stage the application left
+UPDATE applications a
+SET
+  status = g.key,
+  stage_id = g.id,
+  ghosted_at = NOW(),
+  updated_at = NOW()
+FROM pipeline_stages cur
+JOIN pipeline_stages g ON g.pipeline_id = cur.pipeline_id AND g.key = 'ghosted'
+JOIN pipeline_transitions t ON t.from_stage_id = cur.id AND t.to_stage_id = g.id
+WHERE cur.id = a.stage_id
+  AND a.status IN ('applied', 'interviewing')
+  AND a.updated_at < $1
+  AND NOT EXISTS (
+    SELECT 1 FROM interviews i
+    WHERE i.application_id = a.id
+      AND (i.scheduled_at >= $1 OR i.updated_at >= $1)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM notes n
+    WHERE n.application_id = a.id AND n.updated_at >= $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM offers o
+    WHERE o.application_id = a.id AND o.updated_at >= $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM contact_interactions ci
+    WHERE ci.application_id = a.id AND (ci.occurred_at >= $1 OR ci.created_at >= $1)
+  )
+RETURNING a.id, a.user_id, a.job_id, a.status, a.applied_at, a.updated_at,
+          a.interview_date, a.offer_date, a.notes, a.salary_offer, a.reminder_sent, a.follow_up_date, a.stage_id, a.ghosted_at, a.first_response_at,
+          cur.key AS previous_status;
 
--- name: MarkReminderSent :exec
-UPDATE applications
-SET reminder_sent = true, updated_at = NOW()
-WHERE id = $1;
+-- name: ListApplicationsForExport :many
+-- Applications of a user joined with their job, optionally filtered by
+-- status and by an applied_at range [applied_from, applied_to)
+SELECT a.id, a.status, a.applied_at, a.updated_at, a.interview_date, a.offer_date,
+       a.follow_up_date, a.notes, a.salary_offer,
+       j.title AS job_title, j.company AS job_company, j.location AS job_location,
+       j.link AS job_link, j.salary_range AS job_salary_range
+FROM applications a
+JOIN jobs j ON j.id = a.job_id
+WHERE a.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('statuses')::text[] IS NULL OR a.status = ANY(sqlc.narg('statuses')::text[]))
+  AND (sqlc.narg('applied_from')::timestamptz IS NULL OR a.applied_at >= sqlc.narg('applied_from'))
+  AND (sqlc.narg('applied_to')::timestamptz IS NULL OR a.applied_at < sqlc.narg('applied_to'))
+ORDER BY a.applied_at DESC;
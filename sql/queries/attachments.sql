@@ -0,0 +1,34 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (
+  id,
+  application_id,
+  user_id,
+  kind,
+  filename,
+  content_type,
+  size_bytes,
+  checksum,
+  storage_key
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, application_id, user_id, kind, filename, content_type, size_bytes, checksum, storage_key, created_at;
+
+-- name: GetAttachmentByID :one
+SELECT id, application_id, user_id, kind, filename, content_type, size_bytes, checksum, storage_key, created_at
+FROM attachments
+WHERE id = $1;
+
+-- name: GetApplicationAttachments :many
+SELECT id, application_id, user_id, kind, filename, content_type, size_bytes, checksum, storage_key, created_at
+FROM attachments
+WHERE application_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments WHERE id = $1;
+
+-- name: GetUserAttachments :many
+SELECT id, application_id, user_id, kind, filename, content_type, size_bytes, checksum, storage_key, created_at
+FROM attachments
+WHERE user_id = $1
+ORDER BY created_at;
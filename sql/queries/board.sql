@@ -0,0 +1,48 @@
+-- name: ListBoardCards :many
+-- Cards without a manual position come first, newest applications on top.
+-- The next interview is the earliest pending round still to come
+SELECT
+  a.id,
+  a.job_id,
+  a.status,
+  a.stage_id,
+  a.applied_at,
+  a.updated_at,
+  (
+    SELECT MIN(i.scheduled_at)
+    FROM interviews i
+    WHERE i.application_id = a.id
+      AND i.outcome = 'pending'
+      AND i.scheduled_at > NOW()
+  )::timestamptz AS next_interview_at,
+  a.follow_up_date,
+  j.title AS job_title,
+  j.company AS job_company,
+  j.location AS job_location,
+  j.link AS job_link,
+  j.source AS job_source
+FROM applications a
+JOIN jobs j ON j.id = a.job_id
+LEFT JOIN application_board_positions p ON p.application_id = a.id AND p.status = a.status
+WHERE a.user_id = $1
+ORDER BY a.status ASC, p.position ASC NULLS FIRST, a.applied_at DESC;
+
+-- name: LockBoardColumn :many
+-- Returns the column in board order and locks its applications, so
+-- concurrent moves into the same column are serialized
+SELECT a.id
+FROM applications a
+LEFT JOIN application_board_positions p ON p.application_id = a.id AND p.status = a.status
+WHERE a.user_id = $1 AND a.status = $2
+ORDER BY p.position ASC NULLS FIRST, a.applied_at DESC
+FOR UPDATE OF a;
+
+-- name: SetBoardPositions :exec
+-- Numbers the given applications from zero in the order of the array
+INSERT INTO application_board_positions (application_id, status, position)
+SELECT t.id::uuid, $1, (t.ord - 1)::integer
+FROM unnest($2::text[]) WITH ORDINALITY AS t(id, ord)
+ON CONFLICT (application_id) DO UPDATE
+SET status = EXCLUDED.status,
+    position = EXCLUDED.position,
+    updated_at = NOW();
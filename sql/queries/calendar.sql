@@ -0,0 +1,61 @@
+-- name: UpsertCalendarFeed :one
+-- Issuing a new token replaces the previous one, revoking the old URL
+INSERT INTO calendar_feeds (user_id, token_hash)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash,
+    created_at = NOW(),
+    last_accessed_at = NULL
+RETURNING user_id, token_hash, created_at, last_accessed_at;
+
+-- name: GetCalendarFeedByUser :one
+SELECT user_id, token_hash, created_at, last_accessed_at
+FROM calendar_feeds
+WHERE user_id = $1;
+
+-- name: GetCalendarFeedByTokenHash :one
+SELECT user_id, token_hash, created_at, last_accessed_at
+FROM calendar_feeds
+WHERE token_hash = $1;
+
+-- name: TouchCalendarFeed :exec
+UPDATE calendar_feeds
+SET last_accessed_at = NOW()
+WHERE user_id = $1;
+
+-- name: DeleteCalendarFeed :exec
+DELETE FROM calendar_feeds
+WHERE user_id = $1;
+
+-- name: ListCalendarInterviews :many
+-- Interview rounds of the user scheduled after since, with their job
+SELECT i.id, i.application_id, i.round_name, i.type, i.scheduled_at, i.duration_minutes,
+       i.location, i.meeting_link, i.outcome, i.updated_at,
+       j.title AS job_title, j.company AS job_company, j.link AS job_link
+FROM interviews i
+JOIN applications a ON a.id = i.application_id
+JOIN jobs j ON j.id = a.job_id
+WHERE a.user_id = sqlc.arg('user_id')
+  AND i.scheduled_at >= sqlc.arg('since')
+ORDER BY i.scheduled_at ASC;
+
+-- name: ListCalendarApplications :many
+-- Applications with an interview date or follow-up date after since. An
+-- interview date already tracked as an interview round is left out so the
+-- feed does not list it twice
+SELECT a.id, a.status, a.updated_at,
+       CASE
+         WHEN a.interview_date >= sqlc.arg('since') AND NOT EXISTS (
+           SELECT 1 FROM interviews i
+           WHERE i.application_id = a.id AND i.scheduled_at = a.interview_date
+         ) THEN a.interview_date
+       END::timestamptz AS interview_date,
+       CASE WHEN a.follow_up_date >= sqlc.arg('since') THEN a.follow_up_date END::timestamptz AS follow_up_date,
+       s.is_terminal,
+       j.title AS job_title, j.company AS job_company, j.link AS job_link
+FROM applications a
+JOIN jobs j ON j.id = a.job_id
+JOIN pipeline_stages s ON s.id = a.stage_id
+WHERE a.user_id = sqlc.arg('user_id')
+  AND (a.interview_date >= sqlc.arg('since') OR a.follow_up_date >= sqlc.arg('since'))
+ORDER BY a.applied_at ASC;
@@ -0,0 +1,71 @@
+-- name: CreateContact :one
+INSERT INTO contacts (user_id, name, email, linkedin_url, role, company, notes)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, name, email, linkedin_url, role, company, notes, created_at, updated_at;
+
+-- name: GetContactByID :one
+SELECT id, user_id, name, email, linkedin_url, role, company, notes, created_at, updated_at
+FROM contacts
+WHERE id = $1;
+
+-- name: GetUserContacts :many
+-- Contacts with the date of the last interaction, optionally only those of
+-- a company
+SELECT
+  c.id, c.user_id, c.name, c.email, c.linkedin_url, c.role, c.company, c.notes, c.created_at, c.updated_at,
+  (SELECT MAX(i.occurred_at) FROM contact_interactions i WHERE i.contact_id = c.id)::timestamptz AS last_interaction_at
+FROM contacts c
+WHERE c.user_id = $1
+  AND (sqlc.narg('company')::text IS NULL OR LOWER(c.company) = LOWER(sqlc.narg('company')))
+ORDER BY c.name ASC;
+
+-- name: GetApplicationContacts :many
+SELECT
+  c.id, c.user_id, c.name, c.email, c.linkedin_url, c.role, c.company, c.notes, c.created_at, c.updated_at,
+  (SELECT MAX(i.occurred_at) FROM contact_interactions i WHERE i.contact_id = c.id)::timestamptz AS last_interaction_at
+FROM contacts c
+JOIN application_contacts ac ON ac.contact_id = c.id
+WHERE ac.application_id = $1
+ORDER BY c.name ASC;
+
+-- name: GetContactApplicationIDs :many
+SELECT application_id
+FROM application_contacts
+WHERE contact_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdateContact :one
+UPDATE contacts
+SET
+  name = $2,
+  email = $3,
+  linkedin_url = $4,
+  role = $5,
+  company = $6,
+  notes = $7,
+  updated_at = NOW()
+WHERE id = $1
+RETURNING id, user_id, name, email, linkedin_url, role, company, notes, created_at, updated_at;
+
+-- name: DeleteContact :exec
+DELETE FROM contacts WHERE id = $1;
+
+-- name: LinkApplicationContact :exec
+INSERT INTO application_contacts (application_id, contact_id)
+VALUES ($1, $2)
+ON CONFLICT (application_id, contact_id) DO NOTHING;
+
+-- name: UnlinkApplicationContact :execrows
+DELETE FROM application_contacts
+WHERE application_id = $1 AND contact_id = $2;
+
+-- name: CreateContactInteraction :one
+INSERT INTO contact_interactions (contact_id, application_id, type, occurred_at, summary)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, contact_id, application_id, type, occurred_at, summary, created_at;
+
+-- name: GetContactInteractions :many
+SELECT id, contact_id, application_id, type, occurred_at, summary, created_at
+FROM contact_interactions
+WHERE contact_id = $1
+ORDER BY occurred_at DESC;
@@ -0,0 +1,60 @@
+-- name: CreateInterview :one
+INSERT INTO interviews (
+  application_id,
+  round_name,
+  type,
+  scheduled_at,
+  duration_minutes,
+  interviewers,
+  location,
+  meeting_link,
+  notes
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, application_id, round_name, type, scheduled_at, duration_minutes, interviewers,
+          location, meeting_link, outcome, notes, reminder_sent, created_at, updated_at;
+
+-- name: GetInterviewByID :one
+SELECT id, application_id, round_name, type, scheduled_at, duration_minutes, interviewers,
+       location, meeting_link, outcome, notes, reminder_sent, created_at, updated_at
+FROM interviews
+WHERE id = $1;
+
+-- name: GetApplicationInterviews :many
+SELECT id, application_id, round_name, type, scheduled_at, duration_minutes, interviewers,
+       location, meeting_link, outcome, notes, reminder_sent, created_at, updated_at
+FROM interviews
+WHERE application_id = $1
+ORDER BY scheduled_at ASC;
+
+-- name: UpdateInterview :one
+UPDATE interviews
+SET
+  round_name = COALESCE(sqlc.narg('round_name'), round_name),
+  type = COALESCE(sqlc.narg('type'), type),
+  scheduled_at = COALESCE(sqlc.narg('scheduled_at'), scheduled_at),
+  duration_minutes = COALESCE(sqlc.narg('duration_minutes'), duration_minutes),
+  interviewers = COALESCE(sqlc.narg('interviewers'), interviewers),
+  location = COALESCE(sqlc.narg('location'), location),
+  meeting_link = COALESCE(sqlc.narg('meeting_link'), meeting_link),
+  outcome = COALESCE(sqlc.narg('outcome'), outcome),
+  notes = COALESCE(sqlc.narg('notes'), notes),
+  reminder_sent = CASE WHEN sqlc.narg('scheduled_at') IS NULL THEN reminder_sent ELSE false END,
+  updated_at = NOW()
+WHERE id = $1
+RETURNING id, application_id, round_name, type, scheduled_at, duration_minutes, interviewers,
+          location, meeting_link, outcome, notes, reminder_sent, created_at, updated_at;
+
+-- name: DeleteInterview :exec
+DELETE FROM interviews WHERE id = $1;
+
+-- name: GetUpcomingInterviews :many
+SELECT i.id, i.application_id, i.round_name, i.type, i.scheduled_at, i.duration_minutes, i.interviewers,
+       i.location, i.meeting_link, i.outcome, i.notes, i.reminder_sent, i.created_at, i.updated_at
+FROM interviews i
+JOIN applications a ON a.id = i.application_id
+WHERE a.user_id = $1
+  AND i.outcome = 'pending'
+  AND i.scheduled_at >= NOW()
+  AND i.scheduled_at <= $2
+ORDER BY i.scheduled_at ASC;
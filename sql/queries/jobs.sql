@@ -8,21 +8,22 @@ INSERT INTO jobs (
   requirements, 
   source, 
   link, 
-  posted_date
+  posted_date,
+  created_by
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id, title, company, location, description, salary_range, requirements, 
-          source, link, posted_date, scraped_at, is_active, created_at, updated_at;
+          source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by;
 
 -- name: GetJobByID :one
 SELECT id, title, company, location, description, salary_range, requirements, 
-       source, link, posted_date, scraped_at, is_active, created_at, updated_at
+       source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 FROM jobs
 WHERE id = $1;
 
 -- name: GetJobByLink :one
 SELECT id, title, company, location, description, salary_range, requirements, 
-       source, link, posted_date, scraped_at, is_active, created_at, updated_at
+       source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 FROM jobs
 WHERE link = $1;
 
@@ -42,14 +43,14 @@ SET
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, title, company, location, description, salary_range, requirements, 
-          source, link, posted_date, scraped_at, is_active, created_at, updated_at;
+          source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by;
 
 -- name: DeleteJob :exec
 DELETE FROM jobs WHERE id = $1;
 
 -- name: ListJobs :many
 SELECT id, title, company, location, description, salary_range, requirements, 
-       source, link, posted_date, scraped_at, is_active, created_at, updated_at
+       source, link, posted_date, scraped_at, is_active, created_at, updated_at, created_by
 FROM jobs
 WHERE 
   (sqlc.narg('title')::TEXT IS NULL OR title ILIKE '%' || sqlc.narg('title')::TEXT || '%')
@@ -0,0 +1,45 @@
+-- name: GetLoginThrottles :many
+SELECT key, failures, last_failure_at, locked_until
+FROM login_throttles
+WHERE key = ANY(sqlc.arg('keys')::TEXT[]);
+
+-- name: RecordLoginFailure :one
+-- Counts a failure, starting over when the previous one is older than
+-- window_start
+INSERT INTO login_throttles (key, failures, last_failure_at)
+VALUES ($1, 1, sqlc.arg('failed_at'))
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+      WHEN login_throttles.last_failure_at < sqlc.arg('window_start') THEN 1
+      ELSE login_throttles.failures + 1
+    END,
+    last_failure_at = sqlc.arg('failed_at')
+RETURNING failures;
+
+-- name: LockLogin :exec
+UPDATE login_throttles
+SET locked_until = $2
+WHERE key = $1;
+
+-- name: ResetLoginThrottle :exec
+DELETE FROM login_throttles
+WHERE key = $1;
+
+-- name: CreateLoginEvent :exec
+INSERT INTO login_events (user_id, email, ip_address, user_agent, event, reason)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListLoginEvents :many
+SELECT id, user_id, email, ip_address, user_agent, event, reason, created_at
+FROM login_events
+WHERE
+  (sqlc.narg('email')::TEXT IS NULL OR email = sqlc.narg('email')::TEXT)
+  AND (sqlc.narg('user_id')::UUID IS NULL OR user_id = sqlc.narg('user_id')::UUID)
+  AND (sqlc.narg('ip_address')::TEXT IS NULL OR ip_address = sqlc.narg('ip_address')::TEXT)
+  AND (sqlc.narg('event')::TEXT IS NULL OR event = sqlc.narg('event')::TEXT)
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: DeleteLoginHistory :exec
+DELETE FROM login_events
+WHERE user_id = $1 OR email = $2;
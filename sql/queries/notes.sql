@@ -0,0 +1,48 @@
+-- name: CreateNote :one
+INSERT INTO notes (application_id, user_id, body)
+VALUES ($1, $2, $3)
+RETURNING id, application_id, user_id, body, created_at, updated_at;
+
+-- name: GetNoteByID :one
+SELECT id, application_id, user_id, body, created_at, updated_at
+FROM notes
+WHERE id = $1;
+
+-- name: GetApplicationNotes :many
+SELECT id, application_id, user_id, body, created_at, updated_at
+FROM notes
+WHERE application_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetUserNotes :many
+SELECT id, application_id, user_id, body, created_at, updated_at
+FROM notes
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateNote :one
+UPDATE notes
+SET body = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, application_id, user_id, body, created_at, updated_at;
+
+-- name: DeleteNote :exec
+DELETE FROM notes WHERE id = $1;
+
+-- name: SearchNotes :many
+-- Web search syntax: quoted phrases, OR and -excluded words. Matches are
+-- wrapped in ** so snippets render as bold markdown
+SELECT
+  n.id,
+  n.application_id,
+  n.user_id,
+  n.body,
+  n.created_at,
+  n.updated_at,
+  ts_headline('simple', n.body, q, 'StartSel=**, StopSel=**, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet,
+  ts_rank(to_tsvector('simple', n.body), q)::float8 AS rank
+FROM notes n, websearch_to_tsquery('simple', sqlc.arg('query')) q
+WHERE n.user_id = $1
+  AND to_tsvector('simple', n.body) @@ q
+ORDER BY rank DESC, n.created_at DESC
+LIMIT sqlc.arg('limit');
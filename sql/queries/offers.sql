@@ -0,0 +1,65 @@
+-- name: CreateOffer :one
+INSERT INTO offers (
+  application_id,
+  user_id,
+  currency,
+  base_salary,
+  pay_period,
+  bonus,
+  signing_bonus,
+  equity_value,
+  equity_vesting_years,
+  benefits,
+  benefits_value,
+  respond_by,
+  location,
+  work_mode,
+  notes
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING id, application_id, user_id, currency, base_salary, pay_period, bonus, signing_bonus, equity_value, equity_vesting_years,
+          benefits, benefits_value, respond_by, location, work_mode, notes, created_at, updated_at;
+
+-- name: GetOfferByApplication :one
+SELECT id, application_id, user_id, currency, base_salary, pay_period, bonus, signing_bonus, equity_value, equity_vesting_years,
+       benefits, benefits_value, respond_by, location, work_mode, notes, created_at, updated_at
+FROM offers
+WHERE application_id = $1;
+
+-- name: ListUserOffers :many
+-- Offers with the job they are for, those with the closest deadline first
+SELECT
+  o.id, o.application_id, o.user_id, o.currency, o.base_salary, o.pay_period, o.bonus, o.signing_bonus, o.equity_value,
+  o.equity_vesting_years, o.benefits, o.benefits_value, o.respond_by, o.location, o.work_mode, o.notes, o.created_at, o.updated_at,
+  a.status AS application_status,
+  j.title AS job_title,
+  j.company AS job_company
+FROM offers o
+JOIN applications a ON a.id = o.application_id
+JOIN jobs j ON j.id = a.job_id
+WHERE o.user_id = $1
+ORDER BY o.respond_by ASC NULLS LAST, o.created_at DESC;
+
+-- name: UpdateOffer :one
+UPDATE offers
+SET
+  currency = $2,
+  base_salary = $3,
+  pay_period = $4,
+  bonus = $5,
+  signing_bonus = $6,
+  equity_value = $7,
+  equity_vesting_years = $8,
+  benefits = $9,
+  benefits_value = $10,
+  respond_by = $11,
+  location = $12,
+  work_mode = $13,
+  notes = $14,
+  updated_at = NOW()
+WHERE id = $1
+RETURNING id, application_id, user_id, currency, base_salary, pay_period, bonus, signing_bonus, equity_value, equity_vesting_years,
+          benefits, benefits_value, respond_by, location, work_mode, notes, created_at, updated_at;
+
+-- name: DeleteOffer :exec
+DELETE FROM offers WHERE id = $1;
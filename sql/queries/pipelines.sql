@@ -0,0 +1,61 @@
+-- name: CreatePipeline :one
+INSERT INTO pipelines (user_id, name, is_default)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, is_default, created_at, updated_at;
+
+-- name: CreatePipelineStage :one
+INSERT INTO pipeline_stages (pipeline_id, key, name, position, is_terminal, kind)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, pipeline_id, key, name, position, is_terminal, kind;
+
+-- name: CreatePipelineTransition :exec
+INSERT INTO pipeline_transitions (pipeline_id, from_stage_id, to_stage_id)
+VALUES ($1, $2, $3);
+
+-- name: GetPipelineByID :one
+SELECT id, user_id, name, is_default, created_at, updated_at
+FROM pipelines
+WHERE id = $1;
+
+-- name: GetPipelineByStageID :one
+SELECT p.id, p.user_id, p.name, p.is_default, p.created_at, p.updated_at
+FROM pipelines p
+JOIN pipeline_stages s ON s.pipeline_id = p.id
+WHERE s.id = $1;
+
+-- name: GetUserPipelines :many
+SELECT id, user_id, name, is_default, created_at, updated_at
+FROM pipelines
+WHERE user_id = $1 OR user_id IS NULL
+ORDER BY user_id NULLS FIRST, created_at ASC;
+
+-- name: GetDefaultPipeline :one
+SELECT id, user_id, name, is_default, created_at, updated_at
+FROM pipelines
+WHERE is_default = true AND (user_id = $1 OR user_id IS NULL)
+ORDER BY user_id NULLS LAST
+LIMIT 1;
+
+-- name: ClearUserDefaultPipeline :exec
+UPDATE pipelines
+SET is_default = false, updated_at = NOW()
+WHERE user_id = $1 AND is_default = true;
+
+-- name: SetPipelineDefault :exec
+UPDATE pipelines
+SET is_default = true, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetPipelineStages :many
+SELECT id, pipeline_id, key, name, position, is_terminal, kind
+FROM pipeline_stages
+WHERE pipeline_id = $1
+ORDER BY position ASC;
+
+-- name: GetPipelineTransitions :many
+SELECT pipeline_id, from_stage_id, to_stage_id
+FROM pipeline_transitions
+WHERE pipeline_id = $1;
+
+-- name: DeletePipeline :exec
+DELETE FROM pipelines WHERE id = $1;
@@ -0,0 +1,113 @@
+-- name: ListDueInterviewReminders :many
+-- Pending interviews starting before due_before that have not been reminded
+-- yet and are not currently claimed by another worker
+SELECT i.id, i.application_id, a.user_id, u.email AS user_email, u.name AS user_name,
+       u.locale AS user_locale, u.muted_notifications AS user_muted_notifications,
+       j.title AS job_title, j.company AS job_company,
+       i.round_name, i.type, i.scheduled_at, i.duration_minutes, i.location, i.meeting_link
+FROM interviews i
+JOIN applications a ON a.id = i.application_id
+JOIN users u ON u.id = a.user_id
+JOIN jobs j ON j.id = a.job_id
+WHERE i.outcome = 'pending'
+  AND i.scheduled_at >= NOW()
+  AND i.scheduled_at <= sqlc.arg('due_before')
+  AND NOT EXISTS (
+    SELECT 1 FROM reminder_deliveries d
+    WHERE d.kind = 'interview_reminder'
+      AND d.source_id = i.id
+      AND d.due_at = i.scheduled_at
+      AND (d.status = 'sent' OR d.claimed_at >= sqlc.arg('stale_before'))
+  )
+ORDER BY i.scheduled_at ASC
+LIMIT sqlc.arg('batch_size');
+
+-- name: ListDueFollowUps :many
+-- Applications whose follow-up date has passed, still in a non terminal
+-- stage and not followed up for that date yet
+SELECT a.id, a.user_id, u.email AS user_email, u.name AS user_name,
+       u.locale AS user_locale, u.muted_notifications AS user_muted_notifications,
+       j.title AS job_title, j.company AS job_company,
+       a.status, a.follow_up_date
+FROM applications a
+JOIN users u ON u.id = a.user_id
+JOIN jobs j ON j.id = a.job_id
+JOIN pipeline_stages s ON s.id = a.stage_id
+WHERE a.follow_up_date IS NOT NULL
+  AND a.follow_up_date <= NOW()
+  AND s.is_terminal = false
+  AND NOT EXISTS (
+    SELECT 1 FROM reminder_deliveries d
+    WHERE d.kind = 'follow_up'
+      AND d.source_id = a.id
+      AND d.due_at = a.follow_up_date
+      AND (d.status = 'sent' OR d.claimed_at >= sqlc.arg('stale_before'))
+  )
+ORDER BY a.follow_up_date ASC
+LIMIT sqlc.arg('batch_size');
+
+-- name: ClaimReminder :execrows
+-- Takes a reminder for this worker. Affects no rows when it was already sent
+-- or another worker holds a claim newer than stale_before
+INSERT INTO reminder_deliveries (kind, source_id, due_at, user_id, claimed_at)
+VALUES (sqlc.arg('kind'), sqlc.arg('source_id'), sqlc.arg('due_at'), sqlc.arg('user_id'), NOW())
+ON CONFLICT (kind, source_id, due_at) DO UPDATE
+SET claimed_at = NOW(), attempts = reminder_deliveries.attempts + 1
+WHERE reminder_deliveries.status = 'claimed'
+  AND (reminder_deliveries.claimed_at IS NULL OR reminder_deliveries.claimed_at < sqlc.arg('stale_before'));
+
+-- name: MarkReminderDelivered :exec
+UPDATE reminder_deliveries
+SET status = 'sent', sent_at = NOW(), claimed_at = NULL
+WHERE kind = $1 AND source_id = $2 AND due_at = $3;
+
+-- name: ReleaseReminder :exec
+-- Drops the claim so the reminder is retried on the next run
+UPDATE reminder_deliveries
+SET claimed_at = NULL
+WHERE kind = $1 AND source_id = $2 AND due_at = $3 AND status = 'claimed';
+
+-- name: MarkInterviewReminderSent :exec
+UPDATE interviews
+SET reminder_sent = true
+WHERE id = $1 AND scheduled_at = $2;
+
+-- name: ListDigestApplications :many
+-- Applications sent or moved since period_start, of users whose weekly
+-- digest for period_end was not sent and is not claimed by another worker
+SELECT a.user_id, u.email AS user_email, u.name AS user_name,
+       u.locale AS user_locale, u.muted_notifications AS user_muted_notifications,
+       a.applied_at, a.updated_at, s.kind AS stage_kind
+FROM applications a
+JOIN users u ON u.id = a.user_id
+JOIN pipeline_stages s ON s.id = a.stage_id
+WHERE (a.applied_at >= sqlc.arg('period_start') OR a.updated_at >= sqlc.arg('period_start'))
+  AND NOT EXISTS (
+    SELECT 1 FROM reminder_deliveries d
+    WHERE d.kind = 'weekly_digest'
+      AND d.source_id = a.user_id
+      AND d.due_at = sqlc.arg('period_end')
+      AND (d.status = 'sent' OR d.claimed_at >= sqlc.arg('stale_before'))
+  )
+ORDER BY a.user_id;
+
+-- name: ListDigestInterviews :many
+-- Interview rounds from period_start to upcoming_end that were not
+-- cancelled, for the same users as ListDigestApplications
+SELECT a.user_id, u.email AS user_email, u.name AS user_name,
+       u.locale AS user_locale, u.muted_notifications AS user_muted_notifications,
+       i.scheduled_at
+FROM interviews i
+JOIN applications a ON a.id = i.application_id
+JOIN users u ON u.id = a.user_id
+WHERE i.outcome <> 'cancelled'
+  AND i.scheduled_at >= sqlc.arg('period_start')
+  AND i.scheduled_at < sqlc.arg('upcoming_end')
+  AND NOT EXISTS (
+    SELECT 1 FROM reminder_deliveries d
+    WHERE d.kind = 'weekly_digest'
+      AND d.source_id = a.user_id
+      AND d.due_at = sqlc.arg('period_end')
+      AND (d.status = 'sent' OR d.claimed_at >= sqlc.arg('stale_before'))
+  )
+ORDER BY a.user_id;
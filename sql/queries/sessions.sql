@@ -0,0 +1,43 @@
+-- name: CreateSession :one
+INSERT INTO sessions (family_id, user_id, token_hash, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, family_id, user_id, token_hash, user_agent, ip_address, created_at,
+          expires_at, rotated_at, revoked_at;
+
+-- name: GetSessionByTokenHash :one
+SELECT id, family_id, user_id, token_hash, user_agent, ip_address, created_at, expires_at,
+       rotated_at, revoked_at
+FROM sessions
+WHERE token_hash = $1;
+
+-- name: MarkSessionRotated :execrows
+-- Only one caller can rotate a refresh token, a second one gets no rows
+UPDATE sessions
+SET rotated_at = NOW()
+WHERE id = $1
+  AND rotated_at IS NULL
+  AND revoked_at IS NULL;
+
+-- name: RevokeSessionFamily :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE family_id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = $1
+  AND revoked_at IS NULL;
+
+-- name: IsSessionFamilyActive :one
+-- A family is active while its latest refresh token is neither rotated,
+-- revoked nor expired
+SELECT EXISTS (
+  SELECT 1 FROM sessions
+  WHERE family_id = $1
+    AND rotated_at IS NULL
+    AND revoked_at IS NULL
+    AND expires_at > NOW()
+) AS active;
@@ -0,0 +1,13 @@
+-- name: GetUserByIdentity :one
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at
+FROM users
+WHERE id = (
+  SELECT user_id FROM user_identities
+  WHERE issuer = $1 AND subject = $2
+);
+
+-- name: CreateUserIdentity :exec
+-- A subject already linked keeps its user
+INSERT INTO user_identities (user_id, issuer, subject, email)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (issuer, subject) DO NOTHING;
@@ -0,0 +1,19 @@
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (user_id, purpose, token_hash, email, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, purpose, token_hash, email, created_at, expires_at, used_at;
+
+-- name: ConsumeUserToken :one
+-- Marks the token as used and returns it, provided it has the purpose and
+-- was neither used nor expired
+UPDATE user_tokens
+SET used_at = NOW()
+WHERE token_hash = $1
+  AND purpose = $2
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING id, user_id, purpose, token_hash, email, created_at, expires_at, used_at;
+
+-- name: DeleteUserTokens :exec
+DELETE FROM user_tokens
+WHERE user_id = $1 AND purpose = $2;
@@ -1,15 +1,15 @@
 -- name: CreateUser :one
 INSERT INTO users (name, email, password_hash, role)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, email, password_hash, role, created_at, updated_at;
+RETURNING id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at;
 
 -- name: GetUserByID :one 
-SELECT id, name, email, password_hash, role, created_at, updated_at 
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at 
 FROM users 
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, name, email, password_hash, role, created_at, updated_at 
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at 
 FROM users 
 WHERE email = $1;
 
@@ -19,9 +19,15 @@ SET
   name = COALESCE(sqlc.narg('name'), name),
   email = COALESCE(sqlc.narg('email'), email),
   password_hash = COALESCE(sqlc.narg('password_hash'), password_hash),
+  locale = COALESCE(sqlc.narg('locale'), locale),
+  muted_notifications = COALESCE(sqlc.narg('muted_notifications'), muted_notifications),
+  email_verified_at = CASE
+    WHEN sqlc.narg('email') IS NOT NULL AND sqlc.narg('email') <> email THEN NULL
+    ELSE email_verified_at
+  END,
   updated_at = NOW()
 WHERE id = $1
-RETURNING id, name, email, password_hash, role, created_at, updated_at;
+RETURNING id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at;
 
 -- name: DeleteUser :exec
 DELETE FROM users WHERE id = $1; 
@@ -29,3 +35,21 @@ DELETE FROM users WHERE id = $1;
 
 
 
+
+-- name: ListUsers :many
+SELECT id, name, email, password_hash, role, created_at, updated_at, locale, muted_notifications, email_verified_at
+FROM users
+ORDER BY created_at ASC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateUserRole :execrows
+UPDATE users
+SET role = $2,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: MarkEmailVerified :execrows
+UPDATE users
+SET email_verified_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND email = $2;
@@ -0,0 +1,87 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (user_id, url, secret, event_types)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, url, secret, event_types, is_active, created_at, updated_at;
+
+-- name: GetWebhookEndpointByID :one
+SELECT id, user_id, url, secret, event_types, is_active, created_at, updated_at
+FROM webhook_endpoints
+WHERE id = $1;
+
+-- name: GetUserWebhookEndpoints :many
+SELECT id, user_id, url, secret, event_types, is_active, created_at, updated_at
+FROM webhook_endpoints
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET
+  url = COALESCE(sqlc.narg('url'), url),
+  event_types = COALESCE(sqlc.narg('event_types'), event_types),
+  is_active = COALESCE(sqlc.narg('is_active'), is_active),
+  updated_at = NOW()
+WHERE id = $1
+RETURNING id, user_id, url, secret, event_types, is_active, created_at, updated_at;
+
+-- name: DeleteWebhookEndpoint :exec
+DELETE FROM webhook_endpoints WHERE id = $1;
+
+-- name: EnqueueWebhookEvent :execrows
+-- Queues a delivery for every active endpoint of the user subscribed to the
+-- event type
+INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload)
+SELECT e.id, sqlc.arg('event_id')::uuid, sqlc.arg('event_type')::text, sqlc.arg('payload')::text
+FROM webhook_endpoints e
+WHERE e.is_active = true
+  AND sqlc.arg('event_type')::text = ANY(e.event_types)
+  AND e.user_id = sqlc.arg('user_id');
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+          last_attempt_at, response_status, last_error, created_at, completed_at;
+
+-- name: GetWebhookDeliveryByID :one
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+       last_attempt_at, response_status, last_error, created_at, completed_at
+FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: GetEndpointWebhookDeliveries :many
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+       last_attempt_at, response_status, last_error, created_at, completed_at
+FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Pushes next_attempt_at to lease_until so concurrent workers skip the rows
+-- while they are being sent. A worker that dies leaves them due again once
+-- the lease expires
+UPDATE webhook_deliveries d
+SET next_attempt_at = sqlc.arg('lease_until'), attempts = d.attempts + 1, last_attempt_at = NOW()
+FROM webhook_endpoints e
+WHERE e.id = d.endpoint_id
+  AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at ASC
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.endpoint_id, d.event_id, d.event_type, d.payload, d.attempts, e.url, e.secret;
+
+-- name: CompleteWebhookDelivery :exec
+-- Records the outcome of an attempt. Pending deliveries are retried at
+-- next_attempt_at
+UPDATE webhook_deliveries
+SET
+  status = sqlc.arg('status'),
+  response_status = sqlc.narg('response_status'),
+  last_error = sqlc.narg('last_error'),
+  next_attempt_at = sqlc.arg('next_attempt_at'),
+  completed_at = CASE WHEN sqlc.arg('status') = 'pending' THEN NULL ELSE NOW() END
+WHERE id = sqlc.arg('id');
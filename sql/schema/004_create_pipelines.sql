@@ -0,0 +1,88 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS pipelines (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  -- NULL user_id marks a system pipeline shared by every user
+  user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  is_default BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  pipeline_id UUID NOT NULL REFERENCES pipelines(id) ON DELETE CASCADE,
+  key TEXT NOT NULL,
+  name TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  is_terminal BOOLEAN NOT NULL DEFAULT false,
+
+  -- Stage keys are what applications store as their status
+  CONSTRAINT unique_pipeline_stage_key UNIQUE(pipeline_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS pipeline_transitions (
+  pipeline_id UUID NOT NULL REFERENCES pipelines(id) ON DELETE CASCADE,
+  from_stage_id UUID NOT NULL REFERENCES pipeline_stages(id) ON DELETE CASCADE,
+  to_stage_id UUID NOT NULL REFERENCES pipeline_stages(id) ON DELETE CASCADE,
+  PRIMARY KEY (from_stage_id, to_stage_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipelines_user_id ON pipelines(user_id);
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_pipeline_id ON pipeline_stages(pipeline_id, position);
+CREATE INDEX IF NOT EXISTS idx_pipeline_transitions_pipeline_id ON pipeline_transitions(pipeline_id);
+
+-- Only one default pipeline per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pipelines_user_default ON pipelines(user_id) WHERE is_default;
+
+-- Seed the system pipeline with the stages previously hard-coded in the application domain
+INSERT INTO pipelines (id, user_id, name, is_default)
+VALUES ('00000000-0000-0000-0000-000000000001', NULL, 'Default', true);
+
+INSERT INTO pipeline_stages (id, pipeline_id, key, name, position, is_terminal)
+VALUES
+  ('00000000-0000-0000-0000-000000000101', '00000000-0000-0000-0000-000000000001', 'applied', 'Applied', 0, false),
+  ('00000000-0000-0000-0000-000000000102', '00000000-0000-0000-0000-000000000001', 'interviewing', 'Interviewing', 1, false),
+  ('00000000-0000-0000-0000-000000000103', '00000000-0000-0000-0000-000000000001', 'offer', 'Offer', 2, false),
+  ('00000000-0000-0000-0000-000000000104', '00000000-0000-0000-0000-000000000001', 'accepted', 'Accepted', 3, true),
+  ('00000000-0000-0000-0000-000000000105', '00000000-0000-0000-0000-000000000001', 'rejected', 'Rejected', 4, true);
+
+INSERT INTO pipeline_transitions (pipeline_id, from_stage_id, to_stage_id)
+VALUES
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000101', '00000000-0000-0000-0000-000000000102'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000101', '00000000-0000-0000-0000-000000000105'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000102', '00000000-0000-0000-0000-000000000103'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000102', '00000000-0000-0000-0000-000000000105'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000103', '00000000-0000-0000-0000-000000000104'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000103', '00000000-0000-0000-0000-000000000105');
+
+-- Applications now reference a stage; status keeps the stage key for readability
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS valid_status;
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS stage_id UUID;
+
+UPDATE applications a
+SET stage_id = s.id
+FROM pipeline_stages s
+WHERE s.pipeline_id = '00000000-0000-0000-0000-000000000001' AND s.key = a.status;
+
+ALTER TABLE applications ALTER COLUMN stage_id SET NOT NULL;
+ALTER TABLE applications ADD CONSTRAINT fk_applications_stage
+  FOREIGN KEY (stage_id) REFERENCES pipeline_stages(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_applications_stage_id ON applications(stage_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_stage_id;
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS fk_applications_stage;
+ALTER TABLE applications DROP COLUMN IF EXISTS stage_id;
+-- Custom stages have no legacy status, their applications go back to applied
+UPDATE applications SET status = 'applied' WHERE status NOT IN ('applied', 'interviewing', 'offer', 'rejected', 'accepted');
+ALTER TABLE applications ADD CONSTRAINT valid_status CHECK (status IN ('applied', 'interviewing', 'offer', 'rejected', 'accepted'));
+
+DROP INDEX IF EXISTS idx_pipelines_user_default;
+DROP INDEX IF EXISTS idx_pipeline_transitions_pipeline_id;
+DROP INDEX IF EXISTS idx_pipeline_stages_pipeline_id;
+DROP INDEX IF EXISTS idx_pipelines_user_id;
+DROP TABLE IF EXISTS pipeline_transitions;
+DROP TABLE IF EXISTS pipeline_stages;
+DROP TABLE IF EXISTS pipelines;
@@ -0,0 +1,36 @@
+-- +goose Up
+INSERT INTO pipeline_stages (id, pipeline_id, key, name, position, is_terminal)
+VALUES
+  ('00000000-0000-0000-0000-000000000106', '00000000-0000-0000-0000-000000000001', 'withdrawn', 'Withdrawn', 5, true),
+  ('00000000-0000-0000-0000-000000000107', '00000000-0000-0000-0000-000000000001', 'ghosted', 'Ghosted', 6, false);
+
+INSERT INTO pipeline_transitions (pipeline_id, from_stage_id, to_stage_id)
+VALUES
+  -- applied, interviewing and offer can be withdrawn by the candidate
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000101', '00000000-0000-0000-0000-000000000106'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000102', '00000000-0000-0000-0000-000000000106'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000103', '00000000-0000-0000-0000-000000000106'),
+  -- applied and interviewing can go silent
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000101', '00000000-0000-0000-0000-000000000107'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000102', '00000000-0000-0000-0000-000000000107'),
+  -- ghosted is reversible: the company may still answer
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000107', '00000000-0000-0000-0000-000000000101'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000107', '00000000-0000-0000-0000-000000000102'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000107', '00000000-0000-0000-0000-000000000105'),
+  ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000107', '00000000-0000-0000-0000-000000000106');
+
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS ghosted_at TIMESTAMPTZ;
+
+-- Ghost detection scans stale applications by last update
+CREATE INDEX IF NOT EXISTS idx_applications_status_updated_at ON applications(status, updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_status_updated_at;
+ALTER TABLE applications DROP COLUMN IF EXISTS ghosted_at;
+
+UPDATE applications
+SET status = 'applied', stage_id = '00000000-0000-0000-0000-000000000101'
+WHERE stage_id IN ('00000000-0000-0000-0000-000000000106', '00000000-0000-0000-0000-000000000107');
+
+DELETE FROM pipeline_stages
+WHERE id IN ('00000000-0000-0000-0000-000000000106', '00000000-0000-0000-0000-000000000107');
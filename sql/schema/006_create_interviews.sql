@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS interviews (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+  round_name TEXT NOT NULL,
+  type TEXT NOT NULL,
+  scheduled_at TIMESTAMPTZ NOT NULL,
+  duration_minutes INTEGER NOT NULL DEFAULT 60,
+  interviewers TEXT[] NOT NULL DEFAULT '{}',
+  location TEXT,
+  meeting_link TEXT,
+  outcome TEXT NOT NULL DEFAULT 'pending',
+  notes TEXT,
+  reminder_sent BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT valid_interview_type CHECK (type IN ('phone', 'technical', 'onsite', 'other')),
+  CONSTRAINT valid_interview_outcome CHECK (outcome IN ('pending', 'passed', 'failed', 'cancelled')),
+  CONSTRAINT positive_interview_duration CHECK (duration_minutes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interviews_application_id ON interviews(application_id, scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_interviews_scheduled_at ON interviews(scheduled_at) WHERE outcome = 'pending';
+
+-- Carry over the single interview date tracked on applications so far
+INSERT INTO interviews (application_id, round_name, type, scheduled_at, reminder_sent)
+SELECT id, 'Interview', 'other', interview_date, reminder_sent
+FROM applications
+WHERE interview_date IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_interviews_scheduled_at;
+DROP INDEX IF EXISTS idx_interviews_application_id;
+DROP TABLE IF EXISTS interviews;
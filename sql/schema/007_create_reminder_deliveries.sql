@@ -0,0 +1,26 @@
+-- +goose Up
+-- Ledger of reminders handed to a notifier. A row is keyed by what it
+-- reminds about and when that was due, so rescheduling an interview or
+-- moving a follow-up date produces a new reminder instead of being
+-- swallowed by the old one. Workers claim rows with a lease; a claim older
+-- than the lease is considered abandoned and can be taken over.
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+  kind TEXT NOT NULL,
+  source_id UUID NOT NULL,
+  due_at TIMESTAMPTZ NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'claimed',
+  attempts INTEGER NOT NULL DEFAULT 1,
+  claimed_at TIMESTAMPTZ,
+  sent_at TIMESTAMPTZ,
+
+  PRIMARY KEY (kind, source_id, due_at),
+  CONSTRAINT valid_reminder_kind CHECK (kind IN ('interview_reminder', 'follow_up')),
+  CONSTRAINT valid_reminder_status CHECK (status IN ('claimed', 'sent'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_deliveries_user_id ON reminder_deliveries(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_reminder_deliveries_user_id;
+DROP TABLE IF EXISTS reminder_deliveries;
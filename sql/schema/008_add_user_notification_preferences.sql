@@ -0,0 +1,9 @@
+-- +goose Up
+-- Locale picks the language of notifications, muted_notifications lists the
+-- notification kinds the user opted out of. Everything is on by default
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS muted_notifications TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS muted_notifications;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- One private iCalendar feed per user. Only a hash of the token is kept, the
+-- feed URL is shown once when the token is issued
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  token_hash TEXT UNIQUE NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_accessed_at TIMESTAMPTZ
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS calendar_feeds;
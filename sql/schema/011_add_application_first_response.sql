@@ -0,0 +1,16 @@
+-- +goose Up
+-- When the company first answered: the first move out of applied into any
+-- stage other than withdrawn or ghosted, which are not answers
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS first_response_at TIMESTAMPTZ;
+
+-- Without a status history the last update is the best estimate for
+-- applications already past the applied stage
+UPDATE applications
+SET first_response_at = updated_at
+WHERE status NOT IN ('applied', 'withdrawn', 'ghosted');
+
+CREATE INDEX IF NOT EXISTS idx_applications_user_applied_at ON applications(user_id, applied_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_user_applied_at;
+ALTER TABLE applications DROP COLUMN IF EXISTS first_response_at;
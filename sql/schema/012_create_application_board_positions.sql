@@ -0,0 +1,14 @@
+-- +goose Up
+-- Manual order of the cards in a board column. A position only applies
+-- while the application stays in the status it was set for, so moving an
+-- application to another column by a plain status update drops it to the
+-- top of the new column
+CREATE TABLE IF NOT EXISTS application_board_positions (
+  application_id UUID PRIMARY KEY REFERENCES applications(id) ON DELETE CASCADE,
+  status TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS application_board_positions;
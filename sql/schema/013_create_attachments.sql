@@ -0,0 +1,24 @@
+-- +goose Up
+-- Documents sent with an application. The content lives in the blob store
+-- under storage_key, rows only keep what is needed to list and serve it
+CREATE TABLE IF NOT EXISTS attachments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  kind TEXT NOT NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  checksum TEXT NOT NULL,
+  storage_key TEXT UNIQUE NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT valid_attachment_kind CHECK (kind IN ('resume', 'cover_letter', 'other')),
+  CONSTRAINT positive_attachment_size CHECK (size_bytes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_application_id ON attachments(application_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_attachments_application_id;
+DROP TABLE IF EXISTS attachments;
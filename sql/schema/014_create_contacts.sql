@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS contacts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  email TEXT,
+  linkedin_url TEXT,
+  role TEXT,
+  company TEXT,
+  notes TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_contacts_user_id ON contacts(user_id, name);
+
+-- Who is involved in each application, a contact can take part in several
+CREATE TABLE IF NOT EXISTS application_contacts (
+  application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+  contact_id UUID NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (application_id, contact_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_contacts_contact_id ON application_contacts(contact_id);
+
+-- Log of exchanges with a contact, optionally about one application
+CREATE TABLE IF NOT EXISTS contact_interactions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  contact_id UUID NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+  application_id UUID REFERENCES applications(id) ON DELETE SET NULL,
+  type TEXT NOT NULL,
+  occurred_at TIMESTAMPTZ NOT NULL,
+  summary TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT valid_interaction_type CHECK (type IN ('email_sent', 'email_received', 'call', 'message', 'meeting', 'other'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_interactions_contact_id ON contact_interactions(contact_id, occurred_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_contact_interactions_contact_id;
+DROP TABLE IF EXISTS contact_interactions;
+DROP INDEX IF EXISTS idx_application_contacts_contact_id;
+DROP TABLE IF EXISTS application_contacts;
+DROP INDEX IF EXISTS idx_contacts_user_id;
+DROP TABLE IF EXISTS contacts;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Timeline of markdown notes per application, replacing the single notes
+-- field that each update overwrote
+CREATE TABLE IF NOT EXISTS notes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT non_empty_note_body CHECK (length(btrim(body)) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notes_application_id ON notes(application_id, created_at DESC);
+
+-- The simple configuration does not stem, so searches behave the same
+-- whatever language the notes are written in. Queries must use the same
+-- expression for the index to apply
+CREATE INDEX IF NOT EXISTS idx_notes_search ON notes USING GIN (to_tsvector('simple', body));
+
+-- Carry over the notes kept on applications so far
+INSERT INTO notes (application_id, user_id, body, created_at, updated_at)
+SELECT id, user_id, notes, updated_at, updated_at
+FROM applications
+WHERE notes IS NOT NULL AND length(btrim(notes)) > 0;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notes_search;
+DROP INDEX IF EXISTS idx_notes_application_id;
+DROP TABLE IF EXISTS notes;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- Structured compensation of an offer, one per application. Amounts are in
+-- whole units of the offer currency
+CREATE TABLE IF NOT EXISTS offers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  application_id UUID UNIQUE NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  currency TEXT NOT NULL,
+  base_salary BIGINT NOT NULL,
+  pay_period TEXT NOT NULL DEFAULT 'year',
+  bonus BIGINT NOT NULL DEFAULT 0,
+  signing_bonus BIGINT NOT NULL DEFAULT 0,
+  equity_value BIGINT NOT NULL DEFAULT 0,
+  equity_vesting_years INTEGER NOT NULL DEFAULT 4,
+  benefits TEXT[] NOT NULL DEFAULT '{}',
+  benefits_value BIGINT NOT NULL DEFAULT 0,
+  respond_by TIMESTAMPTZ,
+  location TEXT,
+  work_mode TEXT,
+  notes TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT valid_offer_currency CHECK (currency ~ '^[A-Z]{3}$'),
+  CONSTRAINT valid_offer_pay_period CHECK (pay_period IN ('year', 'month', 'hour')),
+  CONSTRAINT valid_offer_work_mode CHECK (work_mode IS NULL OR work_mode IN ('remote', 'hybrid', 'onsite')),
+  CONSTRAINT non_negative_offer_amounts CHECK (
+    base_salary > 0 AND bonus >= 0 AND signing_bonus >= 0 AND equity_value >= 0 AND benefits_value >= 0
+  ),
+  CONSTRAINT positive_offer_vesting CHECK (equity_vesting_years > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_offers_user_id ON offers(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_offers_user_id;
+DROP TABLE IF EXISTS offers;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Roles drive the permissions checked by the API. Accounts registered
+-- without a role get the default one. The first admin is promoted by hand:
+--   UPDATE users SET role = 'admin' WHERE email = '...';
+UPDATE users SET role = 'candidate'
+WHERE role NOT IN ('candidate', 'recruiter', 'admin');
+
+ALTER TABLE users ADD CONSTRAINT users_role_check
+  CHECK (role IN ('candidate', 'recruiter', 'admin'));
+
+-- +goose Down
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Who posted a job through the API. Scraped and imported jobs have no
+-- owner, so only admins see their applicants
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_created_by ON jobs(created_by);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_jobs_created_by;
+ALTER TABLE jobs DROP COLUMN IF EXISTS created_by;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- TOTP second factor. A row without enabled_at is an enrollment waiting
+-- for its first code. The secret has to be readable to check codes, so
+-- unlike passwords and tokens it cannot be hashed. last_used_step keeps a
+-- code from being accepted twice
+CREATE TABLE IF NOT EXISTS user_totp (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  secret TEXT NOT NULL,
+  last_used_step BIGINT NOT NULL DEFAULT 0,
+  enabled_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One time codes for when the authenticator is lost, stored hashed
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  code_hash TEXT NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (user_id, code_hash)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
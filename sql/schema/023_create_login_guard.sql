@@ -0,0 +1,31 @@
+-- +goose Up
+-- Failed login counters, one row per email ("email:<address>") and per
+-- client address ("ip:<address>"). The count starts over when the last
+-- failure is older than the tracking window
+CREATE TABLE IF NOT EXISTS login_throttles (
+  key TEXT PRIMARY KEY,
+  failures INT NOT NULL DEFAULT 0,
+  last_failure_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  locked_until TIMESTAMPTZ
+);
+
+-- Audit log of login attempts. email is what was typed, so failures for
+-- unknown accounts are kept too
+CREATE TABLE IF NOT EXISTS login_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  email TEXT NOT NULL,
+  ip_address TEXT,
+  user_agent TEXT,
+  event TEXT NOT NULL CHECK (event IN ('success', 'failure', 'lockout')),
+  reason TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_events_created_at ON login_events(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_events_email ON login_events(email, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_events_user_id ON login_events(user_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS login_events;
+DROP TABLE IF EXISTS login_throttles;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Accounts their owner asked to delete. The account is purged once
+-- scheduled_for has passed, unless the owner cancels before
+CREATE TABLE IF NOT EXISTS account_deletions (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  scheduled_for TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_scheduled_for ON account_deletions(scheduled_for);
+
+-- +goose Down
+DROP TABLE IF EXISTS account_deletions;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Transitions must join two stages of their own pipeline. Drop the ones
+-- that do not before the constraints go in
+DELETE FROM pipeline_transitions t
+USING pipeline_stages f, pipeline_stages s
+WHERE f.id = t.from_stage_id
+  AND s.id = t.to_stage_id
+  AND (f.pipeline_id <> t.pipeline_id OR s.pipeline_id <> t.pipeline_id);
+
+ALTER TABLE pipeline_stages
+  ADD CONSTRAINT unique_pipeline_stage_id UNIQUE (pipeline_id, id);
+
+ALTER TABLE pipeline_transitions
+  ADD CONSTRAINT fk_pipeline_transitions_from_stage
+    FOREIGN KEY (pipeline_id, from_stage_id) REFERENCES pipeline_stages(pipeline_id, id) ON DELETE CASCADE,
+  ADD CONSTRAINT fk_pipeline_transitions_to_stage
+    FOREIGN KEY (pipeline_id, to_stage_id) REFERENCES pipeline_stages(pipeline_id, id) ON DELETE CASCADE;
+
+-- +goose Down
+ALTER TABLE pipeline_transitions
+  DROP CONSTRAINT IF EXISTS fk_pipeline_transitions_to_stage,
+  DROP CONSTRAINT IF EXISTS fk_pipeline_transitions_from_stage;
+ALTER TABLE pipeline_stages DROP CONSTRAINT IF EXISTS unique_pipeline_stage_id;
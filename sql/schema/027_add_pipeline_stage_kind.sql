@@ -0,0 +1,15 @@
+-- +goose Up
+-- The kind says what a stage means regardless of its key, so stats and
+-- offers work with custom pipelines. Existing stages are matched by key
+ALTER TABLE pipeline_stages ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'active';
+
+UPDATE pipeline_stages
+SET kind = CASE key WHEN 'interviewing' THEN 'interview' ELSE key END
+WHERE key IN ('interviewing', 'offer', 'accepted', 'rejected', 'withdrawn', 'ghosted');
+
+ALTER TABLE pipeline_stages ADD CONSTRAINT valid_pipeline_stage_kind
+  CHECK (kind IN ('active', 'interview', 'offer', 'accepted', 'rejected', 'withdrawn', 'ghosted'));
+
+-- +goose Down
+ALTER TABLE pipeline_stages DROP CONSTRAINT IF EXISTS valid_pipeline_stage_kind;
+ALTER TABLE pipeline_stages DROP COLUMN IF EXISTS kind;
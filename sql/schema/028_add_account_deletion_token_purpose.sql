@@ -0,0 +1,12 @@
+-- +goose Up
+-- Accounts created through an identity provider have no password the user
+-- knows, they confirm the deletion with a mailed token instead
+ALTER TABLE user_tokens DROP CONSTRAINT IF EXISTS user_tokens_purpose_check;
+ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check
+  CHECK (purpose IN ('password_reset', 'email_verification', 'account_deletion'));
+
+-- +goose Down
+DELETE FROM user_tokens WHERE purpose = 'account_deletion';
+ALTER TABLE user_tokens DROP CONSTRAINT IF EXISTS user_tokens_purpose_check;
+ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check
+  CHECK (purpose IN ('password_reset', 'email_verification'));